@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/permission"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// GrantedByUser is Grant.GrantedBy of the permission which is granted to the user directly.
+const GrantedByUser = "user"
+
+// UserPermissions represents a user's effective permissions.
+type UserPermissions struct {
+	Username string `json:"username"`
+	// the permissions which are granted to the user directly
+	Permissions []string `json:"permissions"`
+	// the map of the user's role names to the roles' permissions
+	RolePermissions map[string][]string `json:"role_permissions"`
+}
+
+// Roles returns the user's role names sorted by the name.
+func (up *UserPermissions) Roles() []string {
+	roles := make([]string, 0, len(up.RolePermissions))
+	for role := range up.RolePermissions {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// Effective returns the user's effective permissions sorted and without duplicates.
+func (up *UserPermissions) Effective() []string {
+	perms := set.NewStrSet(up.Permissions...)
+	for _, p := range up.RolePermissions {
+		perms.Adds(p...)
+	}
+	arr := perms.ToList()
+	sort.Strings(arr)
+	return arr
+}
+
+// Grant represents that a user is permitted by a permission.
+type Grant struct {
+	Username string `json:"username"`
+	// GrantedByUser or the role name
+	GrantedBy string `json:"granted_by"`
+	// the granted permission which implies the required permission
+	// ex. "streams:*"
+	Permission string `json:"permission"`
+}
+
+// Grants returns how the user is permitted a required permission.
+// If the user isn't permitted, an empty slice is returned.
+func (up *UserPermissions) Grants(required string) ([]Grant, error) {
+	r, err := permission.Parse(required)
+	if err != nil {
+		return nil, err
+	}
+	grants := []Grant{}
+	add := func(grantedBy string, perms []string) {
+		for _, s := range perms {
+			if p, err := permission.Parse(s); err == nil && p.Implies(r) {
+				grants = append(grants, Grant{
+					Username: up.Username, GrantedBy: grantedBy, Permission: s})
+			}
+		}
+	}
+	add(GrantedByUser, up.Permissions)
+	for _, role := range up.Roles() {
+		add(role, up.RolePermissions[role])
+	}
+	return grants, nil
+}
+
+// Can returns whether the user is permitted a required permission.
+func (up *UserPermissions) Can(required string) (bool, error) {
+	grants, err := up.Grants(required)
+	return len(grants) != 0, err
+}
+
+// Audit is the users' effective permissions.
+type Audit struct {
+	// sorted by the user name
+	Users []UserPermissions `json:"users"`
+}
+
+// New computes the users' effective permissions.
+// members is the map of role names to the role's members' names.
+// The roles which are in a user's Roles or members are the user's roles.
+func New(users []graylog.User, roles []graylog.Role, members map[string][]string) *Audit {
+	rolePerms := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		perms := role.Permissions.ToList()
+		sort.Strings(perms)
+		rolePerms[role.Name] = perms
+	}
+	userRoles := map[string]set.StrSet{}
+	for _, user := range users {
+		userRoles[user.Username] = user.Roles.Clone()
+	}
+	for role, names := range members {
+		for _, name := range names {
+			if r, ok := userRoles[name]; ok {
+				r.Add(role)
+			}
+		}
+	}
+	a := &Audit{Users: make([]UserPermissions, len(users))}
+	for i, user := range users {
+		up := UserPermissions{
+			Username:        user.Username,
+			Permissions:     user.Permissions.ToList(),
+			RolePermissions: map[string][]string{},
+		}
+		sort.Strings(up.Permissions)
+		for role := range userRoles[user.Username] {
+			// the permissions of an unknown role are empty
+			perms := rolePerms[role]
+			if perms == nil {
+				perms = []string{}
+			}
+			up.RolePermissions[role] = perms
+		}
+		a.Users[i] = up
+	}
+	sort.Slice(a.Users, func(i, j int) bool {
+		return a.Users[i].Username < a.Users[j].Username
+	})
+	return a
+}
+
+// Collect gets the users, the roles and the roles' members with a client
+// and computes the users' effective permissions.
+func Collect(ctx context.Context, cl *client.Client) (*Audit, error) {
+	users, _, err := cl.GetUsersContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %s", err)
+	}
+	roles, _, _, err := cl.GetRolesContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %s", err)
+	}
+	members := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		m, _, err := cl.GetRoleMembersContext(ctx, role.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the members of the role %s: %s", role.Name, err)
+		}
+		names := make([]string, len(m))
+		for i, user := range m {
+			names[i] = user.Username
+		}
+		members[role.Name] = names
+	}
+	return New(users, roles, members), nil
+}
+
+// User returns a user's effective permissions.
+// If the user isn't found, nil is returned.
+func (a *Audit) User(name string) *UserPermissions {
+	for i, up := range a.Users {
+		if up.Username == name {
+			return &a.Users[i]
+		}
+	}
+	return nil
+}
+
+// WhoIsPermitted returns the grants of the users who are permitted a required permission.
+func (a *Audit) WhoIsPermitted(required string) ([]Grant, error) {
+	grants := []Grant{}
+	for _, up := range a.Users {
+		g, err := up.Grants(required)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, g...)
+	}
+	return grants, nil
+}
+
+// WhoCan returns the grants of the users who can do an action to a resource.
+// If the id is empty, the grants to do the action to all resources of the type are returned.
+//
+//   // who can edit the stream?
+//   grants, err := a.WhoCan(audit.ResourceTypeStream, "edit", streamID)
+//   // who can read all streams?
+//   grants, err = a.WhoCan(audit.ResourceTypeStream, "read", "")
+func (a *Audit) WhoCan(typ ResourceType, action, id string) ([]Grant, error) {
+	return a.WhoIsPermitted(requiredPermission(typ, action, id))
+}
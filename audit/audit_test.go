@@ -0,0 +1,143 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/audit"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+func newAudit() *audit.Audit {
+	users := []graylog.User{
+		{Username: "admin", Permissions: set.NewStrSet("*")},
+		{Username: "foo", Permissions: set.NewStrSet("streams:read:s1"),
+			Roles: set.NewStrSet("Editor")},
+		{Username: "bar"},
+	}
+	roles := []graylog.Role{
+		{Name: "Editor", Permissions: set.NewStrSet("streams:read,edit:s2", "indexsets:*")},
+		{Name: "Reader", Permissions: set.NewStrSet("*:read")},
+	}
+	return audit.New(users, roles, map[string][]string{"Reader": {"bar"}})
+}
+
+func TestNew(t *testing.T) {
+	a := newAudit()
+	if len(a.Users) != 3 {
+		t.Fatalf("len(a.Users) == %d, wanted 3", len(a.Users))
+	}
+	bar := a.User("bar")
+	if bar == nil {
+		t.Fatal(`the user "bar" should be found`)
+	}
+	if roles := bar.Roles(); len(roles) != 1 || roles[0] != "Reader" {
+		t.Fatalf("bar.Roles() == %v, wanted [Reader]", roles)
+	}
+	foo := a.User("foo")
+	if exp, act := 3, len(foo.Effective()); act != exp {
+		t.Fatalf("len(foo.Effective()) == %d, wanted %d", act, exp)
+	}
+	if a.User("baz") != nil {
+		t.Fatal(`the user "baz" should not be found`)
+	}
+}
+
+func TestCan(t *testing.T) {
+	foo := newAudit().User("foo")
+	data := []struct {
+		perm string
+		exp  bool
+	}{
+		{"streams:read:s1", true},
+		{"streams:edit:s1", false},
+		{"streams:edit:s2", true},
+		{"indexsets:delete:i1", true},
+		{"dashboards:read:d1", false},
+	}
+	for _, d := range data {
+		ok, err := foo.Can(d.perm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != d.exp {
+			t.Fatalf(`foo.Can("%s") == %t, wanted %t`, d.perm, ok, d.exp)
+		}
+	}
+	if _, err := foo.Can(""); err == nil {
+		t.Fatal("the permission is invalid")
+	}
+}
+
+func TestWhoCan(t *testing.T) {
+	grants, err := newAudit().WhoCan(audit.ResourceTypeStream, "edit", "s2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("len(grants) == %d, wanted 2: %v", len(grants), grants)
+	}
+	if g := grants[1]; g.Username != "foo" || g.GrantedBy != "Editor" ||
+		g.Permission != "streams:read,edit:s2" {
+		t.Fatalf("grants[1] == %+v", g)
+	}
+	grants, err = newAudit().WhoCan(audit.ResourceTypeDashboard, "read", "d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// admin and bar
+	if len(grants) != 2 {
+		t.Fatalf("len(grants) == %d, wanted 2: %v", len(grants), grants)
+	}
+	// who can read all streams? foo can read only the stream s1
+	grants, err = newAudit().WhoCan(audit.ResourceTypeStream, "read", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grants) != 2 || grants[0].Username != "admin" || grants[1].Username != "bar" {
+		t.Fatalf("grants == %v, wanted the grants of admin and bar", grants)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	role := &graylog.Role{
+		Name: "StreamReader", Permissions: set.NewStrSet("streams:read")}
+	if _, err := client.CreateRole(role); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddUserToRole("nobody", role.Name); err != nil {
+		t.Fatal(err)
+	}
+	a, err := audit.Collect(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nobody := a.User("nobody")
+	if nobody == nil {
+		t.Fatal(`the user "nobody" should be found`)
+	}
+	ok, err := nobody.Can("streams:read:foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatalf("nobody should be able to read streams: %+v", nobody)
+	}
+	resources, err := audit.CollectResources(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) == 0 {
+		t.Fatal("the seed stream and index set should be collected")
+	}
+}
@@ -0,0 +1,13 @@
+/*
+Package audit computes the users' effective permissions and reports who can access Graylog's resources.
+
+A user's effective permissions are the user's own permissions and the permissions of the user's roles.
+The permissions are matched with Graylog's wildcard permission semantics,
+so "streams:*" grants the access to all streams.
+
+  cl, err := client.NewClient("http://localhost:9000/api", "admin", "admin")
+  a, err := audit.Collect(context.Background(), cl)
+  // who can edit the stream?
+  grants, err := a.WhoCan(audit.ResourceTypeStream, "edit", streamID)
+*/
+package audit
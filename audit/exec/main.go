@@ -0,0 +1,162 @@
+// Report who can access Graylog's resources.
+//
+// Usage
+//   $ graylog-audit [--endpoint <api url>] [--auth-name <name>] [--auth-password <password>] [--format json|csv] [--type streams|indexsets|dashboards] [--id <resource id>] [--action <action>] [--dashboard <dashboard id>]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog/audit"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+const version = "0.2.0"
+
+var help string
+
+func init() {
+	help = fmt.Sprintf(`
+graylog-audit - Report who can access Graylog's resources.
+
+USAGE:
+   graylog-audit [options]
+
+VERSION:
+   %s
+
+EXAMPLE:
+   # who can edit the stream?
+   graylog-audit --type streams --id 5b39fd6e0b2f0f0001d0e4b4 --action edit
+
+OPTIONS:
+   --endpoint value       Graylog API's endpoint url. The environment variable GRAYLOG_WEB_ENDPOINT_URI is used by default.
+   --auth-name value      the user name or access token. The environment variable GRAYLOG_AUTH_NAME is used by default.
+   --auth-password value  the password, "token" or "session". The environment variable GRAYLOG_AUTH_PASSWORD is used by default.
+   --format value         the report format, "json" or "csv". (default: "json")
+   --type value           the audited resource type, "streams", "indexsets" or "dashboards". If this option is not set, all streams and index sets are audited.
+   --id value             the audited resource id. This option requires --type.
+   --action value         the audited action such as "read" and "edit". If this option is not set, the resource type's default actions are audited.
+   --dashboard value      comma separated dashboard ids which are audited in addition to streams and index sets.
+   --help, -h             show help
+   --version, -v          print the version
+`, version)
+}
+
+type options struct {
+	endpoint, authName, authPassword string
+	format, typ, id, action          string
+	dashboards                       string
+}
+
+func getResources(ctx context.Context, cl *client.Client, opts *options) ([]audit.Resource, error) {
+	if opts.id != "" {
+		if opts.typ == "" {
+			return nil, fmt.Errorf("--id requires --type")
+		}
+		return []audit.Resource{{Type: audit.ResourceType(opts.typ), ID: opts.id}}, nil
+	}
+	resources, err := audit.CollectResources(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	if opts.dashboards != "" {
+		for _, id := range strings.Split(opts.dashboards, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			resources = append(resources, audit.Resource{
+				Type: audit.ResourceTypeDashboard, ID: id})
+		}
+	}
+	if opts.typ == "" {
+		return resources, nil
+	}
+	arr := []audit.Resource{}
+	for _, r := range resources {
+		if r.Type == audit.ResourceType(opts.typ) {
+			arr = append(arr, r)
+		}
+	}
+	return arr, nil
+}
+
+func action(opts *options) error {
+	if opts.format != "json" && opts.format != "csv" {
+		return fmt.Errorf(`invalid format %s. format must be "json" or "csv"`, opts.format)
+	}
+	switch audit.ResourceType(opts.typ) {
+	case "", audit.ResourceTypeStream, audit.ResourceTypeIndexSet, audit.ResourceTypeDashboard:
+	default:
+		return fmt.Errorf(
+			`invalid type %s. type must be "streams", "indexsets" or "dashboards"`, opts.typ)
+	}
+	cl, err := client.NewClient(opts.endpoint, opts.authName, opts.authPassword)
+	if err != nil {
+		return errors.Wrap(err, "failed to create a client")
+	}
+	ctx := context.Background()
+	a, err := audit.Collect(ctx, cl)
+	if err != nil {
+		return err
+	}
+	resources, err := getResources(ctx, cl, opts)
+	if err != nil {
+		return err
+	}
+	actions := []string{}
+	if opts.action != "" {
+		actions = append(actions, opts.action)
+	}
+	report, err := a.Report(resources, actions...)
+	if err != nil {
+		return err
+	}
+	if opts.format == "csv" {
+		return report.WriteCSV(os.Stdout)
+	}
+	return report.WriteJSON(os.Stdout)
+}
+
+func main() {
+	opts := &options{}
+	flag.StringVar(
+		&opts.endpoint, "endpoint", os.Getenv("GRAYLOG_WEB_ENDPOINT_URI"),
+		"Graylog API's endpoint url.")
+	flag.StringVar(
+		&opts.authName, "auth-name", os.Getenv("GRAYLOG_AUTH_NAME"),
+		"the user name or access token.")
+	flag.StringVar(
+		&opts.authPassword, "auth-password", os.Getenv("GRAYLOG_AUTH_PASSWORD"),
+		`the password, "token" or "session".`)
+	flag.StringVar(&opts.format, "format", "json", `the report format, "json" or "csv".`)
+	flag.StringVar(
+		&opts.typ, "type", "", `the audited resource type, "streams", "indexsets" or "dashboards".`)
+	flag.StringVar(&opts.id, "id", "", "the audited resource id.")
+	flag.StringVar(&opts.action, "action", "", `the audited action such as "read" and "edit".`)
+	flag.StringVar(
+		&opts.dashboards, "dashboard", "", "comma separated dashboard ids.")
+	var helpFlag = flag.Bool("help", false, "Show help.")
+	var versionFlag = flag.Bool("version", false, "Print the version.")
+	flag.Parse()
+
+	if *helpFlag {
+		fmt.Println(help)
+		return
+	}
+	if *versionFlag {
+		fmt.Println(version)
+		return
+	}
+
+	if err := action(opts); err != nil {
+		log.Fatal(err)
+	}
+}
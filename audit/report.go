@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// Entry is a row of the report, which represents that a user can do an action to a resource.
+type Entry struct {
+	ResourceType  ResourceType `json:"resource_type"`
+	ResourceID    string       `json:"resource_id"`
+	ResourceTitle string       `json:"resource_title,omitempty"`
+	Action        string       `json:"action"`
+	Grant
+}
+
+// Report represents who can do what to the resources.
+type Report struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Report returns the report of the users who can do the actions to the resources.
+// If actions is empty, the resource type's default actions are audited.
+// The wildcard permissions are expanded to each resource,
+// so a user who has "streams:*" appears in the entries of all streams.
+func (a *Audit) Report(resources []Resource, actions ...string) (*Report, error) {
+	report := &Report{Entries: []Entry{}}
+	for _, resource := range resources {
+		acts := actions
+		if len(acts) == 0 {
+			acts = resource.Type.Actions()
+		}
+		for _, action := range acts {
+			grants, err := a.WhoCan(resource.Type, action, resource.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, grant := range grants {
+				report.Entries = append(report.Entries, Entry{
+					ResourceType: resource.Type, ResourceID: resource.ID,
+					ResourceTitle: resource.Title, Action: action, Grant: grant,
+				})
+			}
+		}
+	}
+	return report, nil
+}
+
+// WriteJSON writes the report as JSON.
+func (report *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteCSV writes the report as CSV with a header.
+func (report *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"resource_type", "resource_id", "resource_title", "action",
+		"username", "granted_by", "permission",
+	}); err != nil {
+		return err
+	}
+	for _, e := range report.Entries {
+		if err := cw.Write([]string{
+			string(e.ResourceType), e.ResourceID, e.ResourceTitle, e.Action,
+			e.Username, e.GrantedBy, e.Permission,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,76 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/audit"
+)
+
+func TestReport(t *testing.T) {
+	resources := []audit.Resource{
+		{Type: audit.ResourceTypeStream, ID: "s1", Title: "stream 1"},
+		{Type: audit.ResourceTypeIndexSet, ID: "i1"},
+	}
+	report, err := newAudit().Report(resources, "edit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// streams:edit:s1 admin, indexsets:edit:i1 admin and foo
+	if len(report.Entries) != 3 {
+		t.Fatalf("len(report.Entries) == %d, wanted 3: %+v", len(report.Entries), report.Entries)
+	}
+	report, err = newAudit().Report(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// streams read: admin, foo, bar; edit, changestate: admin
+	// indexsets read: admin, foo, bar; edit, delete: admin, foo
+	if len(report.Entries) != 12 {
+		t.Fatalf("len(report.Entries) == %d, wanted 12: %+v", len(report.Entries), report.Entries)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	report, err := newAudit().Report(
+		[]audit.Resource{{Type: audit.ResourceTypeStream, ID: "s2"}}, "edit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := report.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	r := &audit.Report{}
+	if err := json.Unmarshal(buf.Bytes(), r); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Entries) != 2 || r.Entries[1].GrantedBy != "Editor" {
+		t.Fatalf("r.Entries == %+v", r.Entries)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	report, err := newAudit().Report(
+		[]audit.Resource{{Type: audit.ResourceTypeStream, ID: "s2"}}, "edit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := report.WriteCSV(buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header and 2 entries
+	if len(records) != 3 {
+		t.Fatalf("len(records) == %d, wanted 3", len(records))
+	}
+	if records[2][4] != "foo" || records[2][6] != "streams:read,edit:s2" {
+		t.Fatalf("records[2] == %v", records[2])
+	}
+}
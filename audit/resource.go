@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+const (
+	// ResourceTypeStream is the resource type of streams.
+	ResourceTypeStream ResourceType = "streams"
+	// ResourceTypeIndexSet is the resource type of index sets.
+	ResourceTypeIndexSet ResourceType = "indexsets"
+	// ResourceTypeDashboard is the resource type of dashboards.
+	ResourceTypeDashboard ResourceType = "dashboards"
+)
+
+// ResourceType is the resource type, which is the first part of the permission string.
+type ResourceType string
+
+// Actions returns the actions of the resource type which are audited by default.
+func (typ ResourceType) Actions() []string {
+	switch typ {
+	case ResourceTypeStream:
+		return []string{"read", "edit", "changestate"}
+	case ResourceTypeIndexSet:
+		return []string{"read", "edit", "delete"}
+	case ResourceTypeDashboard:
+		return []string{"read", "edit"}
+	}
+	return []string{"read", "edit"}
+}
+
+// Resource represents an audited resource.
+type Resource struct {
+	Type  ResourceType `json:"type"`
+	ID    string       `json:"id"`
+	Title string       `json:"title,omitempty"`
+}
+
+// requiredPermission returns the permission string which is required to do an action to a resource.
+// If the id is empty, the permission is required to do the action to all resources of the type.
+func requiredPermission(typ ResourceType, action, id string) string {
+	if id == "" {
+		return fmt.Sprintf("%s:%s", typ, action)
+	}
+	return fmt.Sprintf("%s:%s:%s", typ, action, id)
+}
+
+// CollectResources gets the streams and the index sets with a client.
+// The dashboards aren't collected because the client doesn't support the dashboard API.
+func CollectResources(ctx context.Context, cl *client.Client) ([]Resource, error) {
+	streams, _, _, err := cl.GetStreamsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get streams: %s", err)
+	}
+	indexSets, _, _, _, err := cl.GetIndexSetsContext(ctx, 0, 0, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index sets: %s", err)
+	}
+	resources := make([]Resource, 0, len(streams)+len(indexSets))
+	for _, stream := range streams {
+		resources = append(resources, Resource{
+			Type: ResourceTypeStream, ID: stream.ID, Title: stream.Title})
+	}
+	for _, is := range indexSets {
+		resources = append(resources, Resource{
+			Type: ResourceTypeIndexSet, ID: is.ID, Title: is.Title})
+	}
+	return resources, nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetClusterConfigClasses returns the classes of all cluster configurations.
+func (client *Client) GetClusterConfigClasses() ([]string, *ErrorInfo, error) {
+	return client.GetClusterConfigClassesContext(context.Background())
+}
+
+// GetClusterConfigClassesContext returns the classes of all cluster configurations with a context.
+func (client *Client) GetClusterConfigClassesContext(ctx context.Context) (
+	[]string, *ErrorInfo, error,
+) {
+	// GET /system/cluster_config List all configuration classes
+	classes := &graylog.ClusterConfigClassesBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().ClusterConfigs(), nil, classes)
+	return classes.Classes, ei, err
+}
+
+// GetClusterConfig returns a cluster configuration of a given class.
+// The returned value's type is the typed struct of the class such as *graylog.SearchesClusterConfig,
+// or *graylog.GenericClusterConfig if the class has no typed struct.
+//
+//   cfg, _, err := client.GetClusterConfig(graylog.SearchesClusterConfigClass)
+//   searches := cfg.(*graylog.SearchesClusterConfig)
+func (client *Client) GetClusterConfig(class string) (
+	graylog.ClusterConfig, *ErrorInfo, error,
+) {
+	return client.GetClusterConfigContext(context.Background(), class)
+}
+
+// GetClusterConfigContext returns a cluster configuration of a given class with a context.
+func (client *Client) GetClusterConfigContext(
+	ctx context.Context, class string,
+) (graylog.ClusterConfig, *ErrorInfo, error) {
+	// GET /system/cluster_config/{configClass} Get configuration settings from database
+	if class == "" {
+		return nil, nil, errors.New("config class is empty")
+	}
+	u, err := client.Endpoints().ClusterConfig(class)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := graylog.NewClusterConfig(class)
+	ei, err := client.callGet(ctx, u.String(), nil, cfg)
+	return cfg, ei, err
+}
+
+// UpdateClusterConfig creates or updates a cluster configuration.
+func (client *Client) UpdateClusterConfig(cfg graylog.ClusterConfig) (*ErrorInfo, error) {
+	return client.UpdateClusterConfigContext(context.Background(), cfg)
+}
+
+// UpdateClusterConfigContext creates or updates a cluster configuration with a context.
+func (client *Client) UpdateClusterConfigContext(
+	ctx context.Context, cfg graylog.ClusterConfig,
+) (*ErrorInfo, error) {
+	// PUT /system/cluster_config/{configClass} Update configuration in database
+	if cfg == nil {
+		return nil, errors.New("cluster config is nil")
+	}
+	if cfg.ConfigClass() == "" {
+		return nil, errors.New("config class is empty")
+	}
+	u, err := client.Endpoints().ClusterConfig(cfg.ConfigClass())
+	if err != nil {
+		return nil, err
+	}
+	return client.callPut(ctx, u.String(), cfg, nil)
+}
+
+// DeleteClusterConfig deletes a cluster configuration of a given class.
+// Graylog uses the default configuration of the class after the deletion.
+func (client *Client) DeleteClusterConfig(class string) (*ErrorInfo, error) {
+	return client.DeleteClusterConfigContext(context.Background(), class)
+}
+
+// DeleteClusterConfigContext deletes a cluster configuration of a given class with a context.
+func (client *Client) DeleteClusterConfigContext(
+	ctx context.Context, class string,
+) (*ErrorInfo, error) {
+	// DELETE /system/cluster_config/{configClass} Delete configuration settings from database
+	if class == "" {
+		return nil, errors.New("config class is empty")
+	}
+	u, err := client.Endpoints().ClusterConfig(class)
+	if err != nil {
+		return nil, err
+	}
+	return client.callDelete(ctx, u.String(), nil, nil)
+}
@@ -0,0 +1,108 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetClusterConfigClasses(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	classes, _, err := client.GetClusterConfigClasses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil && len(classes) != 2 {
+		t.Fatalf("len(classes) == %d, wanted 2", len(classes))
+	}
+}
+
+func TestGetClusterConfig(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	cfg, _, err := client.GetClusterConfig(graylog.SearchesClusterConfigClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	searches, ok := cfg.(*graylog.SearchesClusterConfig)
+	if !ok {
+		t.Fatal("SearchesClusterConfig should be returned")
+	}
+	if searches.QueryTimeRangeLimit == "" {
+		t.Fatal("query_time_range_limit is empty")
+	}
+	if _, _, err := client.GetClusterConfig(""); err == nil {
+		t.Fatal("config class is required")
+	}
+}
+
+func TestUpdateClusterConfig(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	cfg := &graylog.MessageProcessorsConfig{
+		ProcessorOrder: []string{
+			"org.graylog2.messageprocessors.MessageFilterChainProcessor",
+			"org.graylog.plugins.pipelineprocessor.processors.PipelineInterpreter",
+		},
+		DisabledProcessors: []string{},
+	}
+	if _, err := client.UpdateClusterConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	c, _, err := client.GetClusterConfig(graylog.MessageProcessorsConfigClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.(*graylog.MessageProcessorsConfig).ProcessorOrder[0] != cfg.ProcessorOrder[0] {
+		t.Fatalf("the cluster config isn't updated: %+v", c)
+	}
+	if _, err := client.UpdateClusterConfig(nil); err == nil {
+		t.Fatal("cluster config is nil")
+	}
+	if _, err := client.UpdateClusterConfig(&graylog.GenericClusterConfig{}); err == nil {
+		t.Fatal("config class is required")
+	}
+}
+
+func TestDeleteClusterConfig(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	cfg := &graylog.GenericClusterConfig{
+		Class:  "org.graylog.plugins.foo.FooConfig",
+		Values: map[string]interface{}{"enabled": true}}
+	if _, err := client.UpdateClusterConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteClusterConfig(cfg.Class); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.GetClusterConfig(cfg.Class); err == nil {
+		t.Fatal("the cluster config should be deleted")
+	}
+	if _, err := client.DeleteClusterConfig(""); err == nil {
+		t.Fatal("config class is required")
+	}
+}
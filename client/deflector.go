@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetDeflector returns a given Index Set's deflector status.
+func (client *Client) GetDeflector(indexSetID string) (
+	*graylog.DeflectorSummary, *ErrorInfo, error,
+) {
+	return client.GetDeflectorContext(context.Background(), indexSetID)
+}
+
+// GetDeflectorContext returns a given Index Set's deflector status with a context.
+func (client *Client) GetDeflectorContext(
+	ctx context.Context, indexSetID string,
+) (*graylog.DeflectorSummary, *ErrorInfo, error) {
+	// GET /system/deflector/{indexSetId} Get current deflector status
+	if indexSetID == "" {
+		return nil, nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().Deflector(indexSetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	deflector := &graylog.DeflectorSummary{}
+	ei, err := client.callGet(ctx, u.String(), nil, deflector)
+	return deflector, ei, err
+}
+
+// CycleDeflector cycles a given Index Set's deflector to a new index.
+// The id of the system job which calculates the old index's range is returned.
+// Graylog doesn't return the id, so the id is empty unless the server is the mock server.
+func (client *Client) CycleDeflector(indexSetID string) (
+	string, *ErrorInfo, error,
+) {
+	return client.CycleDeflectorContext(context.Background(), indexSetID)
+}
+
+// CycleDeflectorContext cycles a given Index Set's deflector to a new index with a context.
+func (client *Client) CycleDeflectorContext(
+	ctx context.Context, indexSetID string,
+) (string, *ErrorInfo, error) {
+	// POST /system/deflector/{indexSetId}/cycle Cycle deflector to new/next index
+	if indexSetID == "" {
+		return "", nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().CycleDeflector(indexSetID)
+	if err != nil {
+		return "", nil, err
+	}
+	job := &systemJobIDBody{}
+	ei, err := client.callPost(ctx, u.String(), nil, job)
+	return job.ID, ei, err
+}
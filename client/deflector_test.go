@@ -0,0 +1,75 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetDeflector(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+	deflector, _, err := client.GetDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deflector.IsUp {
+		t.Fatal("deflector.IsUp should be true")
+	}
+	if deflector.CurrentTarget == "" {
+		t.Fatal("deflector.CurrentTarget is empty")
+	}
+	if _, _, err := client.GetDeflector(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+}
+
+func TestCycleDeflector(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+	before, _, err := client.GetDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobID, _, err := client.CycleDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil && jobID == "" {
+		t.Fatal("the system job id is empty")
+	}
+	after, _, err := client.GetDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.CurrentTarget == after.CurrentTarget {
+		t.Fatalf("the deflector target should be changed: %s", after.CurrentTarget)
+	}
+	if _, _, err := client.CycleDeflector(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+}
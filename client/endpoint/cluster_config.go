@@ -0,0 +1,16 @@
+package endpoint
+
+import (
+	"net/url"
+)
+
+// ClusterConfigs returns Get Cluster Config Classes API's endpoint url.
+func (ep *Endpoints) ClusterConfigs() string {
+	return ep.clusterConfigs.String()
+}
+
+// ClusterConfig returns a Cluster Config API's endpoint url.
+func (ep *Endpoints) ClusterConfig(class string) (*url.URL, error) {
+	// /system/cluster_config/{configClass}
+	return urlJoin(ep.clusterConfigs, class)
+}
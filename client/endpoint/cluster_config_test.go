@@ -0,0 +1,36 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestClusterConfigs(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/cluster_config", apiURL)
+	act := ep.ClusterConfigs()
+	if act != exp {
+		t.Fatalf(`ep.ClusterConfigs() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestClusterConfig(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	class := "org.graylog2.indexer.searches.SearchesClusterConfig"
+	exp := fmt.Sprintf("%s/system/cluster_config/%s", apiURL, class)
+	act, err := ep.ClusterConfig(class)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.ClusterConfig("%s") = "%s", wanted "%s"`, class, act.String(), exp)
+	}
+}
@@ -0,0 +1,18 @@
+package endpoint
+
+import (
+	"net/url"
+	"path"
+)
+
+// Deflector returns Get an Index Set's Deflector status API's endpoint url.
+func (ep *Endpoints) Deflector(indexSetID string) (*url.URL, error) {
+	// /system/deflector/{indexSetId}
+	return urlJoin(ep.deflector, indexSetID)
+}
+
+// CycleDeflector returns Cycle an Index Set's Deflector API's endpoint url.
+func (ep *Endpoints) CycleDeflector(indexSetID string) (*url.URL, error) {
+	// /system/deflector/{indexSetId}/cycle
+	return urlJoin(ep.deflector, path.Join(indexSetID, "cycle"))
+}
@@ -0,0 +1,38 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestDeflector(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/deflector/%s", apiURL, ID)
+	act, err := ep.Deflector(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.Deflector("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+func TestCycleDeflector(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/deflector/%s/cycle", apiURL, ID)
+	act, err := ep.CycleDeflector(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.CycleDeflector("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
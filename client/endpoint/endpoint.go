@@ -15,8 +15,26 @@ type Endpoints struct {
 	roles           *url.URL
 	users           *url.URL
 	inputs          *url.URL
+	inputStates     *url.URL
 	indexSets       *url.URL
 	indexSetStats   *url.URL
+	indices         *url.URL
+	indexRanges     *url.URL
+	deflector       *url.URL
+	systemJobs      *url.URL
+	system          *url.URL
+	clusterNodes    *url.URL
+	clusterNode     *url.URL
+	throughput      *url.URL
+	metrics         *url.URL
+	multipleMetrics *url.URL
+	journal         *url.URL
+	notifications   *url.URL
+	clusterConfigs  *url.URL
+	ldapSettings    *url.URL
+	ldapGroupRoles  *url.URL
+	ldapGroups      *url.URL
+	ldapTest        *url.URL
 	streams         *url.URL
 	enabledStreams  *url.URL
 	alertConditions *url.URL
@@ -43,6 +61,10 @@ func NewEndpoints(endpoint string) (*Endpoints, error) {
 	if err != nil {
 		return nil, err
 	}
+	inputStates, err := urlJoin(ep, "system/inputstates")
+	if err != nil {
+		return nil, err
+	}
 	indexSets, err := urlJoin(ep, "system/indices/index_sets")
 	if err != nil {
 		return nil, err
@@ -51,6 +73,74 @@ func NewEndpoints(endpoint string) (*Endpoints, error) {
 	if err != nil {
 		return nil, err
 	}
+	indices, err := urlJoin(ep, "system/indexer/indices")
+	if err != nil {
+		return nil, err
+	}
+	indexRanges, err := urlJoin(ep, "system/indices/ranges")
+	if err != nil {
+		return nil, err
+	}
+	deflector, err := urlJoin(ep, "system/deflector")
+	if err != nil {
+		return nil, err
+	}
+	systemJobs, err := urlJoin(ep, "system/jobs")
+	if err != nil {
+		return nil, err
+	}
+	system, err := urlJoin(ep, "system")
+	if err != nil {
+		return nil, err
+	}
+	clusterNodes, err := urlJoin(ep, "system/cluster/nodes")
+	if err != nil {
+		return nil, err
+	}
+	clusterNode, err := urlJoin(ep, "system/cluster/node")
+	if err != nil {
+		return nil, err
+	}
+	throughput, err := urlJoin(ep, "system/throughput")
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := urlJoin(ep, "system/metrics")
+	if err != nil {
+		return nil, err
+	}
+	multipleMetrics, err := urlJoin(metrics, "multiple")
+	if err != nil {
+		return nil, err
+	}
+	journal, err := urlJoin(ep, "system/journal")
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := urlJoin(ep, "system/notifications")
+	if err != nil {
+		return nil, err
+	}
+	clusterConfigs, err := urlJoin(ep, "system/cluster_config")
+	if err != nil {
+		return nil, err
+	}
+	ldapSettings, err := urlJoin(ep, "system/ldap/settings")
+	if err != nil {
+		return nil, err
+	}
+	ldapGroupRoles, err := urlJoin(ep, "system/ldap/settings/groups")
+	if err != nil {
+		return nil, err
+	}
+	ldapGroups, err := urlJoin(ep, "system/ldap/groups")
+	if err != nil {
+		return nil, err
+	}
+	ldapTest, err := urlJoin(ep, "system/ldap/test")
+	if err != nil {
+		return nil, err
+	}
 	streams, err := urlJoin(ep, "streams")
 	if err != nil {
 		return nil, err
@@ -64,8 +154,26 @@ func NewEndpoints(endpoint string) (*Endpoints, error) {
 		roles:           roles,
 		users:           users,
 		inputs:          inputs,
+		inputStates:     inputStates,
 		indexSets:       indexSets,
 		indexSetStats:   indexSetStats,
+		indices:         indices,
+		indexRanges:     indexRanges,
+		deflector:       deflector,
+		systemJobs:      systemJobs,
+		system:          system,
+		clusterNodes:    clusterNodes,
+		clusterNode:     clusterNode,
+		throughput:      throughput,
+		metrics:         metrics,
+		multipleMetrics: multipleMetrics,
+		journal:         journal,
+		notifications:   notifications,
+		clusterConfigs:  clusterConfigs,
+		ldapSettings:    ldapSettings,
+		ldapGroupRoles:  ldapGroupRoles,
+		ldapGroups:      ldapGroups,
+		ldapTest:        ldapTest,
 		streams:         streams,
 		enabledStreams:  enabledStreams,
 		alertConditions: alertConditions,
@@ -0,0 +1,48 @@
+package endpoint
+
+import (
+	"net/url"
+	"path"
+)
+
+// Indices returns Get an Index Set's all indices API's endpoint url.
+func (ep *Endpoints) Indices(indexSetID string) (*url.URL, error) {
+	// /system/indexer/indices/{indexSetId}/list
+	return urlJoin(ep.indices, path.Join(indexSetID, "list"))
+}
+
+// OpenIndices returns Get an Index Set's open indices API's endpoint url.
+func (ep *Endpoints) OpenIndices(indexSetID string) (*url.URL, error) {
+	// /system/indexer/indices/{indexSetId}/open
+	return urlJoin(ep.indices, path.Join(indexSetID, "open"))
+}
+
+// ClosedIndices returns Get an Index Set's closed indices API's endpoint url.
+func (ep *Endpoints) ClosedIndices(indexSetID string) (*url.URL, error) {
+	// /system/indexer/indices/{indexSetId}/closed
+	return urlJoin(ep.indices, path.Join(indexSetID, "closed"))
+}
+
+// ReopenedIndices returns Get an Index Set's reopened indices API's endpoint url.
+func (ep *Endpoints) ReopenedIndices(indexSetID string) (*url.URL, error) {
+	// /system/indexer/indices/{indexSetId}/reopened
+	return urlJoin(ep.indices, path.Join(indexSetID, "reopened"))
+}
+
+// Index returns an Index API's endpoint url.
+func (ep *Endpoints) Index(name string) (*url.URL, error) {
+	// /system/indexer/indices/{index}
+	return urlJoin(ep.indices, name)
+}
+
+// CloseIndex returns Close an Index API's endpoint url.
+func (ep *Endpoints) CloseIndex(name string) (*url.URL, error) {
+	// /system/indexer/indices/{index}/close
+	return urlJoin(ep.indices, path.Join(name, "close"))
+}
+
+// ReopenIndex returns Reopen an Index API's endpoint url.
+func (ep *Endpoints) ReopenIndex(name string) (*url.URL, error) {
+	// /system/indexer/indices/{index}/reopen
+	return urlJoin(ep.indices, path.Join(name, "reopen"))
+}
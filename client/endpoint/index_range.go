@@ -0,0 +1,30 @@
+package endpoint
+
+import (
+	"net/url"
+	"path"
+)
+
+// IndexRange returns Get an Index Range API's endpoint url.
+func (ep *Endpoints) IndexRange(name string) (*url.URL, error) {
+	// /system/indices/ranges/{index}
+	return urlJoin(ep.indexRanges, name)
+}
+
+// IndexRanges returns Get Index Ranges API's endpoint url.
+func (ep *Endpoints) IndexRanges() string {
+	// /system/indices/ranges
+	return ep.indexRanges.String()
+}
+
+// RebuildIndexRanges returns Rebuild all Index Ranges API's endpoint url.
+func (ep *Endpoints) RebuildIndexRanges() (*url.URL, error) {
+	// /system/indices/ranges/rebuild
+	return urlJoin(ep.indexRanges, "rebuild")
+}
+
+// RebuildIndexSetIndexRanges returns Rebuild an Index Set's Index Ranges API's endpoint url.
+func (ep *Endpoints) RebuildIndexSetIndexRanges(indexSetID string) (*url.URL, error) {
+	// /system/indices/ranges/index_set/{indexSetId}/rebuild
+	return urlJoin(ep.indexRanges, path.Join("index_set", indexSetID, "rebuild"))
+}
@@ -0,0 +1,64 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestIndexRange(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indices/ranges/%s", apiURL, indexName)
+	act, err := ep.IndexRange(indexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.IndexRange("%s") = "%s", wanted "%s"`, indexName, act.String(), exp)
+	}
+}
+
+func TestIndexRanges(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indices/ranges", apiURL)
+	if ep.IndexRanges() != exp {
+		t.Fatalf(`ep.IndexRanges() = "%s", wanted "%s"`, ep.IndexRanges(), exp)
+	}
+}
+
+func TestRebuildIndexRanges(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indices/ranges/rebuild", apiURL)
+	act, err := ep.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.RebuildIndexRanges() = "%s", wanted "%s"`, act.String(), exp)
+	}
+}
+
+func TestRebuildIndexSetIndexRanges(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indices/ranges/index_set/%s/rebuild", apiURL, ID)
+	act, err := ep.RebuildIndexSetIndexRanges(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.RebuildIndexSetIndexRanges("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
@@ -0,0 +1,115 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestIndices(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s/list", apiURL, ID)
+	act, err := ep.Indices(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.Indices("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+func TestOpenIndices(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s/open", apiURL, ID)
+	act, err := ep.OpenIndices(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.OpenIndices("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+func TestClosedIndices(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s/closed", apiURL, ID)
+	act, err := ep.ClosedIndices(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.ClosedIndices("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+func TestReopenedIndices(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s/reopened", apiURL, ID)
+	act, err := ep.ReopenedIndices(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.ReopenedIndices("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+const indexName = "graylog_0"
+
+func TestIndex(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s", apiURL, indexName)
+	act, err := ep.Index(indexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.Index("%s") = "%s", wanted "%s"`, indexName, act.String(), exp)
+	}
+}
+
+func TestCloseIndex(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s/close", apiURL, indexName)
+	act, err := ep.CloseIndex(indexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.CloseIndex("%s") = "%s", wanted "%s"`, indexName, act.String(), exp)
+	}
+}
+
+func TestReopenIndex(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/indexer/indices/%s/reopen", apiURL, indexName)
+	act, err := ep.ReopenIndex(indexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.ReopenIndex("%s") = "%s", wanted "%s"`, indexName, act.String(), exp)
+	}
+}
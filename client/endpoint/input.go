@@ -2,6 +2,7 @@ package endpoint
 
 import (
 	"net/url"
+	"path"
 )
 
 // Inputs returns an Input API's endpoint url.
@@ -13,3 +14,15 @@ func (ep *Endpoints) Inputs() string {
 func (ep *Endpoints) Input(id string) (*url.URL, error) {
 	return urlJoin(ep.inputs, id)
 }
+
+// InputStaticFields returns an Input Static Fields API's endpoint url.
+func (ep *Endpoints) InputStaticFields(inputID string) (*url.URL, error) {
+	// /system/inputs/{inputId}/staticfields
+	return urlJoin(ep.inputs, path.Join(inputID, "staticfields"))
+}
+
+// InputStaticField returns an Input Static Field API's endpoint url.
+func (ep *Endpoints) InputStaticField(inputID, key string) (*url.URL, error) {
+	// /system/inputs/{inputId}/staticfields/{key}
+	return urlJoin(ep.inputs, path.Join(inputID, "staticfields", key))
+}
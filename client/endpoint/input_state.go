@@ -0,0 +1,16 @@
+package endpoint
+
+import (
+	"net/url"
+)
+
+// InputStates returns an Input States API's endpoint url.
+func (ep *Endpoints) InputStates() string {
+	return ep.inputStates.String()
+}
+
+// InputState returns an Input State API's endpoint url.
+func (ep *Endpoints) InputState(id string) (*url.URL, error) {
+	// /system/inputstates/{inputId}
+	return urlJoin(ep.inputStates, id)
+}
@@ -0,0 +1,35 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestInputStates(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/inputstates", apiURL)
+	act := ep.InputStates()
+	if act != exp {
+		t.Fatalf(`ep.InputStates() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestInputState(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/inputstates/%s", apiURL, ID)
+	act, err := ep.InputState(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.InputState("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
@@ -33,3 +33,33 @@ func TestInput(t *testing.T) {
 		t.Fatalf(`ep.Input("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
 	}
 }
+
+func TestInputStaticFields(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/inputs/%s/staticfields", apiURL, ID)
+	act, err := ep.InputStaticFields(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.InputStaticFields("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+func TestInputStaticField(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/inputs/%s/staticfields/%s", apiURL, ID, "env")
+	act, err := ep.InputStaticField(ID, "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.InputStaticField("%s", "%s") = "%s", wanted "%s"`, ID, "env", act.String(), exp)
+	}
+}
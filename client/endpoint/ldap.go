@@ -0,0 +1,21 @@
+package endpoint
+
+// LDAPSettings returns LDAP Settings API's endpoint url.
+func (ep *Endpoints) LDAPSettings() string {
+	return ep.ldapSettings.String()
+}
+
+// LDAPGroupRoleMapping returns LDAP Group Role Mapping API's endpoint url.
+func (ep *Endpoints) LDAPGroupRoleMapping() string {
+	return ep.ldapGroupRoles.String()
+}
+
+// LDAPGroups returns Get LDAP Groups API's endpoint url.
+func (ep *Endpoints) LDAPGroups() string {
+	return ep.ldapGroups.String()
+}
+
+// LDAPTest returns Test LDAP Configuration API's endpoint url.
+func (ep *Endpoints) LDAPTest() string {
+	return ep.ldapTest.String()
+}
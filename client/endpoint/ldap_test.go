@@ -0,0 +1,56 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestLDAPSettings(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/ldap/settings", apiURL)
+	act := ep.LDAPSettings()
+	if act != exp {
+		t.Fatalf(`ep.LDAPSettings() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestLDAPGroupRoleMapping(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/ldap/settings/groups", apiURL)
+	act := ep.LDAPGroupRoleMapping()
+	if act != exp {
+		t.Fatalf(`ep.LDAPGroupRoleMapping() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestLDAPGroups(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/ldap/groups", apiURL)
+	act := ep.LDAPGroups()
+	if act != exp {
+		t.Fatalf(`ep.LDAPGroups() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestLDAPTest(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/ldap/test", apiURL)
+	act := ep.LDAPTest()
+	if act != exp {
+		t.Fatalf(`ep.LDAPTest() = "%s", wanted "%s"`, act, exp)
+	}
+}
@@ -0,0 +1,26 @@
+package endpoint
+
+import (
+	"net/url"
+)
+
+// Throughput returns Get Throughput API's endpoint url.
+func (ep *Endpoints) Throughput() string {
+	return ep.throughput.String()
+}
+
+// Metric returns Get a Metric API's endpoint url.
+func (ep *Endpoints) Metric(name string) (*url.URL, error) {
+	// /system/metrics/{metricName}
+	return urlJoin(ep.metrics, name)
+}
+
+// MultipleMetrics returns Get Multiple Metrics API's endpoint url.
+func (ep *Endpoints) MultipleMetrics() string {
+	return ep.multipleMetrics.String()
+}
+
+// Journal returns Get Journal API's endpoint url.
+func (ep *Endpoints) Journal() string {
+	return ep.journal.String()
+}
@@ -0,0 +1,60 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestThroughput(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/throughput", apiURL)
+	act := ep.Throughput()
+	if act != exp {
+		t.Fatalf(`ep.Throughput() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestMetric(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "org.graylog2.throughput.input"
+	exp := fmt.Sprintf("%s/system/metrics/%s", apiURL, name)
+	act, err := ep.Metric(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.Metric("%s") = "%s", wanted "%s"`, name, act.String(), exp)
+	}
+}
+
+func TestMultipleMetrics(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/metrics/multiple", apiURL)
+	act := ep.MultipleMetrics()
+	if act != exp {
+		t.Fatalf(`ep.MultipleMetrics() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestJournal(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/journal", apiURL)
+	act := ep.Journal()
+	if act != exp {
+		t.Fatalf(`ep.Journal() = "%s", wanted "%s"`, act, exp)
+	}
+}
@@ -0,0 +1,26 @@
+package endpoint
+
+import (
+	"net/url"
+)
+
+// System returns Get System Overview API's endpoint url.
+func (ep *Endpoints) System() string {
+	return ep.system.String()
+}
+
+// Nodes returns Get Cluster Nodes API's endpoint url.
+func (ep *Endpoints) Nodes() string {
+	return ep.clusterNodes.String()
+}
+
+// Node returns Get a Cluster Node API's endpoint url.
+func (ep *Endpoints) Node(id string) (*url.URL, error) {
+	// /system/cluster/nodes/{nodeId}
+	return urlJoin(ep.clusterNodes, id)
+}
+
+// CurrentNode returns Get the Current Cluster Node API's endpoint url.
+func (ep *Endpoints) CurrentNode() string {
+	return ep.clusterNode.String()
+}
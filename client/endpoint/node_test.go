@@ -0,0 +1,59 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestSystem(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system", apiURL)
+	act := ep.System()
+	if act != exp {
+		t.Fatalf(`ep.System() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/cluster/nodes", apiURL)
+	act := ep.Nodes()
+	if act != exp {
+		t.Fatalf(`ep.Nodes() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestNode(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/cluster/nodes/%s", apiURL, ID)
+	act, err := ep.Node(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.Node("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
+
+func TestCurrentNode(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/cluster/node", apiURL)
+	act := ep.CurrentNode()
+	if act != exp {
+		t.Fatalf(`ep.CurrentNode() = "%s", wanted "%s"`, act, exp)
+	}
+}
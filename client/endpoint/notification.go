@@ -0,0 +1,16 @@
+package endpoint
+
+import (
+	"net/url"
+)
+
+// Notifications returns Get Notifications API's endpoint url.
+func (ep *Endpoints) Notifications() string {
+	return ep.notifications.String()
+}
+
+// Notification returns Delete a Notification API's endpoint url.
+func (ep *Endpoints) Notification(typ string) (*url.URL, error) {
+	// /system/notifications/{notificationType}
+	return urlJoin(ep.notifications, typ)
+}
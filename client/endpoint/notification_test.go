@@ -0,0 +1,36 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestNotifications(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/notifications", apiURL)
+	act := ep.Notifications()
+	if act != exp {
+		t.Fatalf(`ep.Notifications() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestNotification(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := "no_master"
+	exp := fmt.Sprintf("%s/system/notifications/%s", apiURL, typ)
+	act, err := ep.Notification(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.Notification("%s") = "%s", wanted "%s"`, typ, act.String(), exp)
+	}
+}
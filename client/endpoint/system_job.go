@@ -0,0 +1,16 @@
+package endpoint
+
+import (
+	"net/url"
+)
+
+// SystemJobs returns Get System Jobs API's endpoint url.
+func (ep *Endpoints) SystemJobs() string {
+	return ep.systemJobs.String()
+}
+
+// SystemJob returns a System Job API's endpoint url.
+func (ep *Endpoints) SystemJob(id string) (*url.URL, error) {
+	// /system/jobs/{jobId}
+	return urlJoin(ep.systemJobs, id)
+}
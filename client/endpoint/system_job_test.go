@@ -0,0 +1,35 @@
+package endpoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/client/endpoint"
+)
+
+func TestSystemJobs(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/jobs", apiURL)
+	act := ep.SystemJobs()
+	if act != exp {
+		t.Fatalf(`ep.SystemJobs() = "%s", wanted "%s"`, act, exp)
+	}
+}
+
+func TestSystemJob(t *testing.T) {
+	ep, err := endpoint.NewEndpoints(apiURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := fmt.Sprintf("%s/system/jobs/%s", apiURL, ID)
+	act, err := ep.SystemJob(ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.String() != exp {
+		t.Fatalf(`ep.SystemJob("%s") = "%s", wanted "%s"`, ID, act.String(), exp)
+	}
+}
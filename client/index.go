@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetIndices returns a given Index Set's all indices.
+func (client *Client) GetIndices(indexSetID string) (
+	*graylog.AllIndices, *ErrorInfo, error,
+) {
+	return client.GetIndicesContext(context.Background(), indexSetID)
+}
+
+// GetIndicesContext returns a given Index Set's all indices with a context.
+func (client *Client) GetIndicesContext(
+	ctx context.Context, indexSetID string,
+) (*graylog.AllIndices, *ErrorInfo, error) {
+	// GET /system/indexer/indices/{indexSetId}/list List all open, closed and reopened indices.
+	if indexSetID == "" {
+		return nil, nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().Indices(indexSetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	indices := &graylog.AllIndices{}
+	ei, err := client.callGet(ctx, u.String(), nil, indices)
+	return indices, ei, err
+}
+
+// GetOpenIndices returns a given Index Set's open indices.
+func (client *Client) GetOpenIndices(indexSetID string) (
+	*graylog.OpenIndicesInfo, *ErrorInfo, error,
+) {
+	return client.GetOpenIndicesContext(context.Background(), indexSetID)
+}
+
+// GetOpenIndicesContext returns a given Index Set's open indices with a context.
+func (client *Client) GetOpenIndicesContext(
+	ctx context.Context, indexSetID string,
+) (*graylog.OpenIndicesInfo, *ErrorInfo, error) {
+	// GET /system/indexer/indices/{indexSetId}/open Get information of all open indices managed by Graylog and their shards.
+	if indexSetID == "" {
+		return nil, nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().OpenIndices(indexSetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	indices := &graylog.OpenIndicesInfo{}
+	ei, err := client.callGet(ctx, u.String(), nil, indices)
+	return indices, ei, err
+}
+
+// GetClosedIndices returns a given Index Set's closed indices.
+func (client *Client) GetClosedIndices(indexSetID string) (
+	*graylog.ClosedIndices, *ErrorInfo, error,
+) {
+	return client.GetClosedIndicesContext(context.Background(), indexSetID)
+}
+
+// GetClosedIndicesContext returns a given Index Set's closed indices with a context.
+func (client *Client) GetClosedIndicesContext(
+	ctx context.Context, indexSetID string,
+) (*graylog.ClosedIndices, *ErrorInfo, error) {
+	// GET /system/indexer/indices/{indexSetId}/closed Get a list of closed indices that can be reopened.
+	if indexSetID == "" {
+		return nil, nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().ClosedIndices(indexSetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	indices := &graylog.ClosedIndices{}
+	ei, err := client.callGet(ctx, u.String(), nil, indices)
+	return indices, ei, err
+}
+
+// GetReopenedIndices returns a given Index Set's reopened indices.
+func (client *Client) GetReopenedIndices(indexSetID string) (
+	*graylog.ClosedIndices, *ErrorInfo, error,
+) {
+	return client.GetReopenedIndicesContext(context.Background(), indexSetID)
+}
+
+// GetReopenedIndicesContext returns a given Index Set's reopened indices with a context.
+func (client *Client) GetReopenedIndicesContext(
+	ctx context.Context, indexSetID string,
+) (*graylog.ClosedIndices, *ErrorInfo, error) {
+	// GET /system/indexer/indices/{indexSetId}/reopened Get a list of reopened indices, which will not be cleaned by retention cleaning
+	if indexSetID == "" {
+		return nil, nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().ReopenedIndices(indexSetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	indices := &graylog.ClosedIndices{}
+	ei, err := client.callGet(ctx, u.String(), nil, indices)
+	return indices, ei, err
+}
+
+// GetIndex returns a given index's information.
+func (client *Client) GetIndex(name string) (
+	*graylog.IndexInfo, *ErrorInfo, error,
+) {
+	return client.GetIndexContext(context.Background(), name)
+}
+
+// GetIndexContext returns a given index's information with a context.
+func (client *Client) GetIndexContext(
+	ctx context.Context, name string,
+) (*graylog.IndexInfo, *ErrorInfo, error) {
+	// GET /system/indexer/indices/{index} Get information of an index and its shards.
+	if name == "" {
+		return nil, nil, errors.New("index name is empty")
+	}
+	u, err := client.Endpoints().Index(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	index := &graylog.IndexInfo{}
+	ei, err := client.callGet(ctx, u.String(), nil, index)
+	return index, ei, err
+}
+
+// CloseIndex closes a given index.
+func (client *Client) CloseIndex(name string) (*ErrorInfo, error) {
+	return client.CloseIndexContext(context.Background(), name)
+}
+
+// CloseIndexContext closes a given index with a context.
+func (client *Client) CloseIndexContext(
+	ctx context.Context, name string,
+) (*ErrorInfo, error) {
+	// POST /system/indexer/indices/{index}/close Close an index. This will also trigger an index ranges rebuild job.
+	if name == "" {
+		return nil, errors.New("index name is empty")
+	}
+	u, err := client.Endpoints().CloseIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	return client.callPost(ctx, u.String(), nil, nil)
+}
+
+// ReopenIndex reopens a given closed index.
+func (client *Client) ReopenIndex(name string) (*ErrorInfo, error) {
+	return client.ReopenIndexContext(context.Background(), name)
+}
+
+// ReopenIndexContext reopens a given closed index with a context.
+func (client *Client) ReopenIndexContext(
+	ctx context.Context, name string,
+) (*ErrorInfo, error) {
+	// POST /system/indexer/indices/{index}/reopen Reopen a closed index. This will also trigger an index ranges rebuild job.
+	if name == "" {
+		return nil, errors.New("index name is empty")
+	}
+	u, err := client.Endpoints().ReopenIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	return client.callPost(ctx, u.String(), nil, nil)
+}
+
+// DeleteIndex deletes a given index.
+func (client *Client) DeleteIndex(name string) (*ErrorInfo, error) {
+	return client.DeleteIndexContext(context.Background(), name)
+}
+
+// DeleteIndexContext deletes a given index with a context.
+func (client *Client) DeleteIndexContext(
+	ctx context.Context, name string,
+) (*ErrorInfo, error) {
+	// DELETE /system/indexer/indices/{index} Delete an index. This will also trigger an index ranges rebuild job.
+	if name == "" {
+		return nil, errors.New("index name is empty")
+	}
+	u, err := client.Endpoints().Index(name)
+	if err != nil {
+		return nil, err
+	}
+	return client.callDelete(ctx, u.String(), nil, nil)
+}
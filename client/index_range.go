@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetIndexRange returns a given index's time range.
+func (client *Client) GetIndexRange(name string) (
+	*graylog.IndexRange, *ErrorInfo, error,
+) {
+	return client.GetIndexRangeContext(context.Background(), name)
+}
+
+// GetIndexRangeContext returns a given index's time range with a context.
+func (client *Client) GetIndexRangeContext(
+	ctx context.Context, name string,
+) (*graylog.IndexRange, *ErrorInfo, error) {
+	// GET /system/indices/ranges/{index} Show single index range
+	if name == "" {
+		return nil, nil, errors.New("index name is empty")
+	}
+	u, err := client.Endpoints().IndexRange(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &graylog.IndexRange{}
+	ei, err := client.callGet(ctx, u.String(), nil, r)
+	return r, ei, err
+}
+
+// GetIndexRanges returns all index ranges.
+func (client *Client) GetIndexRanges() (
+	[]graylog.IndexRange, int, *ErrorInfo, error,
+) {
+	return client.GetIndexRangesContext(context.Background())
+}
+
+// GetIndexRangesContext returns all index ranges with a context.
+func (client *Client) GetIndexRangesContext(ctx context.Context) (
+	[]graylog.IndexRange, int, *ErrorInfo, error,
+) {
+	// GET /system/indices/ranges Get a list of all index ranges
+	body := &graylog.IndexRangesBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().IndexRanges(), nil, body)
+	return body.Ranges, body.Total, ei, err
+}
+
+// RebuildIndexRanges rebuilds all index ranges.
+// The id of the system job which rebuilds the ranges is returned.
+// Graylog doesn't return the id, so the id is empty unless the server is the mock server.
+func (client *Client) RebuildIndexRanges() (string, *ErrorInfo, error) {
+	return client.RebuildIndexRangesContext(context.Background())
+}
+
+// RebuildIndexRangesContext rebuilds all index ranges with a context.
+func (client *Client) RebuildIndexRangesContext(ctx context.Context) (
+	string, *ErrorInfo, error,
+) {
+	// POST /system/indices/ranges/rebuild Rebuild/sync index range information.
+	u, err := client.Endpoints().RebuildIndexRanges()
+	if err != nil {
+		return "", nil, err
+	}
+	job := &systemJobIDBody{}
+	ei, err := client.callPost(ctx, u.String(), nil, job)
+	return job.ID, ei, err
+}
+
+// RebuildIndexSetIndexRanges rebuilds a given Index Set's index ranges.
+// The id of the system job which rebuilds the ranges is returned.
+// Graylog doesn't return the id, so the id is empty unless the server is the mock server.
+func (client *Client) RebuildIndexSetIndexRanges(indexSetID string) (
+	string, *ErrorInfo, error,
+) {
+	return client.RebuildIndexSetIndexRangesContext(context.Background(), indexSetID)
+}
+
+// RebuildIndexSetIndexRangesContext rebuilds a given Index Set's index ranges with a context.
+func (client *Client) RebuildIndexSetIndexRangesContext(
+	ctx context.Context, indexSetID string,
+) (string, *ErrorInfo, error) {
+	// POST /system/indices/ranges/index_set/{indexSetId}/rebuild Rebuild/sync index range information for the given index set.
+	if indexSetID == "" {
+		return "", nil, errors.New("index set id is empty")
+	}
+	u, err := client.Endpoints().RebuildIndexSetIndexRanges(indexSetID)
+	if err != nil {
+		return "", nil, err
+	}
+	job := &systemJobIDBody{}
+	ei, err := client.callPost(ctx, u.String(), nil, job)
+	return job.ID, ei, err
+}
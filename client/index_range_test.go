@@ -0,0 +1,112 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetIndexRange(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+	indices, _, err := client.GetOpenIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range indices.Indices {
+		r, _, err := client.GetIndexRange(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.IndexName != name {
+			t.Fatalf(`r.IndexName == "%s", wanted "%s"`, r.IndexName, name)
+		}
+		break
+	}
+	if _, _, err := client.GetIndexRange(""); err == nil {
+		t.Fatal("index name is required")
+	}
+}
+
+func TestGetIndexRanges(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+	ranges, total, _, err := client.GetIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != len(ranges) {
+		t.Fatalf("total == %d, wanted %d", total, len(ranges))
+	}
+	if total == 0 {
+		t.Fatal("index ranges are empty")
+	}
+}
+
+func TestRebuildIndexRanges(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	jobID, _, err := client.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil && jobID == "" {
+		t.Fatal("the system job id is empty")
+	}
+}
+
+func TestRebuildIndexSetIndexRanges(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+	jobID, _, err := client.RebuildIndexSetIndexRanges(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil && jobID == "" {
+		t.Fatal("the system job id is empty")
+	}
+	if _, _, err := client.RebuildIndexSetIndexRanges(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+}
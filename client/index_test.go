@@ -0,0 +1,199 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetIndices(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+
+	indices, _, err := client.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices.All.Indices) == 0 {
+		t.Fatal("an index set should have at least one index")
+	}
+	if _, _, err := client.GetIndices(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+	if _, _, err := client.GetIndices("h"); err == nil {
+		t.Fatal(`no index set whose id is "h"`)
+	}
+}
+
+func TestGetOpenIndices(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+
+	indices, _, err := client.GetOpenIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices.Indices) == 0 {
+		t.Fatal("an index set should have at least one open index")
+	}
+	if _, _, err := client.GetOpenIndices(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+}
+
+func TestGetClosedIndices(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+
+	if _, _, err := client.GetClosedIndices(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.GetClosedIndices(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+}
+
+func TestGetReopenedIndices(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+
+	if _, _, err := client.GetReopenedIndices(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.GetReopenedIndices(""); err == nil {
+		t.Fatal("index set id is required")
+	}
+}
+
+func TestGetIndex(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	is, f, err := testutil.GetIndexSet(client, server, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != nil {
+		defer f(is.ID)
+	}
+	indices, _, err := client.GetOpenIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range indices.Indices {
+		index, _, err := client.GetIndex(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if index.IndexName != name {
+			t.Fatalf(`index.IndexName == "%s", wanted "%s"`, index.IndexName, name)
+		}
+		break
+	}
+	if _, _, err := client.GetIndex(""); err == nil {
+		t.Fatal("index name is required")
+	}
+	if _, _, err := client.GetIndex("h"); err == nil {
+		t.Fatal(`no index whose name is "h"`)
+	}
+}
+
+func TestCloseIndex(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	if _, err := client.CloseIndex(""); err == nil {
+		t.Fatal("index name is required")
+	}
+	if _, err := client.CloseIndex("h"); err == nil {
+		t.Fatal(`no index whose name is "h"`)
+	}
+}
+
+func TestReopenIndex(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	if _, err := client.ReopenIndex(""); err == nil {
+		t.Fatal("index name is required")
+	}
+	if _, err := client.ReopenIndex("h"); err == nil {
+		t.Fatal(`no index whose name is "h"`)
+	}
+}
+
+func TestDeleteIndex(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	if _, err := client.DeleteIndex(""); err == nil {
+		t.Fatal("index name is required")
+	}
+	if _, err := client.DeleteIndex("h"); err == nil {
+		t.Fatal(`no index whose name is "h"`)
+	}
+}
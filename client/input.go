@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
 )
 
 // GetInputs returns all inputs.
@@ -62,6 +63,9 @@ func (client *Client) CreateInputContext(
 	if input.ID != "" {
 		return nil, fmt.Errorf("input id should be empty")
 	}
+	if err := validator.ValidateInputAttrs(input.Attrs); err != nil {
+		return nil, err
+	}
 	// change attributes to configuration
 	// https://github.com/Graylog2/graylog2-server/issues/3480
 	d := map[string]interface{}{
@@ -78,6 +82,7 @@ func (client *Client) CreateInputContext(
 }
 
 // UpdateInput updates an given input.
+// Graylog returns only the input's id, so the returned input has only the id.
 func (client *Client) UpdateInput(input *graylog.InputUpdateParams) (*graylog.Input, *ErrorInfo, error) {
 	return client.UpdateInputContext(context.Background(), input)
 }
@@ -92,6 +97,9 @@ func (client *Client) UpdateInputContext(
 	if prms.ID == "" {
 		return nil, nil, errors.New("id is empty")
 	}
+	if err := validator.ValidateInputAttrs(prms.Attrs); err != nil {
+		return nil, nil, err
+	}
 	u, err := client.Endpoints().Input(prms.ID)
 	if err != nil {
 		return nil, nil, err
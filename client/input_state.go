@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetInputStates returns all input states.
+func (client *Client) GetInputStates() (
+	[]graylog.InputStateSummary, *ErrorInfo, error,
+) {
+	return client.GetInputStatesContext(context.Background())
+}
+
+// GetInputStatesContext returns all input states with a context.
+func (client *Client) GetInputStatesContext(ctx context.Context) (
+	[]graylog.InputStateSummary, *ErrorInfo, error,
+) {
+	// GET /system/inputstates Get all input states of this node
+	states := &graylog.InputStatesBody{}
+	ei, err := client.callGet(
+		ctx, client.Endpoints().InputStates(), nil, states)
+	return states.States, ei, err
+}
+
+// GetInputState returns a given input's state.
+func (client *Client) GetInputState(id string) (
+	*graylog.InputStateSummary, *ErrorInfo, error,
+) {
+	return client.GetInputStateContext(context.Background(), id)
+}
+
+// GetInputStateContext returns a given input's state with a context.
+func (client *Client) GetInputStateContext(
+	ctx context.Context, id string,
+) (*graylog.InputStateSummary, *ErrorInfo, error) {
+	// GET /system/inputstates/{inputId} Get input state for specified input ID on this node
+	if id == "" {
+		return nil, nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().InputState(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	state := &graylog.InputStateSummary{}
+	ei, err := client.callGet(ctx, u.String(), nil, state)
+	return state, ei, err
+}
+
+// StartInput starts a given input.
+func (client *Client) StartInput(id string) (*ErrorInfo, error) {
+	return client.StartInputContext(context.Background(), id)
+}
+
+// StartInputContext starts a given input with a context.
+func (client *Client) StartInputContext(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	// PUT /system/inputstates/{inputId} (Re-)Start specified input on this node
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().InputState(id)
+	if err != nil {
+		return nil, err
+	}
+	return client.callPut(ctx, u.String(), nil, nil)
+}
+
+// StopInput stops a given input.
+func (client *Client) StopInput(id string) (*ErrorInfo, error) {
+	return client.StopInputContext(context.Background(), id)
+}
+
+// StopInputContext stops a given input with a context.
+func (client *Client) StopInputContext(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	// DELETE /system/inputstates/{inputId} Stop specified input on this node
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().InputState(id)
+	if err != nil {
+		return nil, err
+	}
+	return client.callDelete(ctx, u.String(), nil, nil)
+}
+
+// RestartInput restarts a given input.
+func (client *Client) RestartInput(id string) (*ErrorInfo, error) {
+	return client.RestartInputContext(context.Background(), id)
+}
+
+// RestartInputContext restarts a given input with a context.
+func (client *Client) RestartInputContext(
+	ctx context.Context, id string,
+) (*ErrorInfo, error) {
+	// PUT /system/inputstates/{inputId} (Re-)Start specified input on this node
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().InputState(id)
+	if err != nil {
+		return nil, err
+	}
+	return client.callPut(ctx, u.String(), nil, nil)
+}
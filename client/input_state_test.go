@@ -0,0 +1,101 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetInputStates(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+
+	states, _, err := client.GetInputStates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) == 0 {
+		t.Fatal("input states is empty")
+	}
+}
+
+func TestGetInputState(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+
+	input := testutil.Input()
+	if _, err := client.CreateInput(input); err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteInput(input.ID)
+	state, _, err := client.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ID != input.ID {
+		t.Fatalf(`state.ID = "%s", wanted "%s"`, state.ID, input.ID)
+	}
+	if state.MessageInput == nil || state.MessageInput.Title != input.Title {
+		t.Fatal("state.MessageInput should be the input")
+	}
+	if _, _, err := client.GetInputState(""); err == nil {
+		t.Fatal("input id is required")
+	}
+	if _, _, err := client.GetInputState("h"); err == nil {
+		t.Fatal(`no input with id "h" is found`)
+	}
+}
+
+func TestStopAndStartInput(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+
+	input := testutil.Input()
+	if _, err := client.CreateInput(input); err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteInput(input.ID)
+	if _, err := client.StopInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		state, _, err := client.GetInputState(input.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.State != graylog.InputStateStopped {
+			t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateStopped)
+		}
+	}
+	if _, err := client.StartInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RestartInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartInput(""); err == nil {
+		t.Fatal("input id is required")
+	}
+	if _, err := client.StopInput(""); err == nil {
+		t.Fatal("input id is required")
+	}
+	if _, err := client.RestartInput("h"); err == nil {
+		t.Fatal(`no input with id "h" is found`)
+	}
+}
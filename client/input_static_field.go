@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// AddInputStaticField adds a static field to a given input.
+func (client *Client) AddInputStaticField(id, key, value string) (*ErrorInfo, error) {
+	return client.AddInputStaticFieldContext(context.Background(), id, key, value)
+}
+
+// AddInputStaticFieldContext adds a static field to a given input with a context.
+func (client *Client) AddInputStaticFieldContext(
+	ctx context.Context, id, key, value string,
+) (*ErrorInfo, error) {
+	// POST /system/inputs/{inputId}/staticfields Add a static field to an input
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	if key == "" {
+		return nil, errors.New("key is empty")
+	}
+	u, err := client.Endpoints().InputStaticFields(id)
+	if err != nil {
+		return nil, err
+	}
+	return client.callPost(ctx, u.String(), map[string]string{
+		"key": key, "value": value}, nil)
+}
+
+// DeleteInputStaticField deletes a static field from a given input.
+func (client *Client) DeleteInputStaticField(id, key string) (*ErrorInfo, error) {
+	return client.DeleteInputStaticFieldContext(context.Background(), id, key)
+}
+
+// DeleteInputStaticFieldContext deletes a static field from a given input with a context.
+func (client *Client) DeleteInputStaticFieldContext(
+	ctx context.Context, id, key string,
+) (*ErrorInfo, error) {
+	// DELETE /system/inputs/{inputId}/staticfields/{key} Remove static field of an input
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	if key == "" {
+		return nil, errors.New("key is empty")
+	}
+	u, err := client.Endpoints().InputStaticField(id, key)
+	if err != nil {
+		return nil, err
+	}
+	return client.callDelete(ctx, u.String(), nil, nil)
+}
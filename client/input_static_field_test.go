@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestAddInputStaticField(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+
+	input := testutil.Input()
+	if _, err := client.CreateInput(input); err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteInput(input.ID)
+	if _, err := client.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	r, _, err := client.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.StaticFields["env"] != "test" {
+		t.Fatalf(`StaticFields["env"] = "%s", wanted "test"`, r.StaticFields["env"])
+	}
+	if _, err := client.AddInputStaticField("", "env", "test"); err == nil {
+		t.Fatal("input id is required")
+	}
+	if _, err := client.AddInputStaticField(input.ID, "", "test"); err == nil {
+		t.Fatal("key is required")
+	}
+	if _, err := client.AddInputStaticField("h", "env", "test"); err == nil {
+		t.Fatal(`no input with id "h" is found`)
+	}
+}
+
+func TestDeleteInputStaticField(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+
+	input := testutil.Input()
+	if _, err := client.CreateInput(input); err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteInput(input.ID)
+	if _, err := client.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	r, _, err := client.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.StaticFields["env"]; ok {
+		t.Fatal(`static field "env" should be deleted`)
+	}
+	if _, err := client.DeleteInputStaticField("", "env"); err == nil {
+		t.Fatal("input id is required")
+	}
+	if _, err := client.DeleteInputStaticField(input.ID, ""); err == nil {
+		t.Fatal("key is required")
+	}
+	if _, err := client.DeleteInputStaticField(input.ID, "env"); err == nil {
+		t.Fatal(`static field "env" should be already deleted`)
+	}
+}
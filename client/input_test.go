@@ -86,6 +86,11 @@ func TestCreateInput(t *testing.T) {
 	if _, err := client.CreateInput(input); err == nil {
 		t.Fatal("input id should be empty")
 	}
+	input = testutil.Input()
+	input.Attrs.(*graylog.InputBeatsAttrs).Port = 70000
+	if _, err := client.CreateInput(input); err == nil {
+		t.Fatal("port should be invalid")
+	}
 }
 
 func TestUpdateInput(t *testing.T) {
@@ -109,6 +114,11 @@ func TestUpdateInput(t *testing.T) {
 	if _, _, err := client.UpdateInput(input.NewUpdateParams()); err != nil {
 		t.Fatal(err)
 	}
+	attrs.TLSEnable = true
+	if _, _, err := client.UpdateInput(input.NewUpdateParams()); err == nil {
+		t.Fatal("tls_cert_file should be required")
+	}
+	attrs.TLSEnable = false
 	input.ID = ""
 	if _, _, err := client.UpdateInput(input.NewUpdateParams()); err == nil {
 		t.Fatal("input id is required")
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetLDAPSettings returns the LDAP settings.
+// The system password isn't returned, and SystemPasswordSet is set instead.
+// If LDAP isn't configured, the empty settings are returned.
+func (client *Client) GetLDAPSettings() (*graylog.LDAPSettings, *ErrorInfo, error) {
+	return client.GetLDAPSettingsContext(context.Background())
+}
+
+// GetLDAPSettingsContext returns the LDAP settings with a context.
+func (client *Client) GetLDAPSettingsContext(ctx context.Context) (
+	*graylog.LDAPSettings, *ErrorInfo, error,
+) {
+	// GET /system/ldap/settings Get the LDAP configuration if it is configured
+	settings := &graylog.LDAPSettings{}
+	ei, err := client.callGet(ctx, client.Endpoints().LDAPSettings(), nil, settings)
+	return settings, ei, err
+}
+
+// UpdateLDAPSettings updates the LDAP settings.
+// If the system password is empty, the current password is kept.
+func (client *Client) UpdateLDAPSettings(settings *graylog.LDAPSettings) (*ErrorInfo, error) {
+	return client.UpdateLDAPSettingsContext(context.Background(), settings)
+}
+
+// UpdateLDAPSettingsContext updates the LDAP settings with a context.
+func (client *Client) UpdateLDAPSettingsContext(
+	ctx context.Context, settings *graylog.LDAPSettings,
+) (*ErrorInfo, error) {
+	// PUT /system/ldap/settings Update the LDAP configuration
+	if settings == nil {
+		return nil, errors.New("ldap settings is nil")
+	}
+	s := *settings
+	// system_password_set is read only
+	s.SystemPasswordSet = false
+	return client.callPut(ctx, client.Endpoints().LDAPSettings(), &s, nil)
+}
+
+// DeleteLDAPSettings deletes the LDAP settings.
+func (client *Client) DeleteLDAPSettings() (*ErrorInfo, error) {
+	return client.DeleteLDAPSettingsContext(context.Background())
+}
+
+// DeleteLDAPSettingsContext deletes the LDAP settings with a context.
+func (client *Client) DeleteLDAPSettingsContext(ctx context.Context) (*ErrorInfo, error) {
+	// DELETE /system/ldap/settings Remove the LDAP configuration
+	return client.callDelete(ctx, client.Endpoints().LDAPSettings(), nil, nil)
+}
+
+// TestLDAPSettings tests the connection to the LDAP server and the login of a user.
+// Even if the connection or the login fails, an error isn't returned,
+// so check the returned result.
+func (client *Client) TestLDAPSettings(req *graylog.LDAPTestConfigRequest) (
+	*graylog.LDAPTestConfigResponse, *ErrorInfo, error,
+) {
+	return client.TestLDAPSettingsContext(context.Background(), req)
+}
+
+// TestLDAPSettingsContext tests the connection to the LDAP server and the login of a user with a context.
+func (client *Client) TestLDAPSettingsContext(
+	ctx context.Context, req *graylog.LDAPTestConfigRequest,
+) (*graylog.LDAPTestConfigResponse, *ErrorInfo, error) {
+	// POST /system/ldap/test Test LDAP Configuration
+	if req == nil {
+		return nil, nil, errors.New("request is nil")
+	}
+	resp := &graylog.LDAPTestConfigResponse{}
+	ei, err := client.callPost(ctx, client.Endpoints().LDAPTest(), req, resp)
+	return resp, ei, err
+}
+
+// GetLDAPGroups returns the names of all groups of the LDAP server.
+func (client *Client) GetLDAPGroups() ([]string, *ErrorInfo, error) {
+	return client.GetLDAPGroupsContext(context.Background())
+}
+
+// GetLDAPGroupsContext returns the names of all groups of the LDAP server with a context.
+func (client *Client) GetLDAPGroupsContext(ctx context.Context) (
+	[]string, *ErrorInfo, error,
+) {
+	// GET /system/ldap/groups Get the available LDAP groups
+	groups := []string{}
+	ei, err := client.callGet(ctx, client.Endpoints().LDAPGroups(), nil, &groups)
+	return groups, ei, err
+}
+
+// GetLDAPGroupRoleMapping returns the map of LDAP groups to Graylog roles.
+func (client *Client) GetLDAPGroupRoleMapping() (map[string]string, *ErrorInfo, error) {
+	return client.GetLDAPGroupRoleMappingContext(context.Background())
+}
+
+// GetLDAPGroupRoleMappingContext returns the map of LDAP groups to Graylog roles with a context.
+func (client *Client) GetLDAPGroupRoleMappingContext(ctx context.Context) (
+	map[string]string, *ErrorInfo, error,
+) {
+	// GET /system/ldap/settings/groups Get the LDAP group to Graylog role mapping
+	mapping := map[string]string{}
+	ei, err := client.callGet(
+		ctx, client.Endpoints().LDAPGroupRoleMapping(), nil, &mapping)
+	return mapping, ei, err
+}
+
+// UpdateLDAPGroupRoleMapping updates the map of LDAP groups to Graylog roles.
+//
+//   ei, err := client.UpdateLDAPGroupRoleMapping(map[string]string{"admins": "Admin"})
+func (client *Client) UpdateLDAPGroupRoleMapping(mapping map[string]string) (*ErrorInfo, error) {
+	return client.UpdateLDAPGroupRoleMappingContext(context.Background(), mapping)
+}
+
+// UpdateLDAPGroupRoleMappingContext updates the map of LDAP groups to Graylog roles with a context.
+func (client *Client) UpdateLDAPGroupRoleMappingContext(
+	ctx context.Context, mapping map[string]string,
+) (*ErrorInfo, error) {
+	// PUT /system/ldap/settings/groups Update the LDAP group to Graylog role mapping
+	if mapping == nil {
+		mapping = map[string]string{}
+	}
+	return client.callPut(ctx, client.Endpoints().LDAPGroupRoleMapping(), mapping, nil)
+}
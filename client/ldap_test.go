@@ -0,0 +1,166 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func newLDAPSettings() *graylog.LDAPSettings {
+	return &graylog.LDAPSettings{
+		Enabled:              true,
+		SystemUsername:       "cn=admin,dc=example,dc=com",
+		SystemPassword:       "admin",
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		DefaultGroup:         "Admin",
+	}
+}
+
+func TestGetLDAPSettings(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	if _, _, err := client.GetLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateLDAPSettings(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	if _, err := client.UpdateLDAPSettings(nil); err == nil {
+		t.Fatal("ldap settings is nil")
+	}
+	if _, err := client.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err := client.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.SystemPassword != "" || !settings.SystemPasswordSet {
+		t.Fatalf("the system password should be write only: %+v", settings)
+	}
+	// the settings which are got can be used to update the settings
+	settings.SearchBase = "ou=people,dc=example,dc=com"
+	if _, err := client.UpdateLDAPSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteLDAPSettings(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	if _, err := client.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err := client.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.LDAPURI != "" {
+		t.Fatal("ldap settings should be deleted")
+	}
+}
+
+func TestTestLDAPSettings(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	if _, _, err := client.TestLDAPSettings(nil); err == nil {
+		t.Fatal("request is nil")
+	}
+	server.SetLDAPDirectory(&logic.LDAPDirectory{
+		Users: []logic.LDAPUser{{UID: "foo", Password: "password"}},
+	})
+	resp, _, err := client.TestLDAPSettings(&graylog.LDAPTestConfigRequest{
+		LDAPURI:    "ldap://localhost:389",
+		SearchBase: "cn=users,dc=example,dc=com",
+		Principal:  "foo",
+		Password:   "password",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Connected || !resp.LoginAuthenticated {
+		t.Fatalf("resp == %+v", resp)
+	}
+}
+
+func TestGetLDAPGroups(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	if _, err := client.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	server.SetLDAPDirectory(&logic.LDAPDirectory{
+		SystemUsername: "cn=admin,dc=example,dc=com",
+		SystemPassword: "admin",
+		Users: []logic.LDAPUser{{
+			UID: "foo", Password: "password", Groups: []string{"admins"}}},
+	})
+	groups, _, err := client.GetLDAPGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0] != "admins" {
+		t.Fatalf("groups == %v", groups)
+	}
+}
+
+func TestUpdateLDAPGroupRoleMapping(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	if _, err := client.UpdateLDAPGroupRoleMapping(
+		map[string]string{"admins": "Admin"}); err != nil {
+		t.Fatal(err)
+	}
+	mapping, _, err := client.GetLDAPGroupRoleMapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping["admins"] != "Admin" {
+		t.Fatalf("mapping == %v", mapping)
+	}
+}
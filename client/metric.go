@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetThroughput returns the number of messages processed in the last second.
+func (client *Client) GetThroughput() (int, *ErrorInfo, error) {
+	return client.GetThroughputContext(context.Background())
+}
+
+// GetThroughputContext returns the number of messages processed in the last second with a context.
+func (client *Client) GetThroughputContext(ctx context.Context) (
+	int, *ErrorInfo, error,
+) {
+	// GET /system/throughput Current throughput of this node in messages per second
+	body := &graylog.ThroughputBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().Throughput(), nil, body)
+	return body.Throughput, ei, err
+}
+
+// getMetric gets a metric and decodes it to output.
+func (client *Client) getMetric(
+	ctx context.Context, name string, output interface{},
+) (*ErrorInfo, error) {
+	// GET /system/metrics/{metricName} Get a single metric
+	if name == "" {
+		return nil, errors.New("name is empty")
+	}
+	u, err := client.Endpoints().Metric(name)
+	if err != nil {
+		return nil, err
+	}
+	return client.callGet(ctx, u.String(), nil, output)
+}
+
+// GetGauge returns a given gauge metric.
+func (client *Client) GetGauge(name string) (*graylog.Gauge, *ErrorInfo, error) {
+	return client.GetGaugeContext(context.Background(), name)
+}
+
+// GetGaugeContext returns a given gauge metric with a context.
+func (client *Client) GetGaugeContext(
+	ctx context.Context, name string,
+) (*graylog.Gauge, *ErrorInfo, error) {
+	gauge := &graylog.Gauge{}
+	ei, err := client.getMetric(ctx, name, gauge)
+	return gauge, ei, err
+}
+
+// GetCounter returns a given counter metric.
+func (client *Client) GetCounter(name string) (*graylog.Counter, *ErrorInfo, error) {
+	return client.GetCounterContext(context.Background(), name)
+}
+
+// GetCounterContext returns a given counter metric with a context.
+func (client *Client) GetCounterContext(
+	ctx context.Context, name string,
+) (*graylog.Counter, *ErrorInfo, error) {
+	counter := &graylog.Counter{}
+	ei, err := client.getMetric(ctx, name, counter)
+	return counter, ei, err
+}
+
+// GetMeter returns a given meter metric.
+func (client *Client) GetMeter(name string) (*graylog.Meter, *ErrorInfo, error) {
+	return client.GetMeterContext(context.Background(), name)
+}
+
+// GetMeterContext returns a given meter metric with a context.
+func (client *Client) GetMeterContext(
+	ctx context.Context, name string,
+) (*graylog.Meter, *ErrorInfo, error) {
+	meter := &graylog.Meter{}
+	ei, err := client.getMetric(ctx, name, meter)
+	return meter, ei, err
+}
+
+// GetTimer returns a given timer metric.
+func (client *Client) GetTimer(name string) (*graylog.Timer, *ErrorInfo, error) {
+	return client.GetTimerContext(context.Background(), name)
+}
+
+// GetTimerContext returns a given timer metric with a context.
+func (client *Client) GetTimerContext(
+	ctx context.Context, name string,
+) (*graylog.Timer, *ErrorInfo, error) {
+	timer := &graylog.Timer{}
+	ei, err := client.getMetric(ctx, name, timer)
+	return timer, ei, err
+}
+
+// GetMetrics returns given metrics.
+// Unknown metrics are ignored.
+func (client *Client) GetMetrics(names ...string) (
+	[]graylog.Metric, int, *ErrorInfo, error,
+) {
+	return client.GetMetricsContext(context.Background(), names...)
+}
+
+// GetMetricsContext returns given metrics with a context.
+func (client *Client) GetMetricsContext(
+	ctx context.Context, names ...string,
+) ([]graylog.Metric, int, *ErrorInfo, error) {
+	// POST /system/metrics/multiple Get the values of multiple metrics at once
+	if len(names) == 0 {
+		return nil, 0, nil, errors.New("names are empty")
+	}
+	metrics := &graylog.MetricsBody{}
+	ei, err := client.callPost(
+		ctx, client.Endpoints().MultipleMetrics(),
+		&graylog.MetricNamesBody{Metrics: names}, metrics)
+	return metrics.Metrics, metrics.Total, ei, err
+}
+
+// GetJournal returns the message journal's status of the node which serves the API.
+func (client *Client) GetJournal() (*graylog.JournalSummary, *ErrorInfo, error) {
+	return client.GetJournalContext(context.Background())
+}
+
+// GetJournalContext returns the message journal's status with a context.
+func (client *Client) GetJournalContext(ctx context.Context) (
+	*graylog.JournalSummary, *ErrorInfo, error,
+) {
+	// GET /system/journal Get current state of the journal on this node.
+	journal := &graylog.JournalSummary{}
+	ei, err := client.callGet(ctx, client.Endpoints().Journal(), nil, journal)
+	return journal, ei, err
+}
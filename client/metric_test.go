@@ -0,0 +1,127 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetThroughput(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	if _, _, err := client.GetThroughput(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetMeter(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+		inputs, _, _, err := server.GetInputs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := server.IngestMessage(
+			inputs[0].ID, map[string]interface{}{"message": "hello"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	meter, _, err := client.GetMeter("org.graylog2.throughput.input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil && meter.Count != 1 {
+		t.Fatalf("meter.Count == %d, wanted 1", meter.Count)
+	}
+	if _, _, err := client.GetMeter(""); err == nil {
+		t.Fatal("name is required")
+	}
+}
+
+func TestGetGauge(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	gauge, _, err := client.GetGauge("org.graylog2.throughput.input.1-sec-interval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := gauge.Float64(); !ok {
+		t.Fatalf("gauge.Value should be a number: %v", gauge.Value)
+	}
+}
+
+func TestGetTimer(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	timer, _, err := client.GetTimer(
+		"org.graylog2.shared.buffers.processors.ProcessBufferProcessor.processTime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timer.DurationUnits == "" {
+		t.Fatal("timer.DurationUnits is empty")
+	}
+}
+
+func TestGetMetrics(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	metrics, total, _, err := client.GetMetrics(
+		"org.graylog2.throughput.input",
+		"org.graylog2.throughput.input.1-sec-interval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(metrics) != 2 {
+		t.Fatalf("total == %d, wanted 2", total)
+	}
+	for _, metric := range metrics {
+		if metric.Meter == nil && metric.Gauge == nil {
+			t.Fatalf("metric %s has no value", metric.FullName)
+		}
+	}
+	if _, _, _, err := client.GetMetrics(); err == nil {
+		t.Fatal("names are required")
+	}
+}
+
+func TestGetJournal(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	journal, _, err := client.GetJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if journal.JournalSizeLimit == 0 {
+		t.Fatal("journal.JournalSizeLimit is 0")
+	}
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetSystemOverview returns the overview of the node which serves the API.
+func (client *Client) GetSystemOverview() (
+	*graylog.SystemOverview, *ErrorInfo, error,
+) {
+	return client.GetSystemOverviewContext(context.Background())
+}
+
+// GetSystemOverviewContext returns the overview of the node which serves the API with a context.
+func (client *Client) GetSystemOverviewContext(ctx context.Context) (
+	*graylog.SystemOverview, *ErrorInfo, error,
+) {
+	// GET /system Get system overview
+	overview := &graylog.SystemOverview{}
+	ei, err := client.callGet(ctx, client.Endpoints().System(), nil, overview)
+	return overview, ei, err
+}
+
+// GetNodes returns all nodes of the cluster.
+func (client *Client) GetNodes() (
+	[]graylog.Node, int, *ErrorInfo, error,
+) {
+	return client.GetNodesContext(context.Background())
+}
+
+// GetNodesContext returns all nodes of the cluster with a context.
+func (client *Client) GetNodesContext(ctx context.Context) (
+	[]graylog.Node, int, *ErrorInfo, error,
+) {
+	// GET /system/cluster/nodes List all active nodes in this cluster.
+	nodes := &graylog.NodesBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().Nodes(), nil, nodes)
+	return nodes.Nodes, nodes.Total, ei, err
+}
+
+// GetNode returns a given node.
+func (client *Client) GetNode(id string) (
+	*graylog.Node, *ErrorInfo, error,
+) {
+	return client.GetNodeContext(context.Background(), id)
+}
+
+// GetNodeContext returns a given node with a context.
+func (client *Client) GetNodeContext(
+	ctx context.Context, id string,
+) (*graylog.Node, *ErrorInfo, error) {
+	// GET /system/cluster/nodes/{nodeId} Information about a node.
+	if id == "" {
+		return nil, nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().Node(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	node := &graylog.Node{}
+	ei, err := client.callGet(ctx, u.String(), nil, node)
+	return node, ei, err
+}
+
+// GetCurrentNode returns the node which serves the API.
+func (client *Client) GetCurrentNode() (
+	*graylog.Node, *ErrorInfo, error,
+) {
+	return client.GetCurrentNodeContext(context.Background())
+}
+
+// GetCurrentNodeContext returns the node which serves the API with a context.
+func (client *Client) GetCurrentNodeContext(ctx context.Context) (
+	*graylog.Node, *ErrorInfo, error,
+) {
+	// GET /system/cluster/node Information about this node.
+	node := &graylog.Node{}
+	ei, err := client.callGet(ctx, client.Endpoints().CurrentNode(), nil, node)
+	return node, ei, err
+}
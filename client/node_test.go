@@ -0,0 +1,68 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetSystemOverview(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	overview, _, err := client.GetSystemOverview()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.NodeID == "" {
+		t.Fatal("overview.NodeID is empty")
+	}
+}
+
+func TestGetNodes(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	nodes, total, _, err := client.GetNodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != len(nodes) {
+		t.Fatalf("total == %d, wanted %d", total, len(nodes))
+	}
+	if total == 0 {
+		t.Fatal("nodes are empty")
+	}
+}
+
+func TestGetNode(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+	}
+	current, _, err := client.GetCurrentNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, _, err := client.GetNode(current.NodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.NodeID != current.NodeID {
+		t.Fatalf(`node.NodeID == "%s", wanted "%s"`, node.NodeID, current.NodeID)
+	}
+	if _, _, err := client.GetNode(""); err == nil {
+		t.Fatal("id is required")
+	}
+}
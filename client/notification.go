@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetNotifications returns all system notifications.
+func (client *Client) GetNotifications() (
+	[]graylog.Notification, int, *ErrorInfo, error,
+) {
+	return client.GetNotificationsContext(context.Background())
+}
+
+// GetNotificationsContext returns all system notifications with a context.
+func (client *Client) GetNotificationsContext(ctx context.Context) (
+	[]graylog.Notification, int, *ErrorInfo, error,
+) {
+	// GET /system/notifications Get all active notifications
+	notifications := &graylog.NotificationsBody{}
+	ei, err := client.callGet(
+		ctx, client.Endpoints().Notifications(), nil, notifications)
+	return notifications.Notifications, notifications.Total, ei, err
+}
+
+// DeleteNotification deletes a system notification of a given type.
+func (client *Client) DeleteNotification(typ graylog.NotificationType) (*ErrorInfo, error) {
+	return client.DeleteNotificationContext(context.Background(), typ)
+}
+
+// DeleteNotificationContext deletes a system notification of a given type with a context.
+func (client *Client) DeleteNotificationContext(
+	ctx context.Context, typ graylog.NotificationType,
+) (*ErrorInfo, error) {
+	// DELETE /system/notifications/{notificationType} Delete a notification
+	if typ == "" {
+		return nil, errors.New("notification type is empty")
+	}
+	u, err := client.Endpoints().Notification(string(typ))
+	if err != nil {
+		return nil, err
+	}
+	return client.callDelete(ctx, u.String(), nil, nil)
+}
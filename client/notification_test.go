@@ -0,0 +1,61 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetNotifications(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+		if _, _, err := server.RaiseNotification(&graylog.Notification{
+			Type: graylog.NotificationTypeOutdatedVersion}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notifications, total, _, err := client.GetNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != len(notifications) {
+		t.Fatalf("total == %d, wanted %d", total, len(notifications))
+	}
+	if server != nil && total != 1 {
+		t.Fatalf("total == %d, wanted 1", total)
+	}
+}
+
+func TestDeleteNotification(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+		if _, _, err := server.RaiseNotification(&graylog.Notification{
+			Type: graylog.NotificationTypeOutdatedVersion}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.DeleteNotification(graylog.NotificationTypeOutdatedVersion); err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		_, total, _, err := client.GetNotifications()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 0 {
+			t.Fatalf("total == %d, wanted 0", total)
+		}
+	}
+	if _, err := client.DeleteNotification(""); err == nil {
+		t.Fatal("notification type is required")
+	}
+}
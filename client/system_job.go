@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetSystemJobs returns all running system jobs.
+func (client *Client) GetSystemJobs() (
+	[]graylog.SystemJob, *ErrorInfo, error,
+) {
+	return client.GetSystemJobsContext(context.Background())
+}
+
+// GetSystemJobsContext returns all running system jobs with a context.
+func (client *Client) GetSystemJobsContext(ctx context.Context) (
+	[]graylog.SystemJob, *ErrorInfo, error,
+) {
+	// GET /system/jobs List currently running jobs
+	jobs := &graylog.SystemJobsBody{}
+	ei, err := client.callGet(ctx, client.Endpoints().SystemJobs(), nil, jobs)
+	return jobs.Jobs, ei, err
+}
+
+// GetSystemJob returns a given running system job.
+// Graylog returns 404 for a finished system job.
+func (client *Client) GetSystemJob(id string) (
+	*graylog.SystemJob, *ErrorInfo, error,
+) {
+	return client.GetSystemJobContext(context.Background(), id)
+}
+
+// GetSystemJobContext returns a given running system job with a context.
+func (client *Client) GetSystemJobContext(
+	ctx context.Context, id string,
+) (*graylog.SystemJob, *ErrorInfo, error) {
+	// GET /system/jobs/{jobId} Get information of a specific currently running job
+	if id == "" {
+		return nil, nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().SystemJob(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	job := &graylog.SystemJob{}
+	ei, err := client.callGet(ctx, u.String(), nil, job)
+	return job, ei, err
+}
+
+// CancelSystemJob cancels a given running system job.
+func (client *Client) CancelSystemJob(id string) (
+	*graylog.SystemJob, *ErrorInfo, error,
+) {
+	return client.CancelSystemJobContext(context.Background(), id)
+}
+
+// CancelSystemJobContext cancels a given running system job with a context.
+func (client *Client) CancelSystemJobContext(
+	ctx context.Context, id string,
+) (*graylog.SystemJob, *ErrorInfo, error) {
+	// DELETE /system/jobs/{jobId} Cancel running job
+	if id == "" {
+		return nil, nil, errors.New("id is empty")
+	}
+	u, err := client.Endpoints().SystemJob(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	job := &graylog.SystemJob{}
+	ei, err := client.callDelete(ctx, u.String(), nil, job)
+	return job, ei, err
+}
+
+// WaitForSystemJob polls a given system job at every pollInterval until it finishes.
+// Graylog forgets a finished system job, so the job is regarded as finished when it isn't found.
+// If pollInterval isn't positive, the job is polled every second.
+// If the context is done before the job finishes, the context's error is returned.
+//
+//   jobID, _, err := client.RebuildIndexRanges()
+//   ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//   defer cancel()
+//   _, err = client.WaitForSystemJob(ctx, jobID, time.Second)
+func (client *Client) WaitForSystemJob(
+	ctx context.Context, id string, pollInterval time.Duration,
+) (*ErrorInfo, error) {
+	if id == "" {
+		return nil, errors.New("id is empty")
+	}
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		_, ei, err := client.GetSystemJobContext(ctx, id)
+		if err != nil {
+			if ei != nil && ei.Response != nil && ei.Response.StatusCode == http.StatusNotFound {
+				return ei, nil
+			}
+			return ei, err
+		}
+		select {
+		case <-ctx.Done():
+			return ei, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
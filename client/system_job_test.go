@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetSystemJobs(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+		server.SetSystemJobDuration(time.Hour)
+	}
+	jobID, _, err := client.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, _, err := client.GetSystemJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	if len(jobs) != 1 || jobs[0].ID != jobID {
+		t.Fatalf("jobs == %v, wanted the job %s", jobs, jobID)
+	}
+}
+
+func TestGetSystemJob(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	server.SetSystemJobDuration(time.Hour)
+	jobID, _, err := client.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, _, err := client.GetSystemJob(jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.ID != jobID {
+		t.Fatalf(`job.ID == "%s", wanted "%s"`, job.ID, jobID)
+	}
+	if _, _, err := client.GetSystemJob(""); err == nil {
+		t.Fatal("id is required")
+	}
+}
+
+func TestCancelSystemJob(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	defer server.Close()
+	server.SetSystemJobDuration(time.Hour)
+	jobID, _, err := client.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.CancelSystemJob(jobID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.GetSystemJob(jobID); err == nil {
+		t.Fatal("the canceled job should not be found")
+	}
+	if _, _, err := client.CancelSystemJob(""); err == nil {
+		t.Fatal("id is required")
+	}
+}
+
+func TestWaitForSystemJob(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer server.Close()
+		server.SetSystemJobDuration(50 * time.Millisecond)
+	}
+	jobID, _, err := client.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobID == "" {
+		// Graylog doesn't return the job id
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.WaitForSystemJob(ctx, jobID, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if server == nil {
+		return
+	}
+	server.SetSystemJobDuration(time.Hour)
+	jobID, _, err = client.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForSystemJob(ctx, jobID, 10*time.Millisecond); err == nil {
+		t.Fatal("the job should not finish before the context is done")
+	}
+}
@@ -76,7 +76,10 @@ func (client *Client) UpdateUserContext(
 	if err != nil {
 		return nil, err
 	}
-	return client.callPut(ctx, u.String(), prms, nil)
+	// the user name is passed as the path parameter
+	a := *prms
+	a.Username = ""
+	return client.callPut(ctx, u.String(), &a, nil)
 }
 
 // DeleteUser deletes a given user.
@@ -5,11 +5,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/pkg/errors"
 )
 
+// systemJobIDBody is the response body of APIs which trigger a system job.
+// Graylog returns no body at some of them, so the id may be empty.
+type systemJobIDBody struct {
+	ID string `json:"id"`
+}
+
 func (client *Client) callGet(
 	ctx context.Context, endpoint string, input, output interface{}) (*ErrorInfo, error) {
 	return client.callAPI(ctx, http.MethodGet, endpoint, input, output)
@@ -72,7 +79,8 @@ func (client *Client) callAPI(
 		return ei, errors.New(ei.Message)
 	}
 	if output != nil {
-		if err := json.NewDecoder(ei.Response.Body).Decode(output); err != nil {
+		// some APIs such as "202 Accepted" APIs may return an empty body
+		if err := json.NewDecoder(ei.Response.Body).Decode(output); err != nil && err != io.EOF {
 			return ei, errors.Wrap(
 				err, "failed to decode response body")
 		}
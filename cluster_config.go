@@ -0,0 +1,120 @@
+package graylog
+
+import (
+	"encoding/json"
+)
+
+const (
+	// MessageProcessorsConfigClass is the class name of MessageProcessorsConfig.
+	MessageProcessorsConfigClass = "org.graylog2.messageprocessors.MessageProcessorsConfig"
+	// SearchesClusterConfigClass is the class name of SearchesClusterConfig.
+	SearchesClusterConfigClass = "org.graylog2.indexer.searches.SearchesClusterConfig"
+	// UrlWhitelistConfigClass is the class name of UrlWhitelistConfig.
+	UrlWhitelistConfigClass = "org.graylog2.system.urlwhitelist.UrlWhitelist"
+)
+
+// ClusterConfig represents a cluster configuration,
+// which is shared by all nodes of the cluster.
+type ClusterConfig interface {
+	// ConfigClass returns the cluster configuration's class name.
+	ConfigClass() string
+}
+
+// NewClusterConfig returns an empty cluster configuration of a given class.
+// For a class which has no typed struct, GenericClusterConfig is returned.
+func NewClusterConfig(class string) ClusterConfig {
+	switch class {
+	case MessageProcessorsConfigClass:
+		return &MessageProcessorsConfig{}
+	case SearchesClusterConfigClass:
+		return &SearchesClusterConfig{}
+	case UrlWhitelistConfigClass:
+		return &UrlWhitelistConfig{}
+	}
+	return &GenericClusterConfig{Class: class}
+}
+
+// MessageProcessorsConfig represents the order and the states of the message processors.
+type MessageProcessorsConfig struct {
+	// the class names of the message processors in the processing order
+	// ex. ["org.graylog2.messageprocessors.MessageFilterChainProcessor"]
+	ProcessorOrder []string `json:"processor_order"`
+	// the class names of the disabled message processors
+	DisabledProcessors []string `json:"disabled_processors"`
+}
+
+// ConfigClass returns the cluster configuration's class name.
+func (cfg *MessageProcessorsConfig) ConfigClass() string {
+	return MessageProcessorsConfigClass
+}
+
+// SearchesClusterConfig represents the search configuration such as the query time range limit.
+type SearchesClusterConfig struct {
+	// ISO 8601 period, "P0D" means no limit
+	// ex. "P30D"
+	QueryTimeRangeLimit string `json:"query_time_range_limit"`
+	// ex. {"PT5M": "Search in the last 5 minutes"}
+	RelativeTimerangeOptions map[string]string `json:"relative_timerange_options"`
+	// ex. {"PT1S": "1 second"}
+	SurroundingTimerangeOptions map[string]string `json:"surrounding_timerange_options"`
+	// ex. ["file", "source"]
+	SurroundingFilterFields []string `json:"surrounding_filter_fields"`
+	AnalysisDisabledFields  []string `json:"analysis_disabled_fields,omitempty"`
+}
+
+// ConfigClass returns the cluster configuration's class name.
+func (cfg *SearchesClusterConfig) ConfigClass() string {
+	return SearchesClusterConfigClass
+}
+
+// UrlWhitelistConfig represents the URLs which Graylog is allowed to access.
+type UrlWhitelistConfig struct {
+	Entries  []UrlWhitelistEntry `json:"entries"`
+	Disabled bool                `json:"disabled"`
+}
+
+// UrlWhitelistEntry represents an entry of the URL whitelist.
+type UrlWhitelistEntry struct {
+	ID string `json:"id"`
+	// ex. "https://example.com/api/"
+	Value string `json:"value"`
+	Title string `json:"title"`
+	// "literal" or "regex"
+	Type string `json:"type"`
+}
+
+// ConfigClass returns the cluster configuration's class name.
+func (cfg *UrlWhitelistConfig) ConfigClass() string {
+	return UrlWhitelistConfigClass
+}
+
+// GenericClusterConfig represents a cluster configuration which has no typed struct.
+// Values is encoded as the cluster configuration itself.
+type GenericClusterConfig struct {
+	Class  string
+	Values map[string]interface{}
+}
+
+// ConfigClass returns the cluster configuration's class name.
+func (cfg *GenericClusterConfig) ConfigClass() string {
+	return cfg.Class
+}
+
+// MarshalJSON is the implementation of the json.Marshaler interface.
+func (cfg *GenericClusterConfig) MarshalJSON() ([]byte, error) {
+	if cfg.Values == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(cfg.Values)
+}
+
+// UnmarshalJSON is the implementation of the json.Unmarshaler interface.
+func (cfg *GenericClusterConfig) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &cfg.Values)
+}
+
+// ClusterConfigClassesBody represents Get Cluster Config Classes API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type ClusterConfigClassesBody struct {
+	Classes []string `json:"classes"`
+}
@@ -0,0 +1,39 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestNewClusterConfig(t *testing.T) {
+	if _, ok := graylog.NewClusterConfig(
+		graylog.SearchesClusterConfigClass).(*graylog.SearchesClusterConfig); !ok {
+		t.Fatal("SearchesClusterConfig should be returned")
+	}
+	cfg := graylog.NewClusterConfig("org.graylog.plugins.foo.FooConfig")
+	if _, ok := cfg.(*graylog.GenericClusterConfig); !ok {
+		t.Fatal("GenericClusterConfig should be returned")
+	}
+	if cfg.ConfigClass() != "org.graylog.plugins.foo.FooConfig" {
+		t.Fatalf(`cfg.ConfigClass() == "%s"`, cfg.ConfigClass())
+	}
+}
+
+func TestGenericClusterConfigJSON(t *testing.T) {
+	cfg := &graylog.GenericClusterConfig{Class: "org.graylog.plugins.foo.FooConfig"}
+	if err := json.Unmarshal([]byte(`{"enabled": true}`), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Values["enabled"] != true {
+		t.Fatalf("cfg.Values == %v", cfg.Values)
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"enabled":true}` {
+		t.Fatalf(`json.Marshal(cfg) == "%s", wanted "{"enabled":true}"`, string(b))
+	}
+}
@@ -0,0 +1,9 @@
+package graylog
+
+// DeflectorSummary represents an index set's deflector status.
+type DeflectorSummary struct {
+	IsUp bool `json:"is_up"`
+	// the index which the deflector points to
+	// ex. "graylog_3"
+	CurrentTarget string `json:"current_target"`
+}
@@ -0,0 +1,58 @@
+package graylog
+
+// IndexInfo represents an Elasticsearch index's information.
+type IndexInfo struct {
+	// ex. "graylog_0"
+	IndexName     string         `json:"index_name"`
+	PrimaryShards *IndexStats    `json:"primary_shards"`
+	AllShards     *IndexStats    `json:"all_shards"`
+	Routing       []ShardRouting `json:"routing"`
+	IsReopened    bool           `json:"is_reopened"`
+}
+
+// IndexStats represents an Elasticsearch index's statistics.
+type IndexStats struct {
+	Documents          IndexDocuments `json:"documents"`
+	StoreSizeBytes     int            `json:"store_size_bytes"`
+	Segments           int            `json:"segments"`
+	OpenSearchContexts int            `json:"open_search_contexts"`
+}
+
+// IndexDocuments represents the number of an index's documents.
+type IndexDocuments struct {
+	Count   int `json:"count"`
+	Deleted int `json:"deleted"`
+}
+
+// ShardRouting represents an Elasticsearch shard's routing.
+type ShardRouting struct {
+	ID int `json:"id"`
+	// ex. "STARTED"
+	State        string `json:"state"`
+	Active       bool   `json:"active"`
+	Primary      bool   `json:"primary"`
+	NodeID       string `json:"node_id"`
+	NodeName     string `json:"node_name"`
+	NodeHostname string `json:"node_hostname"`
+	RelocatingTo string `json:"relocating_to,omitempty"`
+}
+
+// OpenIndicesInfo represents Get open indices API's response body.
+type OpenIndicesInfo struct {
+	// key is the index name
+	Indices map[string]IndexInfo `json:"indices"`
+}
+
+// ClosedIndices represents Get closed or reopened indices API's response body.
+type ClosedIndices struct {
+	// index names
+	Indices []string `json:"indices"`
+	Total   int      `json:"total"`
+}
+
+// AllIndices represents Get indices API's response body.
+type AllIndices struct {
+	All      OpenIndicesInfo `json:"all"`
+	Closed   ClosedIndices   `json:"closed"`
+	Reopened ClosedIndices   `json:"reopened"`
+}
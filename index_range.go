@@ -0,0 +1,23 @@
+package graylog
+
+// IndexRange represents an index's time range.
+type IndexRange struct {
+	// ex. "graylog_0"
+	IndexName string `json:"index_name"`
+	// the timestamp of the oldest message in the index
+	// ex. "2018-02-20T11:37:19.305Z"
+	Begin string `json:"begin"`
+	// the timestamp of the newest message in the index
+	End string `json:"end"`
+	// when the range was calculated
+	CalculatedAt string `json:"calculated_at"`
+	// how long the calculation took in milliseconds
+	TookMs int `json:"took_ms"`
+}
+
+// IndexRangesBody represents Get Index Ranges API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type IndexRangesBody struct {
+	Ranges []IndexRange `json:"ranges"`
+	Total  int          `json:"total"`
+}
@@ -60,32 +60,39 @@ type IndexSet struct {
 
 	ID string `json:"id,omitempty" v-create:"isdefault"`
 
-	Description               string         `json:"description,omitempty"`
-	Replicas                  int            `json:"replicas,omitempty"`
-	IndexOptimizationDisabled bool           `json:"index_optimization_disabled,omitempty"`
-	Writable                  bool           `json:"writable,omitempty"`
-	Default                   bool           `json:"default,omitempty"`
-	Stats                     *IndexSetStats `json:"-"`
+	Description               string `json:"description,omitempty"`
+	Replicas                  int    `json:"replicas,omitempty"`
+	IndexOptimizationDisabled bool   `json:"index_optimization_disabled,omitempty"`
+	Writable                  bool   `json:"writable,omitempty"`
+	Default                   bool   `json:"default,omitempty"`
+	// Graylog 3.0 or later. The interval of the field types' refresh in milliseconds.
+	FieldTypeRefreshInterval int            `json:"field_type_refresh_interval,omitempty"`
+	Stats                    *IndexSetStats `json:"-"`
 }
 
 // NewUpdateParams converts an IndexSet to IndexSetUpdateParams.
 func (is *IndexSet) NewUpdateParams() *IndexSetUpdateParams {
+	var fieldTypeRefreshInterval *int
+	if is.FieldTypeRefreshInterval != 0 {
+		fieldTypeRefreshInterval = ptr.PInt(is.FieldTypeRefreshInterval)
+	}
 	return &IndexSetUpdateParams{
-		Title:                  is.Title,
-		IndexPrefix:            is.IndexPrefix,
-		RotationStrategyClass:  is.RotationStrategyClass,
-		RotationStrategy:       is.RotationStrategy,
-		RetentionStrategyClass: is.RetentionStrategyClass,
-		RetentionStrategy:      is.RetentionStrategy,
-		IndexAnalyzer:          is.IndexAnalyzer,
-		Shards:                 is.Shards,
+		Title:                           is.Title,
+		IndexPrefix:                     is.IndexPrefix,
+		RotationStrategyClass:           is.RotationStrategyClass,
+		RotationStrategy:                is.RotationStrategy,
+		RetentionStrategyClass:          is.RetentionStrategyClass,
+		RetentionStrategy:               is.RetentionStrategy,
+		IndexAnalyzer:                   is.IndexAnalyzer,
+		Shards:                          is.Shards,
 		IndexOptimizationMaxNumSegments: is.IndexOptimizationMaxNumSegments,
-		ID: is.ID,
+		ID:                              is.ID,
 
 		Description:               ptr.PStr(is.Description),
 		Replicas:                  ptr.PInt(is.Replicas),
 		IndexOptimizationDisabled: ptr.PBool(is.IndexOptimizationDisabled),
 		Writable:                  ptr.PBool(is.Writable),
+		FieldTypeRefreshInterval:  fieldTypeRefreshInterval,
 	}
 }
 
@@ -101,12 +108,14 @@ type IndexSetUpdateParams struct {
 	IndexAnalyzer                   string             `json:"index_analyzer" v-update:"required"`
 	Shards                          int                `json:"shards" v-update:"required"`
 	IndexOptimizationMaxNumSegments int                `json:"index_optimization_max_num_segments" v-update:"required"`
-	ID                              string             `json:"id" v-update:"required,objectid"`
+	ID                              string             `json:"id,omitempty" v-update:"required,objectid"`
 
 	Description               *string `json:"description,omitempty"`
 	Replicas                  *int    `json:"replicas,omitempty"`
 	IndexOptimizationDisabled *bool   `json:"index_optimization_disabled,omitempty"`
 	Writable                  *bool   `json:"writable,omitempty"`
+	// Graylog 3.0 or later
+	FieldTypeRefreshInterval *int `json:"field_type_refresh_interval,omitempty"`
 }
 
 // SetCreateDefaultValues sets the default values of Create Index Set API.
@@ -26,8 +26,12 @@ type Input struct {
 	CreatedAt string `json:"created_at,omitempty" v-create:"isdefault"`
 	// ex. "admin"
 	CreatorUserID string `json:"creator_user_id,omitempty" v-create:"isdefault"`
+	// Static fields are added to every message the input receives.
+	// They can't be changed by the Create and Update Input APIs,
+	// so use the Add and Delete Input Static Field APIs.
+	// ex. {"env": "production"}
+	StaticFields map[string]string `json:"static_fields,omitempty"`
 	// ContextPack `json:"context_pack,omitempty"`
-	// StaticFields `json:"static_fields,omitempty"`
 }
 
 // Type returns the input's type.
@@ -70,6 +74,7 @@ func (input *Input) ToData() (*InputData, error) {
 		Node:          input.Node,
 		CreatedAt:     input.CreatedAt,
 		CreatorUserID: input.CreatorUserID,
+		StaticFields:  input.StaticFields,
 		Attrs:         map[string]interface{}{},
 	}
 	if input.Attrs == nil {
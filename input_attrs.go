@@ -63,24 +63,109 @@ func init() {
 		panic(err)
 	}
 	for _, attrs := range inputAttrsList {
-		a := attrs()
-		ts := reflect.ValueOf(a).Elem().Type()
-		n := ts.NumField()
-		for i := 0; i < n; i++ {
-			f := ts.Field(i)
-			tag := strings.Split(f.Tag.Get("json"), ",")[0]
-			switch f.Type.Kind() {
-			case reflect.String:
-				InputAttrsStrFieldSet.Add(tag)
-			case reflect.Int:
-				InputAttrsIntFieldSet.Add(tag)
-			case reflect.Bool:
-				InputAttrsBoolFieldSet.Add(tag)
-			default:
-				panic(fmt.Sprintf("invalid type: %v", f.Type.Kind()))
+		if err := addInputAttrsFields(attrs()); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterInputAttrs registers a custom input type such as a third-party plugin input.
+// The constructor must return a pointer to a struct whose InputType method returns typeName,
+// and the struct's fields must be string, int or bool with json tags.
+// The fields are added to InputAttrsStrFieldSet, InputAttrsIntFieldSet and InputAttrsBoolFieldSet,
+// so register custom input types before the Terraform provider's schema is built.
+//
+//   if err := graylog.RegisterInputAttrs("com.example.graylog.MyInput", func() graylog.InputAttrs {
+//   	return &MyInputAttrs{}
+//   }); err != nil {
+//   	return err
+//   }
+func RegisterInputAttrs(typeName string, f NewInputAttrs) error {
+	if typeName == "" {
+		return fmt.Errorf("input type is empty")
+	}
+	if f == nil {
+		return fmt.Errorf("the constructor of InputAttrs is nil")
+	}
+	a := f()
+	if a == nil {
+		return fmt.Errorf("the constructor of InputAttrs returns nil")
+	}
+	if a.InputType() != typeName {
+		return fmt.Errorf(
+			"the input type of InputAttrs is %s, wanted %s", a.InputType(), typeName)
+	}
+	if err := validateInputAttrsFields(a); err != nil {
+		return err
+	}
+	if err := SetInputAttrs(f); err != nil {
+		return err
+	}
+	return addInputAttrsFields(a)
+}
+
+// validateInputAttrsFields validates that the attrs's fields can be added to the field sets.
+func validateInputAttrsFields(attrs InputAttrs) error {
+	v := reflect.ValueOf(attrs)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("NewInputAttrs must return pointer to struct")
+	}
+	ts := v.Elem().Type()
+	n := ts.NumField()
+	for i := 0; i < n; i++ {
+		f := ts.Field(i)
+		if f.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			return fmt.Errorf("json tag of the field %s is required", f.Name)
+		}
+		var sets []set.StrSet
+		switch f.Type.Kind() {
+		case reflect.String:
+			sets = []set.StrSet{InputAttrsIntFieldSet, InputAttrsBoolFieldSet}
+		case reflect.Int:
+			sets = []set.StrSet{InputAttrsStrFieldSet, InputAttrsBoolFieldSet}
+		case reflect.Bool:
+			sets = []set.StrSet{InputAttrsStrFieldSet, InputAttrsIntFieldSet}
+		default:
+			return fmt.Errorf("invalid type of the field %s: %v", f.Name, f.Type.Kind())
+		}
+		for _, s := range sets {
+			if s.Has(tag) {
+				return fmt.Errorf(
+					"the field %s conflicts with the other input type's field", tag)
 			}
 		}
 	}
+	return nil
+}
+
+// addInputAttrsFields adds the attrs's fields to the field sets.
+func addInputAttrsFields(attrs InputAttrs) error {
+	if err := validateInputAttrsFields(attrs); err != nil {
+		return err
+	}
+	ts := reflect.ValueOf(attrs).Elem().Type()
+	n := ts.NumField()
+	for i := 0; i < n; i++ {
+		f := ts.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch f.Type.Kind() {
+		case reflect.String:
+			InputAttrsStrFieldSet.Add(tag)
+		case reflect.Int:
+			InputAttrsIntFieldSet.Add(tag)
+		case reflect.Bool:
+			InputAttrsBoolFieldSet.Add(tag)
+		}
+	}
+	return nil
 }
 
 // GetUnknownTypeInputAttrsIntf returns an unknown type InputAttrs.
@@ -52,3 +52,79 @@ func TestSetFuncGetInputAttrsByType(t *testing.T) {
 		t.Fatalf(`a.Foo = "%s", wanted "foo"`, a.Foo)
 	}
 }
+
+type pluginInputAttrs struct {
+	Host    string `json:"plugin_test_host,omitempty"`
+	Workers int    `json:"plugin_test_workers,omitempty"`
+	Debug   bool   `json:"plugin_test_debug,omitempty"`
+}
+
+const pluginInputType = "com.example.graylog.PluginTestInput"
+
+func (attrs *pluginInputAttrs) InputType() string {
+	return pluginInputType
+}
+
+func TestRegisterInputAttrs(t *testing.T) {
+	if err := graylog.RegisterInputAttrs(pluginInputType, func() graylog.InputAttrs {
+		return &pluginInputAttrs{}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !graylog.InputAttrsStrFieldSet.Has("plugin_test_host") {
+		t.Fatal("plugin_test_host should be added to InputAttrsStrFieldSet")
+	}
+	if !graylog.InputAttrsIntFieldSet.Has("plugin_test_workers") {
+		t.Fatal("plugin_test_workers should be added to InputAttrsIntFieldSet")
+	}
+	if !graylog.InputAttrsBoolFieldSet.Has("plugin_test_debug") {
+		t.Fatal("plugin_test_debug should be added to InputAttrsBoolFieldSet")
+	}
+	data := &graylog.InputData{
+		Type:  pluginInputType,
+		Attrs: map[string]interface{}{"plugin_test_host": "localhost", "plugin_test_workers": 2}}
+	input := &graylog.Input{}
+	if err := data.ToInput(input); err != nil {
+		t.Fatal(err)
+	}
+	attrs, ok := input.Attrs.(*pluginInputAttrs)
+	if !ok {
+		t.Fatalf("input.Attrs is %T, wanted *pluginInputAttrs", input.Attrs)
+	}
+	if attrs.Host != "localhost" || attrs.Workers != 2 {
+		t.Fatalf("attrs == %+v", attrs)
+	}
+
+	// invalid constructors
+	if err := graylog.RegisterInputAttrs("", func() graylog.InputAttrs {
+		return &pluginInputAttrs{}
+	}); err == nil {
+		t.Fatal("input type is required")
+	}
+	if err := graylog.RegisterInputAttrs(pluginInputType, nil); err == nil {
+		t.Fatal("constructor is required")
+	}
+	if err := graylog.RegisterInputAttrs("foo", func() graylog.InputAttrs {
+		return &pluginInputAttrs{}
+	}); err == nil {
+		t.Fatal("input type should be different")
+	}
+	if err := graylog.RegisterInputAttrs("custom", func() graylog.InputAttrs {
+		return CustomInputAttrs{Type: "custom"}
+	}); err == nil {
+		t.Fatal("constructor should return pointer")
+	}
+	if err := graylog.RegisterInputAttrs("conflict", func() graylog.InputAttrs {
+		return &conflictInputAttrs{}
+	}); err == nil {
+		t.Fatal("port is int field")
+	}
+}
+
+type conflictInputAttrs struct {
+	Port string `json:"port,omitempty"`
+}
+
+func (attrs *conflictInputAttrs) InputType() string {
+	return "conflict"
+}
@@ -0,0 +1,34 @@
+package graylog
+
+const (
+	// InputStateRunning means the input is running and receives messages.
+	InputStateRunning InputState = "RUNNING"
+	// InputStateFailed means the input failed to start or crashed.
+	InputStateFailed InputState = "FAILED"
+	// InputStateStopped means the input is stopped.
+	InputStateStopped InputState = "STOPPED"
+	// InputStateStarting means the input is being started.
+	InputStateStarting InputState = "STARTING"
+)
+
+// InputState represents an input's runtime state.
+// ex. "RUNNING"
+type InputState string
+
+// InputStateSummary represents an input's runtime state summary.
+type InputStateSummary struct {
+	// ex. "5a90cee5c006c60001efbbf5"
+	ID    string     `json:"id"`
+	State InputState `json:"state"`
+	// ex. "2018-02-24T03:02:26.001Z"
+	StartedAt string `json:"started_at,omitempty"`
+	// the reason why the input failed
+	DetailedMessage string `json:"detailed_message,omitempty"`
+	MessageInput    *Input `json:"message_input,omitempty"`
+}
+
+// InputStatesBody represents Get Input States API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type InputStatesBody struct {
+	States []InputStateSummary `json:"states"`
+}
@@ -30,3 +30,14 @@ func TestInputNewUpdateParams(t *testing.T) {
 		t.Fatalf(`prms.ID = "%s", wanted "%s"`, prms.ID, input.ID)
 	}
 }
+
+func TestInputUnmarshalJSONStaticFields(t *testing.T) {
+	input := &graylog.Input{}
+	b := []byte(`{"type": "org.graylog.plugins.beats.BeatsInput", "static_fields": {"env": "test"}}`)
+	if err := json.Unmarshal(b, input); err != nil {
+		t.Fatal(err)
+	}
+	if input.StaticFields["env"] != "test" {
+		t.Fatalf(`input.StaticFields["env"] = "%s", wanted "test"`, input.StaticFields["env"])
+	}
+}
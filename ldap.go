@@ -0,0 +1,80 @@
+package graylog
+
+import (
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// LDAPSettings represents the LDAP settings.
+// SystemPassword is write only, so Get LDAP Settings API doesn't return it
+// but returns SystemPasswordSet instead.
+type LDAPSettings struct {
+	Enabled bool `json:"enabled"`
+	// the user name to bind to the LDAP server
+	// ex. "cn=admin,dc=example,dc=com"
+	SystemUsername string `json:"system_username"`
+	// the password to bind to the LDAP server
+	// When the password is empty, Update LDAP Settings API keeps the current password.
+	SystemPassword string `json:"system_password,omitempty"`
+	// whether the system password is set, which is only returned by Get LDAP Settings API
+	SystemPasswordSet bool `json:"system_password_set,omitempty"`
+	// ex. "ldap://localhost:389"
+	LDAPURI              string `json:"ldap_uri"`
+	UseStartTLS          bool   `json:"use_start_tls"`
+	TrustAllCertificates bool   `json:"trust_all_certificates"`
+	ActiveDirectory      bool   `json:"active_directory"`
+	// ex. "cn=users,dc=example,dc=com"
+	SearchBase string `json:"search_base"`
+	// ex. "(&(objectClass=inetOrgPerson)(uid={0}))"
+	SearchPattern string `json:"search_pattern"`
+	// ex. "cn"
+	DisplayNameAttribute string `json:"display_name_attribute"`
+	// the role which is assigned to all LDAP users
+	// ex. "Reader"
+	DefaultGroup string `json:"default_group"`
+	// the map of LDAP groups to Graylog roles
+	// ex. {"admins": "Admin"}
+	GroupMapping map[string]string `json:"group_mapping,omitempty"`
+	// ex. "ou=groups,dc=example,dc=com"
+	GroupSearchBase string `json:"group_search_base,omitempty"`
+	// ex. "cn"
+	GroupIDAttribute string `json:"group_id_attribute,omitempty"`
+	// the additional roles which are assigned to all LDAP users
+	AdditionalDefaultGroups set.StrSet `json:"additional_default_groups,omitempty"`
+	// ex. "(objectClass=groupOfNames)"
+	GroupSearchPattern string `json:"group_search_pattern,omitempty"`
+}
+
+// LDAPTestConfigRequest represents Test LDAP Configuration API's request body.
+// If SystemPassword is empty, the password of the current LDAP settings is used.
+// If TestConnectOnly is false, the login of Principal is tested too.
+type LDAPTestConfigRequest struct {
+	SystemUsername       string `json:"system_username"`
+	SystemPassword       string `json:"system_password,omitempty"`
+	LDAPURI              string `json:"ldap_uri"`
+	UseStartTLS          bool   `json:"use_start_tls"`
+	TrustAllCertificates bool   `json:"trust_all_certificates"`
+	ActiveDirectory      bool   `json:"active_directory"`
+	SearchBase           string `json:"search_base"`
+	SearchPattern        string `json:"search_pattern"`
+	// the user name of the login test
+	Principal string `json:"principal,omitempty"`
+	// the password of the login test
+	Password           string `json:"password,omitempty"`
+	TestConnectOnly    bool   `json:"test_connect_only"`
+	GroupSearchBase    string `json:"group_search_base,omitempty"`
+	GroupIDAttribute   string `json:"group_id_attribute,omitempty"`
+	GroupSearchPattern string `json:"group_search_pattern,omitempty"`
+}
+
+// LDAPTestConfigResponse represents Test LDAP Configuration API's response body.
+type LDAPTestConfigResponse struct {
+	Connected          bool `json:"connected"`
+	LoginAuthenticated bool `json:"login_authenticated"`
+	// the error message when the test fails
+	SystemMessage string `json:"system_message,omitempty"`
+	// ex. "uid=foo,cn=users,dc=example,dc=com"
+	UserDN string `json:"user_dn,omitempty"`
+	// the attributes of the principal's LDAP entry
+	Entry  map[string]string `json:"entry,omitempty"`
+	Groups set.StrSet        `json:"groups,omitempty"`
+}
@@ -0,0 +1,268 @@
+package graylog
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// MetricTypeGauge is the type of a metric which is an instantaneous value.
+	MetricTypeGauge MetricType = "gauge"
+	// MetricTypeCounter is the type of a metric which is incremented and decremented.
+	MetricTypeCounter MetricType = "counter"
+	// MetricTypeMeter is the type of a metric which measures the rate of events.
+	MetricTypeMeter MetricType = "meter"
+	// MetricTypeTimer is the type of a metric which measures the rate and the duration of events.
+	MetricTypeTimer MetricType = "timer"
+	// MetricTypeHistogram is the type of a metric which measures the distribution of values.
+	MetricTypeHistogram MetricType = "histogram"
+)
+
+// MetricType represents a metric's type.
+// ex. "meter"
+type MetricType string
+
+// Gauge represents a gauge metric.
+type Gauge struct {
+	// Graylog has not only numeric gauges but also string gauges.
+	Value interface{} `json:"value"`
+}
+
+// Float64 returns the gauge's value as float64.
+// If the value isn't a number, false is returned.
+func (gauge *Gauge) Float64() (float64, bool) {
+	switch v := gauge.Value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Counter represents a counter metric.
+type Counter struct {
+	Count int64 `json:"count"`
+}
+
+// Meter represents a meter metric.
+type Meter struct {
+	Count    int64   `json:"count"`
+	MeanRate float64 `json:"mean_rate"`
+	M1Rate   float64 `json:"m1_rate"`
+	M5Rate   float64 `json:"m5_rate"`
+	M15Rate  float64 `json:"m15_rate"`
+	// ex. "events/second"
+	Units string `json:"units,omitempty"`
+}
+
+// Timer represents a timer metric.
+// The durations are in DurationUnits and the rates are in RateUnits.
+type Timer struct {
+	Count    int64   `json:"count"`
+	Max      float64 `json:"max"`
+	Mean     float64 `json:"mean"`
+	Min      float64 `json:"min"`
+	P50      float64 `json:"p50"`
+	P75      float64 `json:"p75"`
+	P95      float64 `json:"p95"`
+	P98      float64 `json:"p98"`
+	P99      float64 `json:"p99"`
+	P999     float64 `json:"p999"`
+	Stddev   float64 `json:"stddev"`
+	MeanRate float64 `json:"mean_rate"`
+	M1Rate   float64 `json:"m1_rate"`
+	M5Rate   float64 `json:"m5_rate"`
+	M15Rate  float64 `json:"m15_rate"`
+	// ex. "milliseconds"
+	DurationUnits string `json:"duration_units,omitempty"`
+	// ex. "calls/second"
+	RateUnits string `json:"rate_units,omitempty"`
+}
+
+// Metric represents a metric which Get Multiple Metrics API returns.
+// One of Gauge, Counter, Meter and Timer is set according to Type.
+// A histogram metric has none of them.
+type Metric struct {
+	// ex. "org.graylog2.throughput.input"
+	FullName string `json:"full_name"`
+	// ex. "input"
+	Name    string     `json:"name"`
+	Type    MetricType `json:"type"`
+	Gauge   *Gauge     `json:"-"`
+	Counter *Counter   `json:"-"`
+	Meter   *Meter     `json:"-"`
+	Timer   *Timer     `json:"-"`
+}
+
+// metricWire is Metric's representation in the API.
+type metricWire struct {
+	FullName string          `json:"full_name"`
+	Name     string          `json:"name"`
+	Type     MetricType      `json:"type"`
+	Metric   json.RawMessage `json:"metric,omitempty"`
+}
+
+// metricRateWire is the rate of a meter or timer in Get Multiple Metrics API.
+type metricRateWire struct {
+	Total         float64 `json:"total"`
+	Mean          float64 `json:"mean"`
+	OneMinute     float64 `json:"one_minute"`
+	FiveMinute    float64 `json:"five_minute"`
+	FifteenMinute float64 `json:"fifteen_minute"`
+}
+
+// metricTimeWire is the durations of a timer in Get Multiple Metrics API.
+type metricTimeWire struct {
+	Min          float64 `json:"min"`
+	Max          float64 `json:"max"`
+	Mean         float64 `json:"mean"`
+	StdDev       float64 `json:"std_dev"`
+	Percentile95 float64 `json:"95th_percentile"`
+	Percentile98 float64 `json:"98th_percentile"`
+	Percentile99 float64 `json:"99th_percentile"`
+}
+
+type meterWire struct {
+	Rate     metricRateWire `json:"rate"`
+	RateUnit string         `json:"rate_unit,omitempty"`
+}
+
+type timerWire struct {
+	Time         metricTimeWire `json:"time"`
+	Rate         metricRateWire `json:"rate"`
+	DurationUnit string         `json:"duration_unit,omitempty"`
+	RateUnit     string         `json:"rate_unit,omitempty"`
+}
+
+// MarshalJSON is the implementation of the json.Marshaler interface.
+func (metric *Metric) MarshalJSON() ([]byte, error) {
+	var body interface{}
+	switch metric.Type {
+	case MetricTypeGauge:
+		body = metric.Gauge
+	case MetricTypeCounter:
+		body = metric.Counter
+	case MetricTypeMeter:
+		if m := metric.Meter; m != nil {
+			body = &meterWire{
+				Rate: metricRateWire{
+					Total: float64(m.Count), Mean: m.MeanRate, OneMinute: m.M1Rate,
+					FiveMinute: m.M5Rate, FifteenMinute: m.M15Rate},
+				RateUnit: m.Units}
+		}
+	case MetricTypeTimer:
+		if t := metric.Timer; t != nil {
+			body = &timerWire{
+				Time: metricTimeWire{
+					Min: t.Min, Max: t.Max, Mean: t.Mean, StdDev: t.Stddev,
+					Percentile95: t.P95, Percentile98: t.P98, Percentile99: t.P99},
+				Rate: metricRateWire{
+					Total: float64(t.Count), Mean: t.MeanRate, OneMinute: t.M1Rate,
+					FiveMinute: t.M5Rate, FifteenMinute: t.M15Rate},
+				DurationUnit: t.DurationUnits, RateUnit: t.RateUnits}
+		}
+	}
+	w := &metricWire{FullName: metric.FullName, Name: metric.Name, Type: metric.Type}
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		w.Metric = b
+	}
+	return json.Marshal(w)
+}
+
+// UnmarshalJSON is the implementation of the json.Unmarshaler interface.
+func (metric *Metric) UnmarshalJSON(b []byte) error {
+	w := &metricWire{}
+	if err := json.Unmarshal(b, w); err != nil {
+		return err
+	}
+	*metric = Metric{FullName: w.FullName, Name: w.Name, Type: w.Type}
+	if len(w.Metric) == 0 {
+		return nil
+	}
+	switch w.Type {
+	case MetricTypeGauge:
+		metric.Gauge = &Gauge{}
+		return json.Unmarshal(w.Metric, metric.Gauge)
+	case MetricTypeCounter:
+		metric.Counter = &Counter{}
+		return json.Unmarshal(w.Metric, metric.Counter)
+	case MetricTypeMeter:
+		m := &meterWire{}
+		if err := json.Unmarshal(w.Metric, m); err != nil {
+			return fmt.Errorf("failed to parse the meter %s: %s", w.FullName, err)
+		}
+		metric.Meter = &Meter{
+			Count: int64(m.Rate.Total), MeanRate: m.Rate.Mean, M1Rate: m.Rate.OneMinute,
+			M5Rate: m.Rate.FiveMinute, M15Rate: m.Rate.FifteenMinute,
+			Units: m.RateUnit}
+	case MetricTypeTimer:
+		t := &timerWire{}
+		if err := json.Unmarshal(w.Metric, t); err != nil {
+			return fmt.Errorf("failed to parse the timer %s: %s", w.FullName, err)
+		}
+		metric.Timer = &Timer{
+			Count: int64(t.Rate.Total), Min: t.Time.Min, Max: t.Time.Max,
+			Mean: t.Time.Mean, Stddev: t.Time.StdDev, P95: t.Time.Percentile95,
+			P98: t.Time.Percentile98, P99: t.Time.Percentile99,
+			MeanRate: t.Rate.Mean, M1Rate: t.Rate.OneMinute,
+			M5Rate: t.Rate.FiveMinute, M15Rate: t.Rate.FifteenMinute,
+			DurationUnits: t.DurationUnit, RateUnits: t.RateUnit}
+	}
+	return nil
+}
+
+// MetricsBody represents Get Multiple Metrics API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type MetricsBody struct {
+	Metrics []Metric `json:"metrics"`
+	Total   int      `json:"total"`
+}
+
+// MetricNamesBody represents Get Multiple Metrics API's request body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type MetricNamesBody struct {
+	Metrics []string `json:"metrics"`
+}
+
+// ThroughputBody represents Get Throughput API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type ThroughputBody struct {
+	// the number of messages processed in the last second
+	Throughput int `json:"throughput"`
+}
+
+// JournalSummary represents the message journal's status of a node.
+type JournalSummary struct {
+	Enabled                   bool  `json:"enabled"`
+	AppendEventsPerSecond     int   `json:"append_events_per_second"`
+	ReadEventsPerSecond       int   `json:"read_events_per_second"`
+	UncommittedJournalEntries int   `json:"uncommitted_journal_entries"`
+	JournalSize               int64 `json:"journal_size"`
+	JournalSizeLimit          int64 `json:"journal_size_limit"`
+	NumberOfSegments          int   `json:"number_of_segments"`
+	// ex. "2018-02-24T03:02:26.001Z"
+	OldestSegment string         `json:"oldest_segment,omitempty"`
+	JournalConfig *JournalConfig `json:"journal_config,omitempty"`
+}
+
+// JournalConfig represents the message journal's configuration.
+type JournalConfig struct {
+	// ex. "/usr/share/graylog/data/journal"
+	Directory   string `json:"directory"`
+	SegmentSize int64  `json:"segment_size"`
+	// in milliseconds
+	SegmentAge int64 `json:"segment_age"`
+	MaxSize    int64 `json:"max_size"`
+	// in milliseconds
+	MaxAge        int64 `json:"max_age"`
+	FlushInterval int64 `json:"flush_interval"`
+	// in milliseconds
+	FlushAge int64 `json:"flush_age"`
+}
@@ -0,0 +1,75 @@
+package graylog_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+func TestMetricUnmarshalJSON(t *testing.T) {
+	b := []byte(`{
+  "full_name": "org.graylog2.throughput.input",
+  "name": "input",
+  "type": "meter",
+  "metric": {
+    "rate": {
+      "total": 42, "mean": 1.5, "one_minute": 2, "five_minute": 1, "fifteen_minute": 0.5
+    },
+    "rate_unit": "events/second"
+  }
+}`)
+	metric := &graylog.Metric{}
+	if err := json.Unmarshal(b, metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.Meter == nil {
+		t.Fatal("metric.Meter is nil")
+	}
+	if metric.Meter.Count != 42 || metric.Meter.M1Rate != 2 {
+		t.Fatalf("metric.Meter == %+v", metric.Meter)
+	}
+	b = []byte(`{
+  "full_name": "org.graylog2.buffers.process.size",
+  "name": "size",
+  "type": "gauge",
+  "metric": {"value": 65536}
+}`)
+	metric = &graylog.Metric{}
+	if err := json.Unmarshal(b, metric); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := metric.Gauge.Float64(); !ok || v != 65536 {
+		t.Fatalf("metric.Gauge.Value == %v, wanted 65536", metric.Gauge.Value)
+	}
+}
+
+func TestMetricMarshalJSON(t *testing.T) {
+	data := []graylog.Metric{
+		{FullName: "a.gauge", Name: "gauge", Type: graylog.MetricTypeGauge,
+			Gauge: &graylog.Gauge{Value: 1.0}},
+		{FullName: "a.counter", Name: "counter", Type: graylog.MetricTypeCounter,
+			Counter: &graylog.Counter{Count: 2}},
+		{FullName: "a.meter", Name: "meter", Type: graylog.MetricTypeMeter,
+			Meter: &graylog.Meter{Count: 3, M5Rate: 0.5, Units: "events/second"}},
+		{FullName: "a.timer", Name: "timer", Type: graylog.MetricTypeTimer,
+			Timer: &graylog.Timer{Count: 4, P99: 10, DurationUnits: "microseconds"}},
+	}
+	for _, exp := range data {
+		b, err := json.Marshal(&exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		act := &graylog.Metric{}
+		if err := json.Unmarshal(b, act); err != nil {
+			t.Fatal(err)
+		}
+		ab, err := json.Marshal(act)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(ab) != string(b) {
+			t.Fatalf("%s: %s != %s", exp.Type, string(ab), string(b))
+		}
+	}
+}
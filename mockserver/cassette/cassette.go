@@ -0,0 +1,253 @@
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// sanitizedValue replaces credentials in recorded bodies.
+const sanitizedValue = "********"
+
+var (
+	// sensitiveKeyParts are substrings of the JSON keys and the query parameters whose values are sanitized,
+	// for example "password", "broker_password" and "aws_secret_key".
+	sensitiveKeyParts = []string{"password", "secret", "token", "session_id"}
+	// sensitiveKeySuffixes are suffixes of the keys whose values are sanitized,
+	// for example "aws_access_key".
+	sensitiveKeySuffixes = []string{"_key"}
+	// sensitivePathSegments are the path segments whose next segment is sanitized,
+	// for example the token of "/users/{username}/tokens/{token}".
+	sensitivePathSegments = map[string]struct{}{
+		"tokens":   {},
+		"sessions": {},
+	}
+)
+
+// ignoredHeaders are response headers which aren't recorded.
+var ignoredHeaders = []string{
+	"Set-Cookie", "Date", "Content-Length", "Connection", "Transfer-Encoding",
+}
+
+// Cassette is a list of recorded request/response pairs.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("failed to parse the cassette file %s: %s", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to a file.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Matcher decides which fields of requests are compared to find the recorded response.
+// The method and the path are always compared.
+type Matcher struct {
+	Query bool
+	// If Body is true, JSON bodies are compared regardless of the order of keys.
+	Body bool
+}
+
+// ParseMatcher parses a comma separated list of the compared fields such as "method,path,query".
+// The fields are "method", "path", "query" and "body".
+func ParseMatcher(s string) (Matcher, error) {
+	m := Matcher{}
+	for _, field := range strings.Split(s, ",") {
+		switch strings.TrimSpace(field) {
+		case "", "method", "path":
+		case "query":
+			m.Query = true
+		case "body":
+			m.Body = true
+		default:
+			return m, fmt.Errorf(
+				`invalid field %s. The field must be either "method", "path", "query" or "body"`, field)
+		}
+	}
+	return m, nil
+}
+
+// Match returns whether the recorded request matches the request.
+func (m Matcher) Match(recorded, req *Request) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path {
+		return false
+	}
+	if m.Query && recorded.Query != req.Query {
+		return false
+	}
+	if m.Body && !equalBody(recorded.Body, req.Body) {
+		return false
+	}
+	return true
+}
+
+// equalBody compares bodies as JSON if possible.
+func equalBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// isSensitiveKey returns whether the value of a given key is sanitized.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeRequest returns a copy of the request whose credentials are replaced.
+func sanitizeRequest(req *Request) Request {
+	return Request{
+		Method: req.Method, Path: sanitizePath(req.Path),
+		Query: sanitizeQuery(req.Query), Body: sanitizeBody(req.Body)}
+}
+
+// sanitizePath replaces the path segments which follow sensitive segments.
+func sanitizePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i := 1; i < len(segments); i++ {
+		if _, ok := sensitivePathSegments[segments[i-1]]; ok && segments[i] != "" {
+			segments[i] = sanitizedValue
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// sanitizeQuery replaces the values of sensitive query parameters.
+// If the query isn't changed, the query is returned as it is.
+func sanitizeQuery(query string) string {
+	if query == "" {
+		return query
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	changed := false
+	for k, v := range values {
+		if !isSensitiveKey(k) {
+			continue
+		}
+		for i := range v {
+			v[i] = sanitizedValue
+		}
+		changed = true
+	}
+	if !changed {
+		return query
+	}
+	return values.Encode()
+}
+
+// sanitizeBody replaces credentials in a JSON body.
+// If the body isn't JSON, the body is returned as it is.
+func sanitizeBody(body string) string {
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return body
+	}
+	if !sanitize(data) {
+		return body
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+// sanitize replaces the values of sensitive keys and returns whether data is changed.
+// Objects and arrays of sensitive keys such as "tokens" are sanitized recursively, so their structure is kept.
+func sanitize(data interface{}) bool {
+	changed := false
+	switch d := data.(type) {
+	case map[string]interface{}:
+		for k, v := range d {
+			if isSensitiveKey(k) {
+				switch v.(type) {
+				case string, float64, bool:
+					d[k] = sanitizedValue
+					changed = true
+					continue
+				}
+			}
+			if sanitize(v) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, v := range d {
+			if sanitize(v) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// sanitizeHeader returns a copy of the response header without ignored headers.
+func sanitizeHeader(header http.Header) http.Header {
+	h := http.Header{}
+	for k, v := range header {
+		h[k] = v
+	}
+	for _, k := range ignoredHeaders {
+		h.Del(k)
+	}
+	return h
+}
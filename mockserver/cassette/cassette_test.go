@@ -0,0 +1,241 @@
+package cassette_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/cassette"
+)
+
+func TestParseMatcher(t *testing.T) {
+	m, err := cassette.ParseMatcher("method,path,query")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Query || m.Body {
+		t.Fatalf("matcher = %+v", m)
+	}
+	if _, err := cassette.ParseMatcher("method,header"); err == nil {
+		t.Fatal("an invalid field should be error")
+	}
+}
+
+func TestMatcherMatch(t *testing.T) {
+	recorded := &cassette.Request{
+		Method: "POST", Path: "/api/roles", Query: "foo=bar",
+		Body: `{"name": "foo", "permissions": ["*"]}`}
+	req := &cassette.Request{
+		Method: "POST", Path: "/api/roles", Query: "foo=baz",
+		Body: `{"permissions":["*"],"name":"foo"}`}
+	if !(cassette.Matcher{Body: true}).Match(recorded, req) {
+		t.Fatal("JSON bodies should be compared regardless of the order of keys")
+	}
+	if (cassette.Matcher{Query: true}).Match(recorded, req) {
+		t.Fatal("the queries are different")
+	}
+	req.Method = "PUT"
+	if (cassette.Matcher{}).Match(recorded, req) {
+		t.Fatal("the methods are different")
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			w.WriteHeader(401)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		if r.URL.Path == "/graylog/api/users/foo" {
+			w.Write([]byte(`{"username": "foo", "session_id": "secret"}`))
+			return
+		}
+		w.WriteHeader(404)
+	}))
+	defer upstream.Close()
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(418)
+	})
+	rec, err := cassette.NewRecorder(upstream.URL+"/graylog/api", path, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(rec)
+	defer proxy.Close()
+	req, err := http.NewRequest(
+		http.MethodPut, proxy.URL+"/api/users/foo",
+		strings.NewReader(`{"password": "secret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("admin", "secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 200 || !strings.Contains(string(b), `"secret"`) {
+		t.Fatalf("the upstream's response should be returned: %d %s", resp.StatusCode, b)
+	}
+	resp, err = http.Get(proxy.URL + "/_mock/snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 418 {
+		t.Fatalf("the admin API should be passed to the next handler: %d", resp.StatusCode)
+	}
+
+	b, err = ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "secret") {
+		t.Fatalf("credentials should be sanitized: %s", b)
+	}
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Interactions) != 1 || c.Interactions[0].Request.Path != "/api/users/foo" {
+		t.Fatalf("cassette = %+v", c)
+	}
+
+	rep := httptest.NewServer(cassette.NewReplayer(c, cassette.Matcher{}, next))
+	defer rep.Close()
+	req, err = http.NewRequest(http.MethodPut, rep.URL+"/api/users/foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the last matched pair is used repeatedly
+	for i := 0; i < 2; i++ {
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("the recorded response should be returned: %d %v", resp.StatusCode, resp.Header)
+		}
+	}
+	resp, err = http.Get(rep.URL + "/api/users/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 418 {
+		t.Fatalf("the request which isn't recorded should be passed to the next handler: %d", resp.StatusCode)
+	}
+}
+
+func TestRecordSanitizeInput(t *testing.T) {
+	// the input's attributes are returned as they are
+	input := `{"title": "aws", "type": "org.graylog.aws.inputs.cloudtrail.CloudTrailInput", "configuration": {` +
+		`"aws_access_key": "AKIASECRETVALUE", "aws_secret_key": "secret-aws-key", ` +
+		`"broker_password": "secret-broker", "tls_key_password": "secret-tls", "aws_sqs_region": "us-east-1"}}`
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/system/inputs":
+			if r.Method == http.MethodPost {
+				w.Write([]byte(`{"id": "5a90cee5c006c60001efbbf5"}`))
+				return
+			}
+			w.Write([]byte(`{"total": 1, "inputs": [` + input + `]}`))
+		case "/api/system/inputs/5a90cee5c006c60001efbbf5":
+			w.Write([]byte(input))
+		case "/api/users/admin/tokens/secret-token":
+			w.WriteHeader(204)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer upstream.Close()
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+	rec, err := cassette.NewRecorder(upstream.URL+"/api", path, http.NotFoundHandler())
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := httptest.NewServer(rec)
+	defer proxy.Close()
+
+	data := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, "/api/system/inputs", input},
+		{http.MethodGet, "/api/system/inputs/5a90cee5c006c60001efbbf5", ""},
+		{http.MethodGet, "/api/system/inputs", ""},
+		{http.MethodDelete, "/api/users/admin/tokens/secret-token?session_token=secret-query&foo=bar", ""},
+	}
+	for _, d := range data {
+		req, err := http.NewRequest(d.method, proxy.URL+d.path, strings.NewReader(d.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth("admin", "admin")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{
+		"AKIASECRETVALUE", "secret-aws-key", "secret-broker", "secret-tls", "secret-token", "secret-query",
+	} {
+		if strings.Contains(string(b), secret) {
+			t.Fatalf("%s should be sanitized: %s", secret, b)
+		}
+	}
+	if !strings.Contains(string(b), "us-east-1") || !strings.Contains(string(b), "foo=bar") {
+		t.Fatalf("the values which aren't credentials should be recorded: %s", b)
+	}
+
+	// the request whose credentials are sanitized matches the recorded request
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep := httptest.NewServer(cassette.NewReplayer(c, cassette.Matcher{Query: true}, http.NotFoundHandler()))
+	defer rep.Close()
+	req, err := http.NewRequest(
+		http.MethodDelete, rep.URL+"/api/users/admin/tokens/other-token?session_token=other&foo=bar", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 204 {
+		t.Fatalf("the recorded response should be returned: %d", resp.StatusCode)
+	}
+}
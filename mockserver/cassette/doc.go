@@ -0,0 +1,9 @@
+/*
+Package cassette provides the record-and-replay proxy mode of the mock server.
+
+Recorder forwards requests to a real Graylog and records the request/response pairs
+to a cassette file, and Replayer serves the recorded responses.
+Credentials such as the Authorization header, passwords, secret keys and tokens aren't recorded.
+They are replaced in the JSON bodies, the query parameters and the paths such as "/users/{username}/tokens/{token}".
+*/
+package cassette
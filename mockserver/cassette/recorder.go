@@ -0,0 +1,176 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// hopHeaders are the hop-by-hop headers which aren't forwarded.
+var hopHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization",
+	"Te", "Trailer", "Transfer-Encoding", "Upgrade",
+}
+
+// Recorder is a http.Handler which forwards the Graylog API requests to a real Graylog
+// and records the request/response pairs.
+// The requests whose paths don't start with "/api" such as the admin API are passed to the next handler.
+type Recorder struct {
+	upstream *url.URL
+	path     string
+	cassette *Cassette
+	client   *http.Client
+	next     http.Handler
+	mutex    sync.Mutex
+}
+
+// NewRecorder returns a new Recorder.
+// The argument `upstream` is the real Graylog API's endpoint such as "https://graylog.example.com/api".
+// The recorded pairs are written to the file `path` at each request.
+// If `path` is empty, the pairs aren't recorded.
+func NewRecorder(upstream, path string, next http.Handler) (*Recorder, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream %s: %s", upstream, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid upstream %s: the scheme and the host are required", upstream)
+	}
+	return &Recorder{
+		upstream: u, path: path, cassette: &Cassette{Interactions: []Interaction{}},
+		client: &http.Client{Timeout: 30 * time.Second}, next: next,
+	}, nil
+}
+
+// Cassette returns a copy of the recorded pairs.
+func (rec *Recorder) Cassette() *Cassette {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	c := &Cassette{Interactions: make([]Interaction, len(rec.cassette.Interactions))}
+	copy(c.Interactions, rec.cassette.Interactions)
+	return c
+}
+
+// ServeHTTP forwards the request to the upstream and records the pair.
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !isAPIPath(r.URL.Path) {
+		rec.next.ServeHTTP(w, r)
+		return
+	}
+	req, err := readRequest(r)
+	if err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	resp, err := rec.forward(r, req)
+	if err != nil {
+		writeError(w, 502, fmt.Errorf("failed to forward the request to the upstream: %s", err))
+		return
+	}
+	writeRecordedResponse(w, resp)
+	if err := rec.record(req, resp); err != nil {
+		// the response has been already written
+		log.WithFields(log.Fields{
+			"path": rec.path, "error": err,
+		}).Error("failed to write the cassette")
+	}
+}
+
+// forward sends the request to the upstream.
+func (rec *Recorder) forward(r *http.Request, req *Request) (*Response, error) {
+	u := *rec.upstream
+	u.Path = strings.TrimSuffix(u.Path, "/") + strings.TrimPrefix(req.Path, "/api")
+	u.RawQuery = req.Query
+	fr, err := http.NewRequest(req.Method, u.String(), bytes.NewReader([]byte(req.Body)))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Header {
+		fr.Header[k] = v
+	}
+	for _, k := range hopHeaders {
+		fr.Header.Del(k)
+	}
+	fr.Header.Del("Content-Length")
+	resp, err := rec.client.Do(fr)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header
+	for _, k := range hopHeaders {
+		header.Del(k)
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: header, Body: string(b)}, nil
+}
+
+// record adds the sanitized pair to the cassette and writes the cassette.
+func (rec *Recorder) record(req *Request, resp *Response) error {
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	rec.cassette.Interactions = append(rec.cassette.Interactions, Interaction{
+		Request: sanitizeRequest(req),
+		Response: Response{
+			StatusCode: resp.StatusCode, Header: sanitizeHeader(resp.Header),
+			Body: sanitizeBody(resp.Body)},
+	})
+	if rec.path == "" {
+		return nil
+	}
+	return rec.cassette.Save(rec.path)
+}
+
+// isAPIPath returns whether the path is the Graylog API's path.
+func isAPIPath(p string) bool {
+	return p == "/api" || strings.HasPrefix(p, "/api/")
+}
+
+// readRequest reads the request.
+func readRequest(r *http.Request) (*Request, error) {
+	req := &Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+	if r.Body == nil {
+		return req, nil
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the request body: %s", err)
+	}
+	req.Body = string(b)
+	return req, nil
+}
+
+// writeRecordedResponse writes the response.
+func writeRecordedResponse(w http.ResponseWriter, resp *Response) {
+	for k, v := range resp.Header {
+		if strings.EqualFold(k, "Content-Length") {
+			continue
+		}
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write([]byte(resp.Body))
+}
+
+// writeError writes an error as the Graylog API's error.
+func writeError(w http.ResponseWriter, sc int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(sc)
+	b, e := json.Marshal(map[string]string{"message": err.Error()})
+	if e != nil {
+		w.Write([]byte(`{"message":"failed to marshal an error"}`))
+		return
+	}
+	w.Write(b)
+}
@@ -0,0 +1,73 @@
+package cassette
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Replayer is a http.Handler which serves the recorded responses.
+// The recorded pairs are used in the recorded order, so the same requests can get the different responses.
+// When all matched pairs have been used, the last matched pair is used repeatedly.
+// The requests which aren't recorded are passed to the next handler, which is usually the mock server's handler.
+type Replayer struct {
+	cassette *Cassette
+	matcher  Matcher
+	used     []bool
+	next     http.Handler
+	mutex    sync.Mutex
+}
+
+// NewReplayer returns a new Replayer.
+func NewReplayer(c *Cassette, matcher Matcher, next http.Handler) *Replayer {
+	return &Replayer{
+		cassette: c, matcher: matcher, used: make([]bool, len(c.Interactions)),
+		next: next}
+}
+
+// ServeHTTP serves the recorded response.
+func (rep *Replayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !isAPIPath(r.URL.Path) {
+		rep.next.ServeHTTP(w, r)
+		return
+	}
+	req, err := readRequest(r)
+	if err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	resp := rep.find(req)
+	if resp == nil {
+		// pass the request body to the next handler
+		r.Body = ioutil.NopCloser(strings.NewReader(req.Body))
+		rep.next.ServeHTTP(w, r)
+		return
+	}
+	writeRecordedResponse(w, resp)
+}
+
+// find returns the recorded response of the request.
+// If the request isn't recorded, nil is returned.
+func (rep *Replayer) find(req *Request) *Response {
+	// the request is sanitized to compare with the recorded request
+	sanitized := sanitizeRequest(req)
+	rep.mutex.Lock()
+	defer rep.mutex.Unlock()
+	last := -1
+	for i := range rep.cassette.Interactions {
+		interaction := &rep.cassette.Interactions[i]
+		if !rep.matcher.Match(&interaction.Request, &sanitized) {
+			continue
+		}
+		if !rep.used[i] {
+			rep.used[i] = true
+			return &interaction.Response
+		}
+		last = i
+	}
+	if last < 0 {
+		return nil
+	}
+	return &rep.cassette.Interactions[last].Response
+}
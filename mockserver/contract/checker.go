@@ -0,0 +1,247 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Checker is a http.Handler which validates the requests and responses of the next handler
+// against the specification.
+type Checker struct {
+	spec   *Spec
+	next   http.Handler
+	mutex  sync.Mutex
+	called map[string]int
+	// the violations per route
+	violations map[string][]string
+	// the requests which don't match any route
+	undocumented map[string]int
+}
+
+// NewChecker returns a new Checker.
+func NewChecker(spec *Spec, next http.Handler) *Checker {
+	return &Checker{
+		spec: spec, next: next, called: map[string]int{},
+		violations: map[string][]string{}, undocumented: map[string]int{}}
+}
+
+// ServeHTTP passes the request to the next handler and validates the request and the response.
+// The requests out of the base path such as the admin API aren't validated.
+func (checker *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	basePath := strings.TrimSuffix(checker.spec.BasePath, "/")
+	if r.URL.Path != basePath && !strings.HasPrefix(r.URL.Path, basePath+"/") {
+		checker.next.ServeHTTP(w, r)
+		return
+	}
+	var body []byte
+	if r.Body != nil {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(400)
+			return
+		}
+		body = b
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+	rec := httptest.NewRecorder()
+	checker.next.ServeHTTP(rec, r)
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+
+	route := checker.spec.FindRoute(r.Method, strings.TrimPrefix(r.URL.Path, basePath))
+	checker.mutex.Lock()
+	defer checker.mutex.Unlock()
+	if route == nil {
+		checker.undocumented[r.Method+" "+r.URL.Path]++
+		return
+	}
+	key := route.String()
+	checker.called[key]++
+	violations := validateRequest(route, r, body)
+	violations = append(violations, validateResponse(route, rec.Code, rec.Body.Bytes())...)
+	for _, v := range violations {
+		checker.violations[key] = appendUnique(checker.violations[key], v)
+	}
+}
+
+// appendUnique appends a string if the list doesn't have it.
+func appendUnique(list []string, s string) []string {
+	for _, a := range list {
+		if a == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// validateRequest validates a request against the route.
+func validateRequest(route *Route, r *http.Request, body []byte) []string {
+	violations := []string{}
+	query := r.URL.Query()
+	for _, prm := range route.Operation.Parameters {
+		switch prm.ParamType {
+		case "query":
+			if prm.Required && query.Get(prm.Name) == "" {
+				violations = append(violations, fmt.Sprintf("query parameter %s is required", prm.Name))
+			}
+		case "body":
+			if len(bytes.TrimSpace(body)) == 0 {
+				if prm.Required {
+					violations = append(violations, "request body is required")
+				}
+				continue
+			}
+			var val interface{}
+			if err := json.Unmarshal(body, &val); err != nil {
+				violations = append(violations, fmt.Sprintf("request body isn't JSON: %s", err))
+				continue
+			}
+			violations = append(violations, validateModel(route.Models, prm.Type, val, "request")...)
+		}
+	}
+	return violations
+}
+
+// validateResponse validates a successful response against the route.
+// Error responses aren't validated because Graylog's specification doesn't define their bodies.
+func validateResponse(route *Route, sc int, body []byte) []string {
+	if sc >= 300 {
+		return nil
+	}
+	t := route.Operation.Type
+	if len(bytes.TrimSpace(body)) == 0 {
+		if t == "" || t == typeVoid {
+			return nil
+		}
+		return []string{fmt.Sprintf("response body %s is required", t)}
+	}
+	var val interface{}
+	if err := json.Unmarshal(body, &val); err != nil {
+		return []string{fmt.Sprintf("response body isn't JSON: %s", err)}
+	}
+	if t == "" || t == typeVoid {
+		return []string{"response body should be empty"}
+	}
+	return validateModel(route.Models, t, val, "response")
+}
+
+// RouteReport is the result of a route.
+type RouteReport struct {
+	Route string `json:"route"`
+	// whether the mock server implements the route
+	Implemented bool `json:"implemented"`
+	// the number of the requests to the route
+	Called     int      `json:"called"`
+	Violations []string `json:"violations,omitempty"`
+}
+
+// Report is the coverage report of the real API vs. the mock server.
+type Report struct {
+	Routes []RouteReport `json:"routes"`
+	// the requests which aren't documented in the specification
+	Undocumented []string `json:"undocumented,omitempty"`
+}
+
+// Report returns the coverage report.
+// The argument `router` is the mock server's router, which is used to check
+// whether the mock server implements the routes.
+func (checker *Checker) Report(router *httprouter.Router) *Report {
+	checker.mutex.Lock()
+	defer checker.mutex.Unlock()
+	report := &Report{Routes: []RouteReport{}}
+	basePath := strings.TrimSuffix(checker.spec.BasePath, "/")
+	for _, route := range checker.spec.Routes() {
+		key := route.String()
+		report.Routes = append(report.Routes, RouteReport{
+			Route: key, Implemented: isImplemented(router, route.Method, basePath+route.Path),
+			Called:     checker.called[key],
+			Violations: checker.violations[key]})
+	}
+	for k := range checker.undocumented {
+		report.Undocumented = append(report.Undocumented, k)
+	}
+	sort.Strings(report.Undocumented)
+	return report
+}
+
+// samplePathParam is the value of path parameters to look up the router.
+const samplePathParam = "_"
+
+// isImplemented returns whether the router has the route.
+// The path parameters are replaced with a sample value to look up the router.
+// If a fixed segment of the route matches a path parameter of the router,
+// the router doesn't have the route. For example "/system/inputs/types"
+// matches "/system/inputs/:inputID" but isn't implemented.
+func isImplemented(router *httprouter.Router, method, p string) bool {
+	segs := splitPath(p)
+	for i, seg := range segs {
+		if isPathParam(seg) {
+			segs[i] = samplePathParam
+		}
+	}
+	handle, ps, _ := router.Lookup(method, "/"+strings.Join(segs, "/"))
+	if handle == nil {
+		return false
+	}
+	for _, prm := range ps {
+		if prm.Value != samplePathParam {
+			return false
+		}
+	}
+	return true
+}
+
+// Violations returns all violations such as "GET /roles: response.foo isn't defined".
+func (report *Report) Violations() []string {
+	violations := []string{}
+	for _, route := range report.Routes {
+		for _, v := range route.Violations {
+			violations = append(violations, route.Route+": "+v)
+		}
+	}
+	return violations
+}
+
+// Coverage returns the number of the implemented routes and all routes.
+func (report *Report) Coverage() (int, int) {
+	n := 0
+	for _, route := range report.Routes {
+		if route.Implemented {
+			n++
+		}
+	}
+	return n, len(report.Routes)
+}
+
+// String returns the report as a text.
+func (report *Report) String() string {
+	buf := &bytes.Buffer{}
+	implemented, total := report.Coverage()
+	fmt.Fprintf(buf, "coverage: %d/%d routes are implemented\n", implemented, total)
+	for _, route := range report.Routes {
+		status := "implemented"
+		if !route.Implemented {
+			status = "NOT IMPLEMENTED"
+		}
+		fmt.Fprintf(buf, "%-15s called: %-3d %s\n", status, route.Called, route.Route)
+		for _, v := range route.Violations {
+			fmt.Fprintf(buf, "  violation: %s\n", v)
+		}
+	}
+	for _, u := range report.Undocumented {
+		fmt.Fprintf(buf, "undocumented: %s\n", u)
+	}
+	return buf.String()
+}
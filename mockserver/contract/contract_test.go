@@ -0,0 +1,425 @@
+package contract_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/contract"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+	"github.com/suzuki-shunsuke/go-ptr"
+)
+
+// knownViolations are the known differences between the client or the mock server and Graylog,
+// and the reasons why they are left.
+// When a difference is fixed, remove it from this list.
+var knownViolations = map[string]string{
+	"PUT /system/indices/index_sets/{id}: request.index_prefix isn't defined": "IndexSetUpdateParams requires the index prefix, " +
+		"which the client and the terraform provider send and the mock server validates",
+	"POST /system/deflector/{indexSetId}/cycle: response body should be empty": "the mock server returns the id of the system job " +
+		"which calculates the old index's range, so that the client can wait for the job",
+	"POST /system/indices/ranges/rebuild: response body should be empty": "the mock server returns the id of the system job " +
+		"which rebuilds the ranges, so that the client can wait for and cancel the job",
+	"POST /system/indices/ranges/index_set/{indexSetId}/rebuild: response body should be empty": "the mock server returns the id of the system job " +
+		"which rebuilds the index set's ranges, so that the client can wait for the job",
+}
+
+// minCoverage is the number of the routes which the mock server implements.
+// When the mock server implements a new route, increase this number.
+const minCoverage = 82
+
+func TestContract(t *testing.T) {
+	spec, err := contract.LoadSpec("testdata/graylog-2.4.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := mockserver.NewServer("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := server.Handler().(*httprouter.Router)
+	checker := contract.NewChecker(spec, router)
+	server.SetHandler(checker)
+	server.Start()
+	defer server.Close()
+	cl, err := client.NewClient(server.Endpoint(), "admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// keep the system jobs running to get and cancel them
+	server.SetSystemJobDuration(time.Hour)
+	server.SetLDAPDirectory(&logic.LDAPDirectory{
+		SystemUsername: "cn=admin,dc=example,dc=com", SystemPassword: "password",
+		Users: []logic.LDAPUser{{UID: "foo", Password: "password", Groups: []string{"admins"}}},
+	})
+	runScenario(t, cl)
+
+	report := checker.Report(router)
+	t.Log(report)
+	if implemented, total := report.Coverage(); implemented < minCoverage {
+		t.Errorf("coverage: %d/%d routes are implemented, wanted at least %d",
+			implemented, total, minCoverage)
+	}
+	violations := map[string]bool{}
+	for _, v := range report.Violations() {
+		violations[v] = true
+		if _, ok := knownViolations[v]; !ok {
+			t.Errorf("violation: %s", v)
+		}
+	}
+	for v := range knownViolations {
+		if !violations[v] {
+			t.Errorf("the known violation is fixed, so remove it from knownViolations: %s", v)
+		}
+	}
+	if len(report.Undocumented) != 0 {
+		t.Errorf("undocumented requests: %v", report.Undocumented)
+	}
+}
+
+// runScenario calls the mock server's API.
+func runScenario(t *testing.T, cl *client.Client) {
+	role := testutil.Role()
+	if _, err := cl.CreateRole(role); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetRoles(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetRole(role.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.UpdateRole(role.Name, role.NewUpdateParams()); err != nil {
+		t.Fatal(err)
+	}
+	user := testutil.User()
+	if _, err := cl.CreateUser(user); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetUsers(); err != nil {
+		t.Fatal(err)
+	}
+	u, _, err := cl.GetUser(user.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.UpdateUser(u.NewUpdateParams()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.AddUserToRole(user.Username, role.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetRoleMembers(role.Name); err != nil {
+		t.Fatal(err)
+	}
+
+	is := testutil.IndexSet("contract")
+	if _, err := cl.CreateIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := cl.GetIndexSets(0, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetIndexSet(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	prms := is.NewUpdateParams()
+	prms.Description = ptr.PStr("updated")
+	if _, _, err := cl.UpdateIndexSet(prms); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetIndexSetStats(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	runIndexScenario(t, cl, is.ID)
+
+	stream := testutil.Stream()
+	stream.IndexSetID = is.ID
+	if _, err := cl.CreateStream(stream); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetStreams(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetStream(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.UpdateStream(stream); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.PauseStream(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.ResumeStream(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+	rule := &graylog.StreamRule{StreamID: stream.ID, Field: "level", Value: "error", Type: 1}
+	if _, err := cl.CreateStreamRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	rule.Value = "warn"
+	if _, err := cl.UpdateStreamRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetStreamRules(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetStreamRule(stream.ID, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteStreamRule(stream.ID, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteStream(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, err := cl.GetAlertConditions(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetInputs(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetSystemOverview(); err != nil {
+		t.Fatal(err)
+	}
+	runInputScenario(t, cl)
+	runSystemScenario(t, cl)
+	runLDAPScenario(t, cl)
+
+	if _, err := cl.RemoveUserFromRole(user.Username, role.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteUser(user.Username); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteRole(role.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteIndexSet(is.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// runIndexScenario calls the API of the indices, the index ranges, the deflector and the system jobs.
+func runIndexScenario(t *testing.T, cl *client.Client, indexSetID string) {
+	if _, _, err := cl.GetDeflector(indexSetID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.CycleDeflector(indexSetID); err != nil {
+		t.Fatal(err)
+	}
+	indices, _, err := cl.GetIndices(indexSetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetOpenIndices(indexSetID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetClosedIndices(indexSetID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetReopenedIndices(indexSetID); err != nil {
+		t.Fatal(err)
+	}
+	name := ""
+	for k, index := range indices.All.Indices {
+		if name == "" || k < name {
+			name = index.IndexName
+		}
+	}
+	if _, _, err := cl.GetIndex(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.CloseIndex(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.ReopenIndex(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetIndexRanges(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetIndexRange(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.RebuildIndexSetIndexRanges(indexSetID); err != nil {
+		t.Fatal(err)
+	}
+	jobID, _, err := cl.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetSystemJobs(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetSystemJob(jobID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.CancelSystemJob(jobID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteIndex(name); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// runInputScenario calls the API of the input states and the static fields.
+func runInputScenario(t *testing.T, cl *client.Client) {
+	input := testutil.Input()
+	if _, err := cl.CreateInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	input.Title = "updated"
+	if _, _, err := cl.UpdateInput(input.NewUpdateParams()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.StartInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetInputStates(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetInputState(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.StopInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// runSystemScenario calls the API of the nodes, the metrics, the journal,
+// the notifications and the cluster configurations.
+func runSystemScenario(t *testing.T, cl *client.Client) {
+	if _, _, _, err := cl.GetNodes(); err != nil {
+		t.Fatal(err)
+	}
+	node, _, err := cl.GetCurrentNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetNode(node.NodeID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetThroughput(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetCounter("org.graylog2.throughput.input.total"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetMeter("org.graylog2.throughput.input"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetMetrics(
+		"org.graylog2.throughput.input.1-sec-interval", "org.graylog2.throughput.input"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetJournal(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetNotifications(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteNotification(graylog.NotificationTypeNoMaster); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &graylog.GenericClusterConfig{
+		Class: "com.example.ContractConfig", Values: map[string]interface{}{"foo": "bar"}}
+	if _, err := cl.UpdateClusterConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetClusterConfigClasses(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetClusterConfig(cfg.Class); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteClusterConfig(cfg.Class); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// runLDAPScenario calls the LDAP API.
+func runLDAPScenario(t *testing.T, cl *client.Client) {
+	settings := &graylog.LDAPSettings{
+		Enabled:              true,
+		SystemUsername:       "cn=admin,dc=example,dc=com",
+		SystemPassword:       "password",
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		DefaultGroup:         "Admin",
+	}
+	if _, err := cl.UpdateLDAPSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.TestLDAPSettings(&graylog.LDAPTestConfigRequest{
+		SystemUsername: settings.SystemUsername, SystemPassword: settings.SystemPassword,
+		LDAPURI: settings.LDAPURI, SearchBase: settings.SearchBase,
+		SearchPattern: settings.SearchPattern, TestConnectOnly: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetLDAPGroups(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.UpdateLDAPGroupRoleMapping(map[string]string{"admins": "Admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetLDAPGroupRoleMapping(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.DeleteLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindRoute(t *testing.T) {
+	spec, err := contract.LoadSpec("testdata/graylog-2.4.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		method string
+		path   string
+		exp    string
+	}{
+		{"GET", "/system/inputs/types", "GET /system/inputs/types"},
+		{"GET", "/system/inputs/foo", "GET /system/inputs/{inputId}"},
+		{"DELETE", "/roles/Admin", "DELETE /roles/{rolename}"},
+		{"GET", "/system/indexer/indices/open", "GET /system/indexer/indices/open"},
+		{"GET", "/system/indexer/indices/graylog_0", "GET /system/indexer/indices/{index}"},
+	}
+	for _, d := range data {
+		route := spec.FindRoute(d.method, d.path)
+		if route == nil {
+			t.Fatalf("FindRoute(%s, %s) = nil, wanted %s", d.method, d.path, d.exp)
+		}
+		if route.String() != d.exp {
+			t.Fatalf("FindRoute(%s, %s) = %s, wanted %s", d.method, d.path, route, d.exp)
+		}
+	}
+	if route := spec.FindRoute("PATCH", "/roles/Admin"); route != nil {
+		t.Fatalf("FindRoute(PATCH, /roles/Admin) = %s, wanted nil", route)
+	}
+}
@@ -0,0 +1,18 @@
+/*
+Package contract validates the mock server against Graylog's published API documentation.
+
+Graylog publishes its REST API as Swagger 1.2 at "/api/api-docs".
+Checker is a http.Handler which wraps the mock server's handler
+and validates requests and responses against the Swagger specification,
+and Report reports which operations of the real API the mock server implements.
+
+	spec, err := contract.LoadSpec("testdata/graylog-2.4.json")
+	server, err := mockserver.NewServer("", nil)
+	router := server.Handler().(*httprouter.Router)
+	checker := contract.NewChecker(spec, router)
+	server.SetHandler(checker)
+	// call the mock server's API
+	report := checker.Report(router)
+	fmt.Println(report)
+*/
+package contract
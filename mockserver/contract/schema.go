@@ -0,0 +1,126 @@
+package contract
+
+import (
+	"fmt"
+	"sort"
+)
+
+// primitive types of Swagger 1.2 and JSON schema
+const (
+	typeString  = "string"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeBoolean = "boolean"
+	typeArray   = "array"
+	typeObject  = "object"
+	typeAny     = "any"
+	typeVoid    = "void"
+)
+
+// validateModel validates a decoded JSON value against a model and returns the violations.
+// The properties which the model doesn't define are violations,
+// because clients can't depend on them.
+func validateModel(models map[string]Model, name string, val interface{}, where string) []string {
+	model, ok := models[name]
+	if !ok {
+		// a primitive type such as "string"
+		return validateProperty(models, &Property{Type: name}, val, where)
+	}
+	obj, ok := val.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s must be an object %s: %v", where, name, val)}
+	}
+	return validateObject(models, model.Properties, model.Required, obj, where)
+}
+
+// validateObject validates an object's properties.
+func validateObject(
+	models map[string]Model, props map[string]Property, required []string,
+	obj map[string]interface{}, where string,
+) []string {
+	violations := []string{}
+	req := map[string]bool{}
+	for _, k := range required {
+		req[k] = true
+	}
+	for k, prop := range props {
+		if prop.Required {
+			req[k] = true
+		}
+	}
+	for k := range req {
+		if _, ok := obj[k]; !ok {
+			violations = append(violations, fmt.Sprintf("%s.%s is required", where, k))
+		}
+	}
+	for k, v := range obj {
+		prop, ok := props[k]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("%s.%s isn't defined", where, k))
+			continue
+		}
+		violations = append(
+			violations, validateProperty(models, &prop, v, where+"."+k)...)
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// validateProperty validates a decoded JSON value against a property.
+// null is allowed.
+func validateProperty(models map[string]Model, prop *Property, val interface{}, where string) []string {
+	if val == nil {
+		return nil
+	}
+	if prop.Ref != "" {
+		return validateModel(models, prop.Ref, val, where)
+	}
+	switch prop.Type {
+	case "", typeAny, typeVoid:
+		return nil
+	case typeString:
+		if _, ok := val.(string); !ok {
+			return []string{fmt.Sprintf("%s must be a string: %v", where, val)}
+		}
+	case typeInteger:
+		if f, ok := val.(float64); !ok || f != float64(int64(f)) {
+			return []string{fmt.Sprintf("%s must be an integer: %v", where, val)}
+		}
+	case typeNumber:
+		if _, ok := val.(float64); !ok {
+			return []string{fmt.Sprintf("%s must be a number: %v", where, val)}
+		}
+	case typeBoolean:
+		if _, ok := val.(bool); !ok {
+			return []string{fmt.Sprintf("%s must be a boolean: %v", where, val)}
+		}
+	case typeArray:
+		arr, ok := val.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an array: %v", where, val)}
+		}
+		if prop.Items == nil {
+			return nil
+		}
+		violations := []string{}
+		for i, v := range arr {
+			violations = append(
+				violations, validateProperty(models, prop.Items, v, fmt.Sprintf("%s[%d]", where, i))...)
+		}
+		return violations
+	case typeObject:
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an object: %v", where, val)}
+		}
+		// an object without properties is a map
+		if len(prop.Properties) == 0 {
+			return nil
+		}
+		return validateObject(models, prop.Properties, nil, obj, where)
+	default:
+		// a model
+		return validateModel(models, prop.Type, val, where)
+	}
+	return nil
+}
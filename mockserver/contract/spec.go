@@ -0,0 +1,210 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Spec is Graylog's API specification.
+// This is the Swagger 1.2 API declarations which are merged into a document.
+type Spec struct {
+	APIVersion string           `json:"apiVersion"`
+	BasePath   string           `json:"basePath"`
+	APIs       []APIDeclaration `json:"apis"`
+}
+
+// APIDeclaration is a Swagger 1.2 API declaration, which is a resource of the API.
+type APIDeclaration struct {
+	ResourcePath string           `json:"resourcePath"`
+	APIs         []API            `json:"apis"`
+	Models       map[string]Model `json:"models"`
+}
+
+// API is a path and its operations.
+type API struct {
+	Path       string      `json:"path"`
+	Operations []Operation `json:"operations"`
+}
+
+// Operation is an operation of the API.
+type Operation struct {
+	Method     string      `json:"method"`
+	Nickname   string      `json:"nickname"`
+	Type       string      `json:"type"`
+	Parameters []Parameter `json:"parameters"`
+}
+
+// Parameter is a parameter of an operation.
+type Parameter struct {
+	Name      string `json:"name"`
+	ParamType string `json:"paramType"`
+	Type      string `json:"type"`
+	Required  bool   `json:"required"`
+}
+
+// Model is a JSON schema of a request or response body.
+type Model struct {
+	ID         string              `json:"id"`
+	Properties map[string]Property `json:"properties"`
+	Required   []string            `json:"required"`
+}
+
+// Property is a JSON schema of a model's property.
+type Property struct {
+	Type       string              `json:"type"`
+	Ref        string              `json:"$ref"`
+	Items      *Property           `json:"items"`
+	Properties map[string]Property `json:"properties"`
+	Required   bool                `json:"required"`
+}
+
+// Route is an operation with its API declaration.
+type Route struct {
+	Method    string
+	Path      string
+	Operation *Operation
+	Models    map[string]Model
+}
+
+// String returns the route such as "GET /roles/{rolename}".
+func (route *Route) String() string {
+	return route.Method + " " + route.Path
+}
+
+// LoadSpec reads a specification file.
+func LoadSpec(path string) (*Spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		return nil, fmt.Errorf("failed to parse the specification %s: %s", path, err)
+	}
+	return spec, nil
+}
+
+// FetchSpec gets the specification from a running Graylog.
+// The argument `endpoint` is the Graylog API's endpoint such as "http://localhost:9000/api".
+// This is used to update the vendored specification.
+func FetchSpec(endpoint, authName, authPass string) (*Spec, error) {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	listing := struct {
+		APIVersion string `json:"apiVersion"`
+		APIs       []struct {
+			Path string `json:"path"`
+		} `json:"apis"`
+	}{}
+	if err := getJSON(endpoint+"/api-docs", authName, authPass, &listing); err != nil {
+		return nil, err
+	}
+	spec := &Spec{APIVersion: listing.APIVersion, BasePath: "/api"}
+	for _, api := range listing.APIs {
+		decl := APIDeclaration{}
+		if err := getJSON(endpoint+"/api-docs"+api.Path, authName, authPass, &decl); err != nil {
+			return nil, err
+		}
+		spec.APIs = append(spec.APIs, decl)
+	}
+	return spec, nil
+}
+
+// getJSON gets a JSON document.
+func getJSON(u, authName, authPass string, dest interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(authName, authPass)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("failed to get %s: status code %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+// Routes returns all operations sorted by the path and the method.
+func (spec *Spec) Routes() []Route {
+	routes := []Route{}
+	for _, decl := range spec.APIs {
+		for _, api := range decl.APIs {
+			for i := range api.Operations {
+				op := &api.Operations[i]
+				routes = append(routes, Route{
+					Method: strings.ToUpper(op.Method), Path: api.Path,
+					Operation: op, Models: decl.Models})
+			}
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// FindRoute returns the route which matches the request.
+// The argument `urlPath` is relative to the base path such as "/roles/Admin".
+// If more than one route matches, the route with the fewest path parameters is returned
+// because "/system/inputs/types" should win over "/system/inputs/{inputId}".
+// If no route matches, nil is returned.
+func (spec *Spec) FindRoute(method, urlPath string) *Route {
+	var (
+		found *Route
+		min   int
+	)
+	segs := splitPath(urlPath)
+	routes := spec.Routes()
+	for i := range routes {
+		route := &routes[i]
+		if route.Method != method {
+			continue
+		}
+		params, ok := matchPath(splitPath(route.Path), segs)
+		if !ok {
+			continue
+		}
+		if found == nil || params < min {
+			found, min = route, params
+		}
+	}
+	return found
+}
+
+// splitPath splits a path by "/".
+func splitPath(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
+
+// matchPath returns the number of the path parameters and whether the path matches the template.
+func matchPath(template, segs []string) (int, bool) {
+	if len(template) != len(segs) {
+		return 0, false
+	}
+	params := 0
+	for i, t := range template {
+		if isPathParam(t) {
+			params++
+			continue
+		}
+		if t != segs[i] {
+			return 0, false
+		}
+	}
+	return params, true
+}
+
+// isPathParam returns whether the segment is a path parameter such as "{inputId}".
+func isPathParam(seg string) bool {
+	return strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")
+}
@@ -1,12 +1,13 @@
 // Run Graylog mock server.
 //
 // Usage
-//   $ graylog-mock-server [--port <port number>] [--log-level debug|info|warn|error|fatal|panic] [--data <data-file-path>]
+//   $ graylog-mock-server [--port <port number>] [--log-level debug|info|warn|error|fatal|panic] [--store plain|bolt] [--data <data-file-path>] [--seed <fixture-file-path>] [--graylog-version 2.4|3.0] [--proxy <graylog-api-endpoint> [--record <cassette-file-path>] | --replay <cassette-file-path> [--replay-match method,path,query,body]]
 package main
 
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,6 +15,10 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/cassette"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/bolt"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
 )
 
@@ -32,29 +37,95 @@ VERSION:
    %s
 
 OPTIONS:
-   --port value       port number. If you don't set this option, a free port is assigned and the assigned port number is output to the console when the mock server runs.
-   --log-level value  the log level of logrus which the mock server uses internally. (default: "info")
-   --data value       data file path. When the server runs data of the file is loaded and when data of the server is changed data is saved at the file. If this option is not set, no data is loaded and saved.
-   --help, -h         show help
-   --version, -v      print the version
+   --port value             port number. If you don't set this option, a free port is assigned and the assigned port number is output to the console when the mock server runs.
+   --log-level value        the log level of logrus which the mock server uses internally. (default: "info")
+   --store value            the type of the store. "plain" or "bolt". The "bolt" store writes data to the BoltDB file at each operation and requires the --data option. (default: "plain")
+   --data value             data file path. When the server runs data of the file is loaded and when data of the server is changed data is saved at the file. If this option is not set, no data is loaded and saved.
+   --seed value             the YAML or JSON fixture file path. The users, roles, index sets, inputs, streams with stream rules and alert conditions of the file are added to the initial data. The references are resolved by name.
+   --graylog-version value  the Graylog version which the mock server emulates. "2.4" or "3.0". The system overview's version, the index sets' fields and the streams' validation differ across the versions. (default: "2.4")
+   --proxy value            the real Graylog API's endpoint such as "https://graylog.example.com/api". The Graylog API requests are forwarded to the endpoint.
+   --record value           the cassette file path. The request/response pairs which are forwarded by the --proxy option are recorded to the file. Credentials aren't recorded.
+   --replay value           the cassette file path. The recorded responses are served, and the requests which aren't recorded are handled by the mock server.
+   --replay-match value     the fields which are compared to find the recorded response. (default: "method,path,query")
+   --help, -h               show help
+   --version, -v            print the version
 `, version)
 }
 
-func action(dataPath, logLevel string, port int) error {
+func newStore(storeType, dataPath string) (store.Store, func() error, error) {
+	switch storeType {
+	case "plain":
+		return plain.NewStore(dataPath), func() error { return nil }, nil
+	case "bolt":
+		if dataPath == "" {
+			return nil, nil, fmt.Errorf("the bolt store requires the --data option")
+		}
+		s, err := bolt.NewStore(dataPath)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to open the BoltDB file %s", dataPath))
+		}
+		return s, s.Close, nil
+	default:
+		return nil, nil, fmt.Errorf(`invalid store %s.
+store must be either plain or bolt`, storeType)
+	}
+}
+
+// newHandler wraps the mock server's handler with the record-and-replay proxy.
+func newHandler(next http.Handler, proxy, record, replay, replayMatch string) (http.Handler, error) {
+	if replay != "" {
+		if proxy != "" || record != "" {
+			return nil, fmt.Errorf("the --replay option can't be used with the --proxy and --record options")
+		}
+		c, err := cassette.Load(replay)
+		if err != nil {
+			return nil, err
+		}
+		matcher, err := cassette.ParseMatcher(replayMatch)
+		if err != nil {
+			return nil, err
+		}
+		return cassette.NewReplayer(c, matcher, next), nil
+	}
+	if proxy == "" {
+		if record != "" {
+			return nil, fmt.Errorf("the --record option requires the --proxy option")
+		}
+		return next, nil
+	}
+	return cassette.NewRecorder(proxy, record, next)
+}
+
+func action(
+	storeType, dataPath, seedPath, graylogVersion, logLevel string, port int,
+	proxy, record, replay, replayMatch string,
+) error {
 	var (
 		server *mockserver.Server
 		err    error
 	)
+	st, closeStore, err := newStore(storeType, dataPath)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+	opts := []logic.Option{mockserver.WithGraylogVersion(graylogVersion)}
+	if seedPath != "" {
+		opts = append(opts, mockserver.WithSeed(seedPath))
+	}
 	if port == 0 {
-		server, err = mockserver.NewServer(
-			"", plain.NewStore(dataPath))
+		server, err = mockserver.NewServer("", st, opts...)
 	} else {
-		server, err = mockserver.NewServer(
-			fmt.Sprintf(":%d", port), plain.NewStore(dataPath))
+		server, err = mockserver.NewServer(fmt.Sprintf(":%d", port), st, opts...)
 	}
 	if err != nil {
 		return errors.Wrap(err, "failed to create a mock server")
 	}
+	h, err := newHandler(server.Handler(), proxy, record, replay, replayMatch)
+	if err != nil {
+		return err
+	}
+	server.SetHandler(h)
 	lvl, err := log.ParseLevel(logLevel)
 	if err != nil {
 		return fmt.Errorf(
@@ -93,6 +164,27 @@ func main() {
 	var dataFlag = flag.String(
 		"data", "",
 		"data file path. When the server runs data of the file is loaded and when data of the server is changed data is saved at the file. If this option is not set, no data is loaded and saved.")
+	var storeFlag = flag.String(
+		"store", "plain",
+		`the type of the store. "plain" or "bolt". The "bolt" store writes data to the BoltDB file at each operation and requires the --data option. (default: "plain")`)
+	var seedFlag = flag.String(
+		"seed", "",
+		"the YAML or JSON fixture file path. The users, roles, index sets, inputs, streams with stream rules and alert conditions of the file are added to the initial data. The references are resolved by name.")
+	var graylogVersionFlag = flag.String(
+		"graylog-version", "2.4",
+		`the Graylog version which the mock server emulates. "2.4" or "3.0". The system overview's version, the index sets' fields and the streams' validation differ across the versions. (default: "2.4")`)
+	var proxyFlag = flag.String(
+		"proxy", "",
+		`the real Graylog API's endpoint such as "https://graylog.example.com/api". The Graylog API requests are forwarded to the endpoint.`)
+	var recordFlag = flag.String(
+		"record", "",
+		"the cassette file path. The request/response pairs which are forwarded by the --proxy option are recorded to the file. Credentials aren't recorded.")
+	var replayFlag = flag.String(
+		"replay", "",
+		"the cassette file path. The recorded responses are served, and the requests which aren't recorded are handled by the mock server.")
+	var replayMatchFlag = flag.String(
+		"replay-match", "method,path,query",
+		`the fields which are compared to find the recorded response. (default: "method,path,query")`)
 	var logLevelFlag = flag.String(
 		"log-level", "info",
 		`the log level of logrus which the mock server uses internally. (default: "info")`)
@@ -109,7 +201,9 @@ func main() {
 		return
 	}
 
-	if err := action(*dataFlag, *logLevelFlag, *portFlag); err != nil {
+	if err := action(
+		*storeFlag, *dataFlag, *seedFlag, *graylogVersionFlag, *logLevelFlag, *portFlag,
+		*proxyFlag, *recordFlag, *replayFlag, *replayMatchFlag); err != nil {
 		log.Fatal(err)
 	}
 }
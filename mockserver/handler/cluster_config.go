@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetClusterConfigClasses is the handler of Get Cluster Config Classes API.
+func HandleGetClusterConfigClasses(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/cluster_config List all configuration classes
+	if sc, err := lgc.Authorize(user, "clusterconfigentry:read"); err != nil {
+		return nil, sc, err
+	}
+	classes, sc, err := lgc.GetClusterConfigClasses()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.ClusterConfigClassesBody{Classes: classes}, sc, nil
+}
+
+// HandleGetClusterConfig is the handler of Get a Cluster Config API.
+func HandleGetClusterConfig(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/cluster_config/{configClass} Get configuration settings from database
+	class := ps.ByName("configClass")
+	if sc, err := lgc.Authorize(user, "clusterconfigentry:read", class); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetClusterConfig(class)
+}
+
+// HandleUpdateClusterConfig is the handler of Update a Cluster Config API.
+func HandleUpdateClusterConfig(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// PUT /system/cluster_config/{configClass} Update configuration in database
+	class := ps.ByName("configClass")
+	if sc, err := lgc.Authorize(user, "clusterconfigentry:edit", class); err != nil {
+		return nil, sc, err
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, 400, err
+	}
+	cfg, err := logic.DecodeClusterConfig(class, b)
+	if err != nil {
+		return nil, 400, err
+	}
+	sc, err := lgc.UpdateClusterConfig(cfg)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return cfg, sc, nil
+}
+
+// HandleDeleteClusterConfig is the handler of Delete a Cluster Config API.
+func HandleDeleteClusterConfig(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/cluster_config/{configClass} Delete configuration settings from database
+	class := ps.ByName("configClass")
+	if sc, err := lgc.Authorize(user, "clusterconfigentry:delete", class); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.DeleteClusterConfig(class)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
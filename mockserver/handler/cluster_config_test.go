@@ -0,0 +1,47 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleUpdateClusterConfig(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	ei, err := client.UpdateClusterConfig(&graylog.SearchesClusterConfig{
+		QueryTimeRangeLimit: "30 days"})
+	if err == nil {
+		t.Fatal("query_time_range_limit is invalid")
+	}
+	if ei.Response.StatusCode != 400 {
+		t.Fatalf("status code == %d, wanted 400", ei.Response.StatusCode)
+	}
+
+	u, err := client.Endpoints().ClusterConfig(graylog.SearchesClusterConfigClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(
+		http.MethodPut, u.String(), bytes.NewBufferString(`{"foo": "bar"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(client.Name(), client.Password())
+	req.Header.Set("Content-Type", "application/json")
+	hc := &http.Client{}
+	resp, err := hc.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Fatalf("status code == %d, wanted 400", resp.StatusCode)
+	}
+}
@@ -0,0 +1,267 @@
+package handler_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+// TestConcurrentRequests sends API calls from several goroutines at once.
+// Run the test with the -race flag to detect the data races.
+func TestConcurrentRequests(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	indexSet := testutil.IndexSet("hoge")
+	if _, err := server.AddIndexSet(indexSet); err != nil {
+		t.Fatal(err)
+	}
+	_, initial, _, err := cl.GetStreams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				if err := streamLifecycle(cl, indexSet.ID, fmt.Sprintf("stream-%d-%d", worker, j)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	// the snapshot must not contain the rules of the streams which are being deleted
+	snapshotErr := make(chan error, 1)
+	go func() {
+		defer close(snapshotErr)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			snapshot, err := server.Snapshot()
+			if err != nil {
+				snapshotErr <- err
+				return
+			}
+			ids := make(map[string]struct{}, len(snapshot.Streams))
+			for _, stream := range snapshot.Streams {
+				ids[stream.ID] = struct{}{}
+			}
+			for _, rule := range snapshot.StreamRules {
+				if _, ok := ids[rule.StreamID]; !ok {
+					snapshotErr <- fmt.Errorf("the rule <%s> of the deleted stream <%s> is in the snapshot", rule.ID, rule.StreamID)
+					return
+				}
+			}
+		}
+	}()
+	wg.Wait()
+	close(done)
+	if err := <-snapshotErr; err != nil {
+		t.Fatal(err)
+	}
+	if t.Failed() {
+		return
+	}
+	if _, total, _, err := cl.GetStreams(); err != nil || total != initial {
+		t.Fatalf("total = %d, wanted %d: %v", total, initial, err)
+	}
+}
+
+// TestConcurrentLoginsAndSystemJobs logs in the LDAP users for the first time
+// and reads the system jobs from several goroutines at once.
+// Both the first login and the finished system jobs change the data.
+// Run the test with the -race flag to detect the data races.
+func TestConcurrentLoginsAndSystemJobs(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	const numUsers = 4
+	dir := &logic.LDAPDirectory{}
+	for i := 0; i < numUsers; i++ {
+		dir.Users = append(dir.Users, logic.LDAPUser{
+			UID: fmt.Sprintf("ldap-%d", i), Password: "password"})
+	}
+	server.SetLDAPDirectory(dir)
+	if _, err := cl.UpdateLDAPSettings(&graylog.LDAPSettings{
+		Enabled:              true,
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		DefaultGroup:         "Admin",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	indexSet := testutil.IndexSet("hoge")
+	if _, err := cl.CreateIndexSet(indexSet); err != nil {
+		t.Fatal(err)
+	}
+	initial, _, err := cl.GetUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetSystemJobDuration(time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		// two goroutines log in as each user at once
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			ext, err := client.NewClient(server.Endpoint(), fmt.Sprintf("ldap-%d", worker%numUsers), "password")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for j := 0; j < 4; j++ {
+				if _, _, err := ext.GetSystemJobs(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				if _, _, err := cl.RebuildIndexSetIndexRanges(indexSet.ID); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, _, err := cl.GetSystemJobs(); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, _, _, err := cl.GetIndexRanges(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+	if users, _, err := cl.GetUsers(); err != nil || len(users) != len(initial)+numUsers {
+		t.Fatalf("len(users) = %d, wanted %d: %v", len(users), len(initial)+numUsers, err)
+	}
+}
+
+// streamLifecycle creates a stream and its rules, changes them and deletes the stream by the API.
+func streamLifecycle(cl *client.Client, indexSetID, title string) error {
+	stream := testutil.Stream()
+	stream.Title = title
+	stream.IndexSetID = indexSetID
+	if _, err := cl.CreateStream(stream); err != nil {
+		return err
+	}
+	for k := 0; k < 2; k++ {
+		rule := testutil.StreamRule()
+		rule.StreamID = stream.ID
+		if _, err := cl.CreateStreamRule(rule); err != nil {
+			return err
+		}
+		rule.Value = "changed"
+		if _, err := cl.UpdateStreamRule(rule); err != nil {
+			return err
+		}
+	}
+	if _, _, _, err := cl.GetStreams(); err != nil {
+		return err
+	}
+	if _, total, _, err := cl.GetStreamRules(stream.ID); err != nil || total != 2 {
+		return fmt.Errorf("the stream <%s> has %d rules, wanted 2: %v", stream.ID, total, err)
+	}
+	_, err := cl.DeleteStream(stream.ID)
+	return err
+}
+
+// TestLockedData checks that neither the first login of a LDAP user
+// nor reading the system jobs changes the data while another goroutine holds the lock.
+func TestLockedData(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	server.SetLDAPDirectory(&logic.LDAPDirectory{
+		Users: []logic.LDAPUser{{UID: "foo", Password: "password"}},
+	})
+	if _, err := cl.UpdateLDAPSettings(&graylog.LDAPSettings{
+		Enabled:              true,
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		DefaultGroup:         "Admin",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	indexSet := testutil.IndexSet("hoge")
+	if _, err := cl.CreateIndexSet(indexSet); err != nil {
+		t.Fatal(err)
+	}
+
+	server.SetSystemJobDuration(time.Millisecond)
+	if _, _, err := cl.RebuildIndexSetIndexRanges(indexSet.ID); err != nil {
+		t.Fatal(err)
+	}
+	server.RLock()
+	before, _, err := server.GetIndexRange("hoge_0")
+	if err != nil {
+		server.RUnlock()
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, _, err := cl.GetSystemJobs(); err != nil {
+		server.RUnlock()
+		t.Fatal(err)
+	}
+	after, _, err := server.GetIndexRange("hoge_0")
+	server.RUnlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.CalculatedAt != before.CalculatedAt {
+		t.Fatal("reading the system jobs should not run the finished job while the data is locked")
+	}
+
+	ext, err := client.NewClient(server.Endpoint(), "foo", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Lock()
+	login := make(chan error, 1)
+	go func() {
+		_, _, err := ext.GetUser("foo")
+		login <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	user, _, _ := server.GetUser("foo")
+	server.Unlock()
+	if err := <-login; err != nil {
+		t.Fatal(err)
+	}
+	if user != nil {
+		t.Fatal("the external user should not be created while the data is locked")
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetDeflector is the handler of Get an Index Set's Deflector status API.
+func HandleGetDeflector(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/deflector/{indexSetId} Get current deflector status
+	id := ps.ByName("indexSetID")
+	if sc, err := lgc.Authorize(user, "deflector:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetDeflector(id)
+}
+
+// HandleCycleDeflector is the handler of Cycle an Index Set's Deflector API.
+func HandleCycleDeflector(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/deflector/{indexSetId}/cycle Cycle deflector to new/next index
+	id := ps.ByName("indexSetID")
+	if sc, err := lgc.Authorize(user, "deflector:cycle", id); err != nil {
+		return nil, sc, err
+	}
+	jobID, sc, err := lgc.CycleDeflector(id)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return map[string]string{"id": jobID}, sc, nil
+}
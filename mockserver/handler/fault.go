@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// injectFault injects the fault into the response.
+// If the request shouldn't be handled any more, true is returned.
+func injectFault(lgc *logic.Logic, w http.ResponseWriter, r *http.Request, fault *logic.Fault) bool {
+	lgc.Logger().WithFields(log.Fields{
+		"path": r.URL.Path, "method": r.Method, "fault_id": fault.ID,
+	}).Info("inject a fault")
+	if d := fault.Delay(); d > 0 {
+		time.Sleep(d)
+	}
+	if fault.ConnectionReset {
+		resetConnection(lgc, w)
+		return true
+	}
+	if fault.StatusCode == 0 {
+		return false
+	}
+	w.WriteHeader(fault.StatusCode)
+	w.Write([]byte(fault.Body))
+	return true
+}
+
+// resetConnection closes the connection without any response.
+func resetConnection(lgc *logic.Logic, w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		lgc.Logger().Error("failed to reset the connection: hijacking isn't supported")
+		w.WriteHeader(500)
+		return
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		lgc.Logger().WithFields(log.Fields{
+			"error": err,
+		}).Error("failed to reset the connection")
+		return
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		// send RST instead of FIN
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
+
+// HandleGetFaults is the handler of the admin API to get the injected faults.
+func HandleGetFaults(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /_mock/faults
+	faults := lgc.GetFaults()
+	return map[string]interface{}{"faults": faults, "total": len(faults)}, 200, nil
+}
+
+// HandleInjectFault is the handler of the admin API to inject a fault.
+func HandleInjectFault(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// POST /_mock/faults
+	fault := &logic.Fault{}
+	if err := json.NewDecoder(r.Body).Decode(fault); err != nil {
+		return nil, 400, fmt.Errorf("failed to parse the request body as a fault: %s", err)
+	}
+	if sc, err := lgc.InjectFault(fault); err != nil {
+		return nil, sc, err
+	}
+	return fault, 201, nil
+}
+
+// HandleRemoveFault is the handler of the admin API to remove a fault.
+func HandleRemoveFault(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /_mock/faults/{faultID}
+	sc, err := lgc.RemoveFault(ps.ByName("faultID"))
+	return nil, sc, err
+}
+
+// HandleClearFaults is the handler of the admin API to remove all faults.
+func HandleClearFaults(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /_mock/faults
+	lgc.ClearFaults()
+	return nil, 204, nil
+}
@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleInjectFault(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	base := strings.TrimSuffix(server.Endpoint(), "/api")
+	resp, err := http.Post(
+		base+"/_mock/faults", "application/json",
+		strings.NewReader(`{"method": "GET", "path": "/api/roles/*", "status_code": 503, "body": "{}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+	fault := logic.Fault{}
+	if err := json.NewDecoder(resp.Body).Decode(&fault); err != nil {
+		t.Fatal(err)
+	}
+	_, ei, err := cl.GetRole("Admin")
+	if err == nil {
+		t.Fatal("the fault should be injected")
+	}
+	if ei.Response.StatusCode != 503 {
+		t.Fatalf("status code = %d, wanted 503", ei.Response.StatusCode)
+	}
+	if _, _, _, err := cl.GetRoles(); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, base+"/_mock/faults/"+fault.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+	if _, _, err := cl.GetRole("Admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = http.Post(
+		base+"/_mock/faults", "application/json", strings.NewReader(`{"path": "/api/roles"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Fatalf("status code = %d, wanted 400", resp.StatusCode)
+	}
+}
+
+func TestConnectionReset(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	if _, err := server.InjectFault(&logic.Fault{ConnectionReset: true, Nth: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := cl.GetRoles(); err == nil {
+		t.Fatal("the connection should be reset")
+	}
+	if _, _, _, err := cl.GetRoles(); err != nil {
+		t.Fatal(err)
+	}
+}
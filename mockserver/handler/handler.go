@@ -15,11 +15,34 @@ import (
 type Handler func(user *graylog.User, lgc *logic.Logic, w http.ResponseWriter, r *http.Request, ps httprouter.Params) (interface{}, int, error)
 
 func wrapHandle(lgc *logic.Logic, handler Handler) httprouter.Handle {
-	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	return func(rw http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		lgc.Logger().WithFields(log.Fields{
 			"path": r.URL.Path, "method": r.Method,
 		}).Info("request start")
+		w := &responseRecorder{ResponseWriter: rw, statusCode: 200}
+		req, err := newRecordedRequest(r)
+		if err != nil {
+			writeResponse(w, nil, 400, err)
+			return
+		}
+		defer func() {
+			req.StatusCode = w.statusCode
+			lgc.RecordRequest(req)
+		}()
 		w.Header().Set("Content-Type", "application/json")
+		if err := lgc.CheckCall(r.Method, r.URL.Path); err != nil {
+			writeResponse(w, nil, 500, err)
+			return
+		}
+		if !lgc.IsRouteAvailable(r.Method, r.URL.Path) {
+			HandleNotFound(lgc)(w, r)
+			return
+		}
+		if fault := lgc.MatchFault(r.Method, r.URL.Path); fault != nil {
+			if injectFault(lgc, w, r, fault) {
+				return
+			}
+		}
 		// authentication
 		var user *graylog.User
 		if lgc.Auth() {
@@ -35,7 +58,7 @@ func wrapHandle(lgc *logic.Logic, handler Handler) httprouter.Handle {
 				sc  int
 				err error
 			)
-			user, sc, err = lgc.Authenticate(authName, authPass)
+			user, sc, err = authenticate(lgc, authName, authPass)
 			if err != nil {
 				w.WriteHeader(sc)
 				if sc == 401 {
@@ -54,30 +77,87 @@ func wrapHandle(lgc *logic.Logic, handler Handler) httprouter.Handle {
 				"path": r.URL.Path, "method": r.Method,
 				"user_name": user.Username,
 			}).Info("request user name")
+			req.User = user.Username
 		}
 
-		body, sc, err := handler(user, lgc, w, r, ps)
-		if err != nil {
-			w.WriteHeader(sc)
+		body, sc, err := callHandler(user, lgc, handler, w, r, ps)
+		writeResponse(w, body, sc, err)
+	}
+}
 
-			ae := NewAPIError(err.Error())
-			b, err := json.Marshal(ae)
-			if err != nil {
-				w.Write([]byte(`{"message":"failed to marshal an APIError"}`))
-				return
-			}
-			w.Write(b)
-			return
-		}
-		if body == nil {
-			return
+// authenticate authenticates a user while the mock server's data are locked.
+// The LDAP authentication creates or updates the external user,
+// so then the data are locked for writing and saved.
+func authenticate(lgc *logic.Logic, name, password string) (*graylog.User, int, error) {
+	lgc.RLock()
+	isLDAP, err := lgc.RequiresLDAPAuthentication(name, password)
+	if err != nil {
+		lgc.RUnlock()
+		return nil, 500, err
+	}
+	if !isLDAP {
+		defer lgc.RUnlock()
+		return lgc.Authenticate(name, password)
+	}
+	lgc.RUnlock()
+
+	lgc.Lock()
+	defer lgc.Unlock()
+	user, sc, err := lgc.Authenticate(name, password)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return user, sc, nil
+}
+
+// callHandler calls the handler while the mock server's data are locked,
+// so the API calls which read and write the data in several steps are isolated from each other.
+// The requests which don't change the data share the lock and must not change the data.
+// Before the requests which change the data, the finished system jobs are run.
+func callHandler(
+	user *graylog.User, lgc *logic.Logic, handler Handler,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		lgc.RLock()
+		defer lgc.RUnlock()
+		return handler(user, lgc, w, r, ps)
+	}
+	lgc.Lock()
+	defer lgc.Unlock()
+	if lgc.RunSystemJobs() != 0 {
+		if err := lgc.Save(); err != nil {
+			return nil, 500, err
 		}
-		b, err := json.Marshal(body)
-		if err == nil {
-			w.Write(b)
+	}
+	return handler(user, lgc, w, r, ps)
+}
+
+// writeResponse writes the handler's result as the response.
+func writeResponse(w http.ResponseWriter, body interface{}, sc int, err error) {
+	if err != nil {
+		w.WriteHeader(sc)
+
+		ae := NewAPIError(err.Error())
+		b, err := json.Marshal(ae)
+		if err != nil {
+			w.Write([]byte(`{"message":"failed to marshal an APIError"}`))
 			return
 		}
-		w.WriteHeader(500)
-		w.Write([]byte(`{"message":"500 Internal Server Error"}`))
+		w.Write(b)
+		return
+	}
+	if body == nil {
+		return
+	}
+	b, err := json.Marshal(body)
+	if err == nil {
+		w.Write(b)
+		return
 	}
+	w.WriteHeader(500)
+	w.Write([]byte(`{"message":"500 Internal Server Error"}`))
 }
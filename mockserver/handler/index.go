@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// The path parameter "index" is an index set id at the list APIs
+// and an index name at the others,
+// because httprouter requires the same name at the same path position.
+
+// HandleGetIndices is the handler of Get an Index Set's all indices API.
+func HandleGetIndices(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indexer/indices/{indexSetId}/list List all open, closed and reopened indices.
+	id := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indexsets:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetIndices(id)
+}
+
+// HandleGetOpenIndices is the handler of Get an Index Set's open indices API.
+func HandleGetOpenIndices(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indexer/indices/{indexSetId}/open Get information of all open indices managed by Graylog and their shards.
+	id := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indexsets:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetOpenIndices(id)
+}
+
+// HandleGetClosedIndices is the handler of Get an Index Set's closed indices API.
+func HandleGetClosedIndices(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indexer/indices/{indexSetId}/closed Get a list of closed indices that can be reopened.
+	id := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indexsets:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetClosedIndices(id)
+}
+
+// HandleGetReopenedIndices is the handler of Get an Index Set's reopened indices API.
+func HandleGetReopenedIndices(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indexer/indices/{indexSetId}/reopened Get a list of reopened indices, which will not be cleaned by retention cleaning
+	id := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indexsets:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetReopenedIndices(id)
+}
+
+// HandleGetIndex is the handler of Get an Index API.
+func HandleGetIndex(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indexer/indices/{index} Get information of an index and its shards.
+	name := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indices:read", name); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetIndex(name)
+}
+
+// HandleCloseIndex is the handler of Close an Index API.
+func HandleCloseIndex(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/indexer/indices/{index}/close Close an index. This will also trigger an index ranges rebuild job.
+	name := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indices:changestate", name); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.CloseIndex(name)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
+
+// HandleReopenIndex is the handler of Reopen an Index API.
+func HandleReopenIndex(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/indexer/indices/{index}/reopen Reopen a closed index. This will also trigger an index ranges rebuild job.
+	name := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indices:changestate", name); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.ReopenIndex(name)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
+
+// HandleDeleteIndex is the handler of Delete an Index API.
+func HandleDeleteIndex(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/indexer/indices/{index} Delete an index. This will also trigger an index ranges rebuild job.
+	name := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indices:delete", name); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.DeleteIndex(name)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetIndexRange is the handler of Get an Index Range API.
+func HandleGetIndexRange(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indices/ranges/{index} Show single index range
+	name := ps.ByName("index")
+	if sc, err := lgc.Authorize(user, "indexranges:read", name); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetIndexRange(name)
+}
+
+// HandleGetIndexRanges is the handler of Get Index Ranges API.
+func HandleGetIndexRanges(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/indices/ranges Get a list of all index ranges
+	if sc, err := lgc.Authorize(user, "indexranges:read"); err != nil {
+		return nil, sc, err
+	}
+	ranges, sc, err := lgc.GetIndexRanges()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.IndexRangesBody{Ranges: ranges, Total: len(ranges)}, sc, nil
+}
+
+// HandleRebuildIndexRanges is the handler of Rebuild all Index Ranges API.
+func HandleRebuildIndexRanges(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/indices/ranges/rebuild Rebuild/sync index range information.
+	if sc, err := lgc.Authorize(user, "indexranges:rebuild"); err != nil {
+		return nil, sc, err
+	}
+	jobID, sc, err := lgc.RebuildIndexRanges()
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return map[string]string{"id": jobID}, sc, nil
+}
+
+// HandleRebuildIndexSetIndexRanges is the handler of Rebuild an Index Set's Index Ranges API.
+func HandleRebuildIndexSetIndexRanges(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/indices/ranges/index_set/{indexSetId}/rebuild Rebuild/sync index range information for the given index set.
+	id := ps.ByName("indexSetID")
+	if sc, err := lgc.Authorize(user, "indexranges:rebuild", id); err != nil {
+		return nil, sc, err
+	}
+	jobID, sc, err := lgc.RebuildIndexSetIndexRanges(id)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return map[string]string{"id": jobID}, sc, nil
+}
@@ -107,13 +107,17 @@ func HandleCreateIndexSet(
 	if sc, err := lgc.Authorize(user, "indexsets:create"); err != nil {
 		return nil, sc, err
 	}
+	optional := set.NewStrSet("description", "replicas", "index_optimization_disabled", "writable")
+	if lgc.Profile().FieldTypeRefreshInterval {
+		optional.Add("field_type_refresh_interval")
+	}
 	body, sc, err := validateRequestBody(
 		r.Body, &validateReqBodyPrms{
 			Required: set.NewStrSet(
 				"title", "index_prefix", "rotation_strategy_class", "rotation_strategy",
 				"retention_strategy_class", "retention_strategy", "creation_date",
 				"index_analyzer", "shards", "index_optimization_max_num_segments"),
-			Optional:     set.NewStrSet("description", "replicas", "index_optimization_disabled", "writable"),
+			Optional:     optional,
 			Ignored:      set.NewStrSet("default"),
 			ExtForbidden: true,
 		})
@@ -164,13 +168,17 @@ func HandleUpdateIndexSet(
 		return nil, sc, err
 	}
 
+	optional := set.NewStrSet("description", "replicas", "index_optimization_disabled", "writable")
+	if lgc.Profile().FieldTypeRefreshInterval {
+		optional.Add("field_type_refresh_interval")
+	}
 	body, sc, err := validateRequestBody(
 		r.Body, &validateReqBodyPrms{
 			Required: set.NewStrSet(
 				"title", "index_prefix", "rotation_strategy_class", "rotation_strategy",
 				"retention_strategy_class", "retention_strategy",
 				"index_analyzer", "shards", "index_optimization_max_num_segments"),
-			Optional: set.NewStrSet("description", "replicas", "index_optimization_disabled", "writable"),
+			Optional: optional,
 			Ignored:  set.NewStrSet("default", "creation_date"),
 		})
 	if err != nil {
@@ -0,0 +1,55 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleCloseIndex(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	is := testutil.IndexSet("hoge")
+	if _, err := server.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	ei, err := client.CloseIndex("hoge_0")
+	if err == nil {
+		t.Fatal("the active write index can't be closed")
+	}
+	if ei.Response.StatusCode != 403 {
+		t.Fatalf("status code == %d, wanted 403", ei.Response.StatusCode)
+	}
+	indices, _, err := client.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices.Closed.Total != 0 {
+		t.Fatalf("indices.Closed.Total == %d, wanted 0", indices.Closed.Total)
+	}
+}
+
+func TestHandleDeleteIndex(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	is := testutil.IndexSet("hoge")
+	if _, err := server.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	ei, err := client.DeleteIndex("hoge_0")
+	if err == nil {
+		t.Fatal("the active write index can't be deleted")
+	}
+	if ei.Response.StatusCode != 403 {
+		t.Fatalf("status code == %d, wanted 403", ei.Response.StatusCode)
+	}
+	if _, _, err := client.GetIndex("hoge_0"); err != nil {
+		t.Fatal(err)
+	}
+}
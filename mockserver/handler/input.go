@@ -128,7 +128,8 @@ func HandleUpdateInput(
 	if err := lgc.Save(); err != nil {
 		return nil, 500, err
 	}
-	return input, sc, nil
+	// Graylog returns only the input's id
+	return &map[string]string{"id": input.ID}, sc, nil
 }
 
 // HandleDeleteInput is the handler of Delete an Input API.
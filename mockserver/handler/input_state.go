@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetInputStates is the handler of Get all Input States API.
+func HandleGetInputStates(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/inputstates Get all input states of this node
+	arr, sc, err := lgc.GetInputStates()
+	if err != nil {
+		return nil, sc, err
+	}
+	states := []graylog.InputStateSummary{}
+	for _, state := range arr {
+		if _, err := lgc.Authorize(user, "inputs:read", state.ID); err != nil {
+			continue
+		}
+		states = append(states, state)
+	}
+	return &graylog.InputStatesBody{States: states}, sc, nil
+}
+
+// HandleGetInputState is the handler of Get an Input State API.
+func HandleGetInputState(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/inputstates/{inputId} Get input state for specified input ID on this node
+	id := ps.ByName("inputID")
+	if sc, err := lgc.Authorize(user, "inputs:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetInputState(id)
+}
+
+// HandleStartInput is the handler of Start an Input API.
+func HandleStartInput(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// PUT /system/inputstates/{inputId} (Re-)Start specified input on this node
+	id := ps.ByName("inputID")
+	if sc, err := lgc.Authorize(user, "inputs:changestate", id); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.StartInput(id)
+	if err != nil {
+		return nil, sc, err
+	}
+	return map[string]string{"id": id}, sc, nil
+}
+
+// HandleStopInput is the handler of Stop an Input API.
+func HandleStopInput(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/inputstates/{inputId} Stop specified input on this node
+	id := ps.ByName("inputID")
+	if sc, err := lgc.Authorize(user, "inputs:changestate", id); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.StopInput(id)
+	if err != nil {
+		return nil, sc, err
+	}
+	return map[string]string{"id": id}, sc, nil
+}
@@ -0,0 +1,40 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleGetInputState(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.FailInput(input.ID, "failed to bind the port"); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err := client.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateFailed {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateFailed)
+	}
+	if _, err := client.StartInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err = client.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateRunning {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateRunning)
+	}
+}
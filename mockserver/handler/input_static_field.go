@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// HandleCreateInputStaticField is the handler of Add a static field to an Input API.
+func HandleCreateInputStaticField(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/inputs/{inputId}/staticfields Add a static field to an input
+	id := ps.ByName("inputID")
+	if sc, err := lgc.Authorize(user, "inputs:edit", id); err != nil {
+		return nil, sc, err
+	}
+	body, sc, err := validateRequestBody(
+		r.Body, &validateReqBodyPrms{
+			Required:     set.NewStrSet("key", "value"),
+			ExtForbidden: true,
+		})
+	if err != nil {
+		return nil, sc, err
+	}
+	key, ok := body["key"].(string)
+	if !ok {
+		return nil, 400, fmt.Errorf(
+			`in the request body the field "key" must be a string`)
+	}
+	value, ok := body["value"].(string)
+	if !ok {
+		return nil, 400, fmt.Errorf(
+			`in the request body the field "value" must be a string`)
+	}
+	sc, err = lgc.AddInputStaticField(id, key, value)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
+
+// HandleDeleteInputStaticField is the handler of Remove static field of an Input API.
+func HandleDeleteInputStaticField(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/inputs/{inputId}/staticfields/{key} Remove static field of an input
+	id := ps.ByName("inputID")
+	if sc, err := lgc.Authorize(user, "inputs:edit", id); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.DeleteInputStaticField(id, ps.ByName("key"))
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
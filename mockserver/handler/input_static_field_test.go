@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleCreateInputStaticField(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	act, _, err := server.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.StaticFields["env"] != "test" {
+		t.Fatalf(`StaticFields["env"] = "%s", wanted "test"`, act.StaticFields["env"])
+	}
+
+	u, err := client.Endpoints().InputStaticFields(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := bytes.NewBuffer([]byte(`{"key": "env", "value": 1}`))
+	req, err := http.NewRequest(http.MethodPost, u.String(), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(client.Name(), client.Password())
+	hc := &http.Client{}
+	resp, err := hc.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 400 {
+		t.Fatalf("resp.StatusCode == %d, wanted 400", resp.StatusCode)
+	}
+}
+
+func TestHandleDeleteInputStaticField(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.DeleteInputStaticField(input.ID, "env"); err == nil {
+		t.Fatal(`static field "env" should be already deleted`)
+	}
+}
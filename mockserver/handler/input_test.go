@@ -5,9 +5,22 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/testutil"
 )
 
+type pluginInputAttrs struct {
+	Host    string `json:"handler_plugin_host,omitempty"`
+	Workers int    `json:"handler_plugin_workers,omitempty"`
+	Debug   bool   `json:"handler_plugin_debug,omitempty"`
+}
+
+const pluginInputType = "com.example.graylog.HandlerPluginInput"
+
+func (attrs *pluginInputAttrs) InputType() string {
+	return pluginInputType
+}
+
 func TestHandleGetInput(t *testing.T) {
 	server, client, err := testutil.GetServerAndClient()
 	if err != nil {
@@ -183,3 +196,50 @@ func TestHandleDeleteInput(t *testing.T) {
 		t.Fatal(`no input whose id is "h"`)
 	}
 }
+
+func TestHandleRegisteredInputType(t *testing.T) {
+	if err := graylog.RegisterInputAttrs(pluginInputType, func() graylog.InputAttrs {
+		return &pluginInputAttrs{}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	input := &graylog.Input{
+		Title: "plugin",
+		Node:  "2ad6b340-3e5f-4a96-ae81-040cfb8b6024",
+		Attrs: &pluginInputAttrs{Host: "example.com", Workers: 2, Debug: true},
+	}
+	if _, err := client.CreateInput(input); err != nil {
+		t.Fatal(err)
+	}
+	act, _, err := client.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.Type() != pluginInputType {
+		t.Fatalf("act.Type() == %s, wanted %s", act.Type(), pluginInputType)
+	}
+	attrs, ok := act.Attrs.(*pluginInputAttrs)
+	if !ok {
+		t.Fatalf("act.Attrs is %T, wanted *pluginInputAttrs", act.Attrs)
+	}
+	if *attrs != (pluginInputAttrs{Host: "example.com", Workers: 2, Debug: true}) {
+		t.Fatalf("act.Attrs == %+v", attrs)
+	}
+	inputs, _, _, err := client.GetInputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range inputs {
+		if a.ID != input.ID {
+			continue
+		}
+		if _, ok := a.Attrs.(*pluginInputAttrs); !ok {
+			t.Fatalf("the listed input's attrs is %T, wanted *pluginInputAttrs", a.Attrs)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/util"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// HandleGetLDAPSettings is the handler of Get LDAP Settings API.
+func HandleGetLDAPSettings(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/ldap/settings Get the LDAP configuration if it is configured
+	if sc, err := lgc.Authorize(user, "ldap:edit"); err != nil {
+		return nil, sc, err
+	}
+	settings, sc, err := lgc.GetLDAPSettings()
+	if err != nil || settings == nil {
+		return nil, sc, err
+	}
+	return settings, sc, nil
+}
+
+// HandleUpdateLDAPSettings is the handler of Update LDAP Settings API.
+func HandleUpdateLDAPSettings(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// PUT /system/ldap/settings Update the LDAP configuration
+	if sc, err := lgc.Authorize(user, "ldap:edit"); err != nil {
+		return nil, sc, err
+	}
+	body, sc, err := validateRequestBody(
+		r.Body, &validateReqBodyPrms{
+			Required: set.NewStrSet(
+				"enabled", "ldap_uri", "search_base", "search_pattern",
+				"display_name_attribute"),
+			Optional: set.NewStrSet(
+				"system_username", "system_password", "use_start_tls",
+				"trust_all_certificates", "active_directory", "default_group",
+				"group_mapping", "group_search_base", "group_id_attribute",
+				"additional_default_groups", "group_search_pattern"),
+			Ignored:      set.NewStrSet("system_password_set"),
+			ExtForbidden: true,
+		})
+	if err != nil {
+		return nil, sc, err
+	}
+	settings := &graylog.LDAPSettings{}
+	if err := util.MSDecode(body, settings); err != nil {
+		lgc.Logger().WithFields(log.Fields{
+			"body": body, "error": err,
+		}).Info("Failed to parse request body as LDAPSettings")
+		return nil, 400, err
+	}
+	sc, err = lgc.UpdateLDAPSettings(settings)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
+
+// HandleDeleteLDAPSettings is the handler of Delete LDAP Settings API.
+func HandleDeleteLDAPSettings(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/ldap/settings Remove the LDAP configuration
+	if sc, err := lgc.Authorize(user, "ldap:edit"); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.DeleteLDAPSettings()
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
+
+// HandleTestLDAPSettings is the handler of Test LDAP Configuration API.
+func HandleTestLDAPSettings(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/ldap/test Test LDAP Configuration
+	if sc, err := lgc.Authorize(user, "ldap:edit"); err != nil {
+		return nil, sc, err
+	}
+	body, sc, err := validateRequestBody(
+		r.Body, &validateReqBodyPrms{
+			Required: set.NewStrSet("ldap_uri", "test_connect_only"),
+			Optional: set.NewStrSet(
+				"system_username", "system_password", "use_start_tls",
+				"trust_all_certificates", "active_directory", "search_base",
+				"search_pattern", "principal", "password", "group_search_base",
+				"group_id_attribute", "group_search_pattern"),
+			ExtForbidden: true,
+		})
+	if err != nil {
+		return nil, sc, err
+	}
+	req := &graylog.LDAPTestConfigRequest{}
+	if err := util.MSDecode(body, req); err != nil {
+		lgc.Logger().WithFields(log.Fields{
+			"body": body, "error": err,
+		}).Info("Failed to parse request body as LDAPTestConfigRequest")
+		return nil, 400, err
+	}
+	return lgc.TestLDAPSettings(req)
+}
+
+// HandleGetLDAPGroups is the handler of Get LDAP Groups API.
+func HandleGetLDAPGroups(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/ldap/groups Get the available LDAP groups
+	if sc, err := lgc.Authorize(user, "ldapgroups:read"); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetLDAPGroups()
+}
+
+// HandleGetLDAPGroupRoleMapping is the handler of Get LDAP Group Role Mapping API.
+func HandleGetLDAPGroupRoleMapping(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/ldap/settings/groups Get the LDAP group to Graylog role mapping
+	if sc, err := lgc.Authorize(user, "ldapgroups:read"); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetLDAPGroupRoleMapping()
+}
+
+// HandleUpdateLDAPGroupRoleMapping is the handler of Update LDAP Group Role Mapping API.
+func HandleUpdateLDAPGroupRoleMapping(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// PUT /system/ldap/settings/groups Update the LDAP group to Graylog role mapping
+	if sc, err := lgc.Authorize(user, "ldapgroups:edit"); err != nil {
+		return nil, sc, err
+	}
+	mapping := map[string]string{}
+	if err := json.NewDecoder(r.Body).Decode(&mapping); err != nil {
+		return nil, 400, fmt.Errorf(
+			"failed to parse the request body as a map of groups to roles: %s", err)
+	}
+	sc, err := lgc.UpdateLDAPGroupRoleMapping(mapping)
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
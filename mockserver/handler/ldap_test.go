@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleExternalUserSaved(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	server, err := mockserver.NewServer("", plain.NewStore(tmpfile.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Start()
+	defer server.Close()
+	server.SetLDAPDirectory(&logic.LDAPDirectory{
+		Users: []logic.LDAPUser{{UID: "foo", Password: "password"}},
+	})
+	cl, err := client.NewClient(server.Endpoint(), "admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.UpdateLDAPSettings(&graylog.LDAPSettings{
+		Enabled:              true,
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		DefaultGroup:         "Admin",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ext, err := client.NewClient(server.Endpoint(), "foo", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ext.GetUser("foo"); err != nil {
+		t.Fatal(err)
+	}
+	// the external user which is created at the login is saved
+	s := plain.NewStore(tmpfile.Name())
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+	user, err := s.GetUser("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || !user.External {
+		t.Fatalf("the external user should be saved: %v", user)
+	}
+}
+
+func TestHandleExternalUser(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	server.SetLDAPDirectory(&logic.LDAPDirectory{
+		Users: []logic.LDAPUser{{
+			UID: "foo", Password: "password", Groups: []string{"admins"}}},
+	})
+	if _, err := cl.UpdateLDAPSettings(&graylog.LDAPSettings{
+		Enabled:              true,
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		GroupMapping:         map[string]string{"admins": "Admin"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ext, err := client.NewClient(server.Endpoint(), "foo", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, err := ext.GetUser("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !user.External {
+		t.Fatal("the user should be external")
+	}
+	ext, err = client.NewClient(server.Endpoint(), "foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ei, err := ext.GetUser("foo")
+	if err == nil {
+		t.Fatal("the password is wrong")
+	}
+	if ei.Response.StatusCode != 401 {
+		t.Fatalf("status code == %d, wanted 401", ei.Response.StatusCode)
+	}
+}
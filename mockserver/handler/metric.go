@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/util"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// HandleGetThroughput is the handler of Get Throughput API.
+func HandleGetThroughput(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/throughput Current throughput of this node in messages per second
+	if sc, err := lgc.Authorize(user, "throughput:read"); err != nil {
+		return nil, sc, err
+	}
+	throughput, sc, err := lgc.GetThroughput()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.ThroughputBody{Throughput: throughput}, sc, nil
+}
+
+// HandleGetMetric is the handler of Get a Metric API.
+func HandleGetMetric(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/metrics/{metricName} Get a single metric
+	name := ps.ByName("metricName")
+	if sc, err := lgc.Authorize(user, "metrics:read", name); err != nil {
+		return nil, sc, err
+	}
+	metric, sc, err := lgc.GetMetric(name)
+	if err != nil {
+		return nil, sc, err
+	}
+	// unlike Get Multiple Metrics API, only the metric's value is returned
+	switch metric.Type {
+	case graylog.MetricTypeGauge:
+		return metric.Gauge, sc, nil
+	case graylog.MetricTypeCounter:
+		return metric.Counter, sc, nil
+	case graylog.MetricTypeMeter:
+		return metric.Meter, sc, nil
+	case graylog.MetricTypeTimer:
+		return metric.Timer, sc, nil
+	}
+	return nil, 500, fmt.Errorf("unsupported metric type: %s", metric.Type)
+}
+
+// HandleGetMetrics is the handler of Get Multiple Metrics API.
+func HandleGetMetrics(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// POST /system/metrics/multiple Get the values of multiple metrics at once
+	body, sc, err := validateRequestBody(
+		r.Body, &validateReqBodyPrms{
+			Required:     set.NewStrSet("metrics"),
+			ExtForbidden: true,
+		})
+	if err != nil {
+		return body, sc, err
+	}
+	names := &graylog.MetricNamesBody{}
+	if err := util.MSDecode(body, names); err != nil {
+		lgc.Logger().WithFields(log.Fields{
+			"body": body, "error": err,
+		}).Info("Failed to parse request body as MetricNamesBody")
+		return nil, 400, err
+	}
+	for _, name := range names.Metrics {
+		if sc, err := lgc.Authorize(user, "metrics:read", name); err != nil {
+			return nil, sc, err
+		}
+	}
+	metrics, sc, err := lgc.GetMetrics(names.Metrics)
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.MetricsBody{Metrics: metrics, Total: len(metrics)}, sc, nil
+}
+
+// HandleGetJournal is the handler of Get Journal API.
+func HandleGetJournal(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/journal Get current state of the journal on this node.
+	if sc, err := lgc.Authorize(user, "journal:read"); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetJournal()
+}
@@ -0,0 +1,22 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleGetMetric(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	_, ei, err := client.GetCounter("foo")
+	if err == nil {
+		t.Fatal(`no metric "foo"`)
+	}
+	if ei.Response.StatusCode != 404 {
+		t.Fatalf("status code == %d, wanted 404", ei.Response.StatusCode)
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// MockHandler is the handler of the mock server's admin API.
+// The admin API isn't the part of the Graylog API but controls the mock server,
+// so the authentication isn't required.
+// The paths of the admin API start with "/_mock/".
+type MockHandler func(lgc *logic.Logic, w http.ResponseWriter, r *http.Request, ps httprouter.Params) (interface{}, int, error)
+
+func wrapMockHandle(lgc *logic.Logic, handler MockHandler) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		lgc.Logger().WithFields(log.Fields{
+			"path": r.URL.Path, "method": r.Method,
+		}).Info("admin request start")
+		w.Header().Set("Content-Type", "application/json")
+		body, sc, err := handler(lgc, w, r, ps)
+		writeResponse(w, body, sc, err)
+	}
+}
+
+// HandleGetSnapshot is the handler of the admin API to get a snapshot of the mock server's data.
+func HandleGetSnapshot(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /_mock/snapshot
+	snapshot, err := lgc.Snapshot()
+	if err != nil {
+		return nil, 500, err
+	}
+	return &snapshot, 200, nil
+}
+
+// HandleRestoreSnapshot is the handler of the admin API to restore the mock server's data from a snapshot.
+func HandleRestoreSnapshot(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// PUT /_mock/snapshot
+	snapshot := logic.Snapshot{}
+	if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+		return nil, 400, fmt.Errorf("failed to parse the request body as a snapshot: %s", err)
+	}
+	if err := lgc.Restore(snapshot); err != nil {
+		return nil, 500, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, 204, nil
+}
+
+// HandleReset is the handler of the admin API to reset the mock server's data to the initial data.
+func HandleReset(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// POST /_mock/reset
+	if err := lgc.Reset(); err != nil {
+		return nil, 500, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, 204, nil
+}
@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleSnapshot(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	base := strings.TrimSuffix(server.Endpoint(), "/api")
+	// the admin API doesn't require the authentication
+	resp, err := http.Get(base + "/_mock/snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+	snapshot, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := testutil.Role()
+	if _, err := cl.CreateRole(role); err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPut, base+"/_mock/snapshot", bytes.NewReader(snapshot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+	if _, _, err := cl.GetRole(role.Name); err == nil {
+		t.Fatal("the role should be removed by restoring the snapshot")
+	}
+
+	req, err = http.NewRequest(http.MethodPut, base+"/_mock/snapshot", strings.NewReader("foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Fatalf("status code = %d, wanted 400", resp.StatusCode)
+	}
+}
+
+func TestHandleReset(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	role := testutil.Role()
+	if _, err := cl.CreateRole(role); err != nil {
+		t.Fatal(err)
+	}
+	base := strings.TrimSuffix(server.Endpoint(), "/api")
+	resp, err := http.Post(base+"/_mock/reset", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+	if _, _, err := cl.GetRole(role.Name); err == nil {
+		t.Fatal("the role should be removed by the reset")
+	}
+	if _, _, err := cl.GetUser("admin"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetSystemOverview is the handler of Get System Overview API.
+func HandleGetSystemOverview(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system Get system overview
+	if sc, err := lgc.Authorize(user, "system:read"); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetSystemOverview()
+}
+
+// HandleGetNodes is the handler of Get Cluster Nodes API.
+func HandleGetNodes(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/cluster/nodes List all active nodes in this cluster.
+	nodes, sc, err := lgc.GetNodes()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.NodesBody{Nodes: nodes, Total: len(nodes)}, sc, nil
+}
+
+// HandleGetNode is the handler of Get a Cluster Node API.
+func HandleGetNode(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/cluster/nodes/{nodeId} Information about a node.
+	return lgc.GetNode(ps.ByName("nodeID"))
+}
+
+// HandleGetCurrentNode is the handler of Get the Current Cluster Node API.
+func HandleGetCurrentNode(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/cluster/node Information about this node.
+	return lgc.GetCurrentNode()
+}
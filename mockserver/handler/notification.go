@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetNotifications is the handler of Get Notifications API.
+func HandleGetNotifications(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/notifications Get all active notifications
+	if sc, err := lgc.Authorize(user, "notifications:read"); err != nil {
+		return nil, sc, err
+	}
+	notifications, sc, err := lgc.GetNotifications()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.NotificationsBody{
+		Notifications: notifications, Total: len(notifications)}, sc, nil
+}
+
+// HandleDeleteNotification is the handler of Delete a Notification API.
+func HandleDeleteNotification(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/notifications/{notificationType} Delete a notification
+	typ := ps.ByName("notificationType")
+	if sc, err := lgc.Authorize(user, "notifications:delete", typ); err != nil {
+		return nil, sc, err
+	}
+	sc, err := lgc.DeleteNotification(graylog.NotificationType(typ))
+	if err != nil {
+		return nil, sc, err
+	}
+	if err := lgc.Save(); err != nil {
+		return nil, 500, err
+	}
+	return nil, sc, nil
+}
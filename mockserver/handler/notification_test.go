@@ -0,0 +1,22 @@
+package handler_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleDeleteNotification(t *testing.T) {
+	server, client, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	ei, err := client.DeleteNotification("foo")
+	if err == nil {
+		t.Fatal(`no notification type "foo"`)
+	}
+	if ei.Response.StatusCode != 404 {
+		t.Fatalf("status code == %d, wanted 404", ei.Response.StatusCode)
+	}
+}
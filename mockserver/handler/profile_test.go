@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+func TestGraylogVersion(t *testing.T) {
+	server, err := mockserver.NewServer("", nil, mockserver.WithGraylogVersion("3.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Start()
+	defer server.Close()
+	server.SetAuth(false)
+	// index_set_id is optional at 3.0
+	resp, err := http.Post(
+		server.Endpoint()+"/streams", "application/json",
+		strings.NewReader(`{"title": "foo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+
+	server, err = mockserver.NewServer("", nil, logic.WithProfile(logic.Profile{
+		Name: "custom", StreamIndexSetRequired: true,
+		UnavailableRoutes: []string{"GET /api/alerts/conditions"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Start()
+	defer server.Close()
+	server.SetAuth(false)
+	resp, err = http.Get(server.Endpoint() + "/alerts/conditions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 404 {
+		t.Fatalf("status code = %d, wanted 404", resp.StatusCode)
+	}
+	resp, err = http.Post(
+		server.Endpoint()+"/streams", "application/json",
+		strings.NewReader(`{"title": "foo"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Fatalf("status code = %d, wanted 400", resp.StatusCode)
+	}
+}
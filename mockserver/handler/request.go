@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// responseRecorder records the response's status code.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (rec *responseRecorder) WriteHeader(sc int) {
+	rec.statusCode = sc
+	rec.ResponseWriter.WriteHeader(sc)
+}
+
+// Hijack is used to reset the connection.
+func (rec *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := rec.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("hijacking isn't supported")
+	}
+	// no response is returned
+	rec.statusCode = 0
+	return hj.Hijack()
+}
+
+// newRecordedRequest returns a RecordedRequest of the request.
+// The request body is read and replaced so that the handler can read it.
+func newRecordedRequest(r *http.Request) (logic.RecordedRequest, error) {
+	req := logic.RecordedRequest{
+		Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery,
+		Time: time.Now()}
+	if r.Body == nil {
+		return req, nil
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return req, fmt.Errorf("failed to read the request body: %s", err)
+	}
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.Body = string(b)
+	return req, nil
+}
+
+// HandleGetRequests is the handler of the admin API to get the recorded requests.
+func HandleGetRequests(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /_mock/requests
+	reqs := lgc.Requests()
+	return map[string]interface{}{"requests": reqs, "total": len(reqs)}, 200, nil
+}
+
+// HandleClearRequests is the handler of the admin API to remove the recorded requests.
+func HandleClearRequests(
+	lgc *logic.Logic, w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /_mock/requests
+	lgc.ClearRequests()
+	return nil, 204, nil
+}
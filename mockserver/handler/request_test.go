@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestHandleGetRequests(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	role := testutil.Role()
+	if _, err := cl.CreateRole(role); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cl.GetRole("nonexistent"); err == nil {
+		t.Fatal("the role should not be found")
+	}
+	base := strings.TrimSuffix(server.Endpoint(), "/api")
+	resp, err := http.Get(base + "/_mock/requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("status code = %d, wanted 200", resp.StatusCode)
+	}
+	body := struct {
+		Requests []logic.RecordedRequest `json:"requests"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Requests) != 2 {
+		t.Fatalf("requests = %+v, wanted 2 requests", body.Requests)
+	}
+	req := body.Requests[0]
+	if req.Method != "POST" || req.Path != "/api/roles" || req.User != "admin" {
+		t.Fatalf("request = %+v", req)
+	}
+	if !strings.Contains(req.Body, role.Name) {
+		t.Fatalf("request body = %s, wanted the role", req.Body)
+	}
+	if body.Requests[1].StatusCode != 404 {
+		t.Fatalf("status code = %d, wanted 404", body.Requests[1].StatusCode)
+	}
+
+	req2, err := http.NewRequest(http.MethodDelete, base+"/_mock/requests", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.DefaultClient.Do(req2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if reqs := server.Requests(); len(reqs) != 0 {
+		t.Fatalf("requests = %+v, wanted empty", reqs)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	server, cl, err := testutil.GetServerAndClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	server.SetStrict(true)
+	server.ExpectCall("GET", "/api/roles/*").Times(1)
+	if _, _, err := cl.GetRole("Admin"); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, err = cl.GetRoles()
+	if err == nil {
+		t.Fatal("unexpected calls should fail in the strict mode")
+	}
+	if err := server.VerifyExpectations(); err != nil {
+		t.Fatal(err)
+	}
+}
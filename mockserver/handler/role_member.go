@@ -32,6 +32,10 @@ func HandleRoleMembers(
 	if err != nil {
 		return nil, sc, err
 	}
+	for i, u := range arr {
+		u.Password = ""
+		arr[i] = u
+	}
 	users := &membersBody{Users: arr, Role: name}
 	return users, sc, nil
 }
@@ -31,6 +31,17 @@ func NewRouter(lgc *logic.Logic) *httprouter.Router {
 	router.POST("/api/system/inputs", wrapHandle(lgc, HandleCreateInput))
 	router.PUT("/api/system/inputs/:inputID", wrapHandle(lgc, HandleUpdateInput))
 	router.DELETE("/api/system/inputs/:inputID", wrapHandle(lgc, HandleDeleteInput))
+	router.POST(
+		"/api/system/inputs/:inputID/staticfields",
+		wrapHandle(lgc, HandleCreateInputStaticField))
+	router.DELETE(
+		"/api/system/inputs/:inputID/staticfields/:key",
+		wrapHandle(lgc, HandleDeleteInputStaticField))
+
+	router.GET("/api/system/inputstates", wrapHandle(lgc, HandleGetInputStates))
+	router.GET("/api/system/inputstates/:inputID", wrapHandle(lgc, HandleGetInputState))
+	router.PUT("/api/system/inputstates/:inputID", wrapHandle(lgc, HandleStartInput))
+	router.DELETE("/api/system/inputstates/:inputID", wrapHandle(lgc, HandleStopInput))
 
 	router.GET("/api/system/indices/index_sets", wrapHandle(lgc, HandleGetIndexSets))
 	router.GET(
@@ -48,6 +59,71 @@ func NewRouter(lgc *logic.Logic) *httprouter.Router {
 		"/api/system/indices/index_sets/:indexSetID/stats",
 		wrapHandle(lgc, HandleGetIndexSetStats))
 
+	router.GET(
+		"/api/system/indexer/indices/:index/list", wrapHandle(lgc, HandleGetIndices))
+	router.GET(
+		"/api/system/indexer/indices/:index/open", wrapHandle(lgc, HandleGetOpenIndices))
+	router.GET(
+		"/api/system/indexer/indices/:index/closed", wrapHandle(lgc, HandleGetClosedIndices))
+	router.GET(
+		"/api/system/indexer/indices/:index/reopened",
+		wrapHandle(lgc, HandleGetReopenedIndices))
+	router.GET("/api/system/indexer/indices/:index", wrapHandle(lgc, HandleGetIndex))
+	router.POST(
+		"/api/system/indexer/indices/:index/close", wrapHandle(lgc, HandleCloseIndex))
+	router.POST(
+		"/api/system/indexer/indices/:index/reopen", wrapHandle(lgc, HandleReopenIndex))
+	router.DELETE("/api/system/indexer/indices/:index", wrapHandle(lgc, HandleDeleteIndex))
+
+	router.GET("/api/system/indices/ranges", wrapHandle(lgc, HandleGetIndexRanges))
+	router.GET("/api/system/indices/ranges/:index", wrapHandle(lgc, HandleGetIndexRange))
+	router.POST(
+		"/api/system/indices/ranges/rebuild", wrapHandle(lgc, HandleRebuildIndexRanges))
+	router.POST(
+		"/api/system/indices/ranges/index_set/:indexSetID/rebuild",
+		wrapHandle(lgc, HandleRebuildIndexSetIndexRanges))
+
+	router.GET("/api/system", wrapHandle(lgc, HandleGetSystemOverview))
+	router.GET("/api/system/cluster/nodes", wrapHandle(lgc, HandleGetNodes))
+	router.GET("/api/system/cluster/nodes/:nodeID", wrapHandle(lgc, HandleGetNode))
+	router.GET("/api/system/cluster/node", wrapHandle(lgc, HandleGetCurrentNode))
+
+	router.GET("/api/system/throughput", wrapHandle(lgc, HandleGetThroughput))
+	router.GET("/api/system/metrics/:metricName", wrapHandle(lgc, HandleGetMetric))
+	router.POST("/api/system/metrics/multiple", wrapHandle(lgc, HandleGetMetrics))
+	router.GET("/api/system/journal", wrapHandle(lgc, HandleGetJournal))
+
+	router.GET("/api/system/notifications", wrapHandle(lgc, HandleGetNotifications))
+	router.DELETE(
+		"/api/system/notifications/:notificationType",
+		wrapHandle(lgc, HandleDeleteNotification))
+
+	router.GET("/api/system/cluster_config", wrapHandle(lgc, HandleGetClusterConfigClasses))
+	router.GET(
+		"/api/system/cluster_config/:configClass", wrapHandle(lgc, HandleGetClusterConfig))
+	router.PUT(
+		"/api/system/cluster_config/:configClass", wrapHandle(lgc, HandleUpdateClusterConfig))
+	router.DELETE(
+		"/api/system/cluster_config/:configClass", wrapHandle(lgc, HandleDeleteClusterConfig))
+
+	router.GET("/api/system/ldap/settings", wrapHandle(lgc, HandleGetLDAPSettings))
+	router.PUT("/api/system/ldap/settings", wrapHandle(lgc, HandleUpdateLDAPSettings))
+	router.DELETE("/api/system/ldap/settings", wrapHandle(lgc, HandleDeleteLDAPSettings))
+	router.POST("/api/system/ldap/test", wrapHandle(lgc, HandleTestLDAPSettings))
+	router.GET("/api/system/ldap/groups", wrapHandle(lgc, HandleGetLDAPGroups))
+	router.GET(
+		"/api/system/ldap/settings/groups", wrapHandle(lgc, HandleGetLDAPGroupRoleMapping))
+	router.PUT(
+		"/api/system/ldap/settings/groups", wrapHandle(lgc, HandleUpdateLDAPGroupRoleMapping))
+
+	router.GET("/api/system/jobs", wrapHandle(lgc, HandleGetSystemJobs))
+	router.GET("/api/system/jobs/:jobID", wrapHandle(lgc, HandleGetSystemJob))
+	router.DELETE("/api/system/jobs/:jobID", wrapHandle(lgc, HandleCancelSystemJob))
+
+	router.GET("/api/system/deflector/:indexSetID", wrapHandle(lgc, HandleGetDeflector))
+	router.POST(
+		"/api/system/deflector/:indexSetID/cycle", wrapHandle(lgc, HandleCycleDeflector))
+
 	router.GET("/api/streams", wrapHandle(lgc, HandleGetStreams))
 	router.POST("/api/streams", wrapHandle(lgc, HandleCreateStream))
 	router.GET("/api/streams/:streamID", wrapHandle(lgc, HandleGetStream))
@@ -64,6 +140,17 @@ func NewRouter(lgc *logic.Logic) *httprouter.Router {
 
 	router.GET("/api/alerts/conditions", wrapHandle(lgc, HandleGetAlertConditions))
 
+	// the admin API of the mock server
+	router.GET("/_mock/snapshot", wrapMockHandle(lgc, HandleGetSnapshot))
+	router.PUT("/_mock/snapshot", wrapMockHandle(lgc, HandleRestoreSnapshot))
+	router.POST("/_mock/reset", wrapMockHandle(lgc, HandleReset))
+	router.GET("/_mock/faults", wrapMockHandle(lgc, HandleGetFaults))
+	router.POST("/_mock/faults", wrapMockHandle(lgc, HandleInjectFault))
+	router.DELETE("/_mock/faults", wrapMockHandle(lgc, HandleClearFaults))
+	router.DELETE("/_mock/faults/:faultID", wrapMockHandle(lgc, HandleRemoveFault))
+	router.GET("/_mock/requests", wrapMockHandle(lgc, HandleGetRequests))
+	router.DELETE("/_mock/requests", wrapMockHandle(lgc, HandleClearRequests))
+
 	router.NotFound = HandleNotFound(lgc)
 	return router
 }
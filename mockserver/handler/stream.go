@@ -50,11 +50,18 @@ func HandleCreateStream(
 	if sc, err := lgc.Authorize(user, "streams:create"); err != nil {
 		return nil, sc, err
 	}
+	required := set.NewStrSet("title")
+	optional := set.NewStrSet("rules", "description", "content_pack", "matching_type", "remove_matches_from_default_stream")
+	if lgc.Profile().StreamIndexSetRequired {
+		required.Add("index_set_id")
+	} else {
+		optional.Add("index_set_id")
+	}
 	// empty description is ignored
 	body, sc, err := validateRequestBody(
 		r.Body, &validateReqBodyPrms{
-			Required:     set.NewStrSet("title", "index_set_id"),
-			Optional:     set.NewStrSet("rules", "description", "content_pack", "matching_type", "remove_matches_from_default_stream"),
+			Required:     required,
+			Optional:     optional,
 			ExtForbidden: true,
 		})
 	if err != nil {
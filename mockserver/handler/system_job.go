@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+// HandleGetSystemJobs is the handler of Get System Jobs API.
+func HandleGetSystemJobs(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, _ httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/jobs List currently running jobs
+	if sc, err := lgc.Authorize(user, "systemjobs:read"); err != nil {
+		return nil, sc, err
+	}
+	jobs, sc, err := lgc.GetSystemJobs()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.SystemJobsBody{Jobs: jobs}, sc, nil
+}
+
+// HandleGetSystemJob is the handler of Get a System Job API.
+func HandleGetSystemJob(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// GET /system/jobs/{jobId} Get information of a specific currently running job
+	id := ps.ByName("jobID")
+	if sc, err := lgc.Authorize(user, "systemjobs:read", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.GetSystemJob(id)
+}
+
+// HandleCancelSystemJob is the handler of Cancel a System Job API.
+func HandleCancelSystemJob(
+	user *graylog.User, lgc *logic.Logic,
+	w http.ResponseWriter, r *http.Request, ps httprouter.Params,
+) (interface{}, int, error) {
+	// DELETE /system/jobs/{jobId} Cancel running job
+	id := ps.ByName("jobID")
+	if sc, err := lgc.Authorize(user, "systemjobs:delete", id); err != nil {
+		return nil, sc, err
+	}
+	return lgc.CancelSystemJob(id)
+}
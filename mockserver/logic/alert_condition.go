@@ -1,14 +1,54 @@
 package logic
 
 import (
+	"fmt"
+
 	"github.com/suzuki-shunsuke/go-graylog"
 )
 
 // GetAlertConditions returns a list of alert conditions.
+// The streams' alert conditions are included.
 func (lgc *Logic) GetAlertConditions() ([]graylog.AlertCondition, int, int, error) {
-	conds, total, err := lgc.store.GetAlertConditions()
+	conds, _, err := lgc.store.GetAlertConditions()
 	if err != nil {
 		return nil, 0, 500, err
 	}
-	return conds, total, 200, nil
+	streams, _, err := lgc.store.GetStreams()
+	if err != nil {
+		return nil, 0, 500, err
+	}
+	for _, stream := range streams {
+		conds = append(conds, stream.AlertConditions...)
+	}
+	return conds, len(conds), 200, nil
+}
+
+// validateAlertCondition validates an alert condition as Graylog does when it is created.
+// The type must be one of Graylog's built-in condition types,
+// and the parameters which the type requires must be set.
+func validateAlertCondition(cond *graylog.AlertCondition) (int, error) {
+	if cond.Title == "" {
+		return 400, fmt.Errorf("the alert condition's title is required")
+	}
+	prms := cond.Parameters
+	if prms == nil {
+		return 400, fmt.Errorf("the alert condition <%s>'s parameters are required", cond.Title)
+	}
+	switch cond.Type {
+	case "field_content_value":
+		if prms.Field == "" || prms.Value == "" {
+			return 400, fmt.Errorf(
+				"the alert condition <%s> requires the parameters field and value", cond.Title)
+		}
+	case "field_value":
+		if prms.Field == "" {
+			return 400, fmt.Errorf(
+				"the alert condition <%s> requires the parameter field", cond.Title)
+		}
+	case "message_count":
+	default:
+		return 400, fmt.Errorf(
+			"the alert condition <%s>'s type <%s> is unknown", cond.Title, cond.Type)
+	}
+	return 200, nil
 }
@@ -7,6 +7,11 @@ import (
 )
 
 // Authenticate authenticates a user.
+// A user who doesn't exist or is external is authenticated with the LDAP settings.
+// The LDAP authentication creates or updates the external user,
+// so then hold the lock with Lock and save the data afterwards.
+// Otherwise the data isn't changed and RLock is enough.
+// RequiresLDAPAuthentication returns which lock is needed.
 func (lgc *Logic) Authenticate(name, password string) (*graylog.User, int, error) {
 	if name == "" || password == "" {
 		return nil, 401, fmt.Errorf("authentication failure")
@@ -30,11 +35,24 @@ func (lgc *Logic) Authenticate(name, password string) (*graylog.User, int, error
 	if err != nil {
 		return nil, 500, err
 	}
-	if user == nil {
-		return nil, 401, fmt.Errorf("authentication failure")
+	if user == nil || user.External {
+		return lgc.authenticateLDAPUser(user, name, password)
 	}
 	if user.Password != encryptPassword(password) {
 		return nil, 401, fmt.Errorf("authentication failure")
 	}
 	return user, 200, nil
 }
+
+// RequiresLDAPAuthentication returns whether Authenticate authenticates a user with the LDAP settings,
+// which creates or updates the external user.
+func (lgc *Logic) RequiresLDAPAuthentication(name, password string) (bool, error) {
+	if name == "" || password == "" || password == "session" || password == "token" {
+		return false, nil
+	}
+	user, err := lgc.store.GetUser(name)
+	if err != nil {
+		return false, err
+	}
+	return user == nil || user.External, nil
+}
@@ -0,0 +1,116 @@
+package logic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+var urlWhitelistEntryTypes = map[string]bool{"literal": true, "regex": true}
+
+// DecodeClusterConfig parses a cluster configuration of a given class.
+// A cluster configuration of a class which has a typed struct must not have unknown fields.
+func DecodeClusterConfig(class string, b []byte) (graylog.ClusterConfig, error) {
+	cfg := graylog.NewClusterConfig(class)
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, ok := cfg.(*graylog.GenericClusterConfig); !ok {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the cluster config %s: %s", class, err)
+	}
+	return cfg, nil
+}
+
+// validateClusterConfig validates a cluster configuration of a class which has a typed struct.
+func validateClusterConfig(cfg graylog.ClusterConfig) error {
+	switch c := cfg.(type) {
+	case *graylog.SearchesClusterConfig:
+		if _, err := parseISO8601Duration(c.QueryTimeRangeLimit); err != nil {
+			return fmt.Errorf("invalid query_time_range_limit: %s", err)
+		}
+	case *graylog.UrlWhitelistConfig:
+		for _, entry := range c.Entries {
+			if entry.Value == "" {
+				return fmt.Errorf("the value of the url whitelist entry is required")
+			}
+			if !urlWhitelistEntryTypes[entry.Type] {
+				return fmt.Errorf(
+					`the type of the url whitelist entry must be "literal" or "regex": %s`,
+					entry.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// GetClusterConfig returns a cluster configuration.
+func (lgc *Logic) GetClusterConfig(class string) (graylog.ClusterConfig, int, error) {
+	if class == "" {
+		return nil, 400, fmt.Errorf("config class is empty")
+	}
+	m, err := lgc.store.GetClusterConfig(class)
+	if err != nil {
+		return nil, 500, err
+	}
+	if m == nil {
+		return nil, 404, fmt.Errorf("no cluster config of the class <%s> found", class)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, 500, err
+	}
+	cfg := graylog.NewClusterConfig(class)
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, 500, err
+	}
+	return cfg, 200, nil
+}
+
+// GetClusterConfigClasses returns the classes of all cluster configurations.
+func (lgc *Logic) GetClusterConfigClasses() ([]string, int, error) {
+	classes, err := lgc.store.GetClusterConfigClasses()
+	if err != nil {
+		return nil, 500, err
+	}
+	return classes, 200, nil
+}
+
+// UpdateClusterConfig creates or updates a cluster configuration.
+func (lgc *Logic) UpdateClusterConfig(cfg graylog.ClusterConfig) (int, error) {
+	if cfg == nil {
+		return 400, fmt.Errorf("cluster config is nil")
+	}
+	class := cfg.ConfigClass()
+	if class == "" {
+		return 400, fmt.Errorf("config class is empty")
+	}
+	if err := validateClusterConfig(cfg); err != nil {
+		return 400, err
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return 400, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return 400, fmt.Errorf("the cluster config must be a JSON object: %s", err)
+	}
+	if err := lgc.store.SetClusterConfig(class, m); err != nil {
+		return 500, err
+	}
+	return 202, nil
+}
+
+// DeleteClusterConfig deletes a cluster configuration.
+func (lgc *Logic) DeleteClusterConfig(class string) (int, error) {
+	if _, sc, err := lgc.GetClusterConfig(class); err != nil {
+		return sc, err
+	}
+	if err := lgc.store.DeleteClusterConfig(class); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
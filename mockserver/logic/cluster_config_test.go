@@ -0,0 +1,122 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+func TestDecodeClusterConfig(t *testing.T) {
+	cfg, err := logic.DecodeClusterConfig(
+		graylog.MessageProcessorsConfigClass,
+		[]byte(`{"processor_order": ["a", "b"], "disabled_processors": ["b"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp, ok := cfg.(*graylog.MessageProcessorsConfig)
+	if !ok {
+		t.Fatal("MessageProcessorsConfig should be returned")
+	}
+	if len(mp.ProcessorOrder) != 2 {
+		t.Fatalf("len(mp.ProcessorOrder) == %d, wanted 2", len(mp.ProcessorOrder))
+	}
+	if _, err := logic.DecodeClusterConfig(
+		graylog.MessageProcessorsConfigClass, []byte(`{"foo": "bar"}`)); err == nil {
+		t.Fatal("unknown fields should be rejected")
+	}
+	if _, err := logic.DecodeClusterConfig(
+		"org.graylog.plugins.foo.FooConfig", []byte(`{"foo": "bar"}`)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetClusterConfig(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, _, err := lgc.GetClusterConfig(graylog.SearchesClusterConfigClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cfg.(*graylog.SearchesClusterConfig); !ok {
+		t.Fatal("SearchesClusterConfig should be returned")
+	}
+	if _, sc, err := lgc.GetClusterConfig("foo"); err == nil {
+		t.Fatal("no cluster config of the class foo")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+	if _, sc, err := lgc.GetClusterConfig(""); err == nil {
+		t.Fatal("config class is required")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+}
+
+func TestGetClusterConfigClasses(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	classes, _, err := lgc.GetClusterConfigClasses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(classes) != 2 {
+		t.Fatalf("len(classes) == %d, wanted 2", len(classes))
+	}
+}
+
+func TestUpdateClusterConfig(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.UpdateClusterConfig(nil); err == nil {
+		t.Fatal("cluster config is nil")
+	}
+	if _, err := lgc.UpdateClusterConfig(&graylog.SearchesClusterConfig{
+		QueryTimeRangeLimit: "30 days"}); err == nil {
+		t.Fatal("query_time_range_limit should be an ISO 8601 period")
+	}
+	if _, err := lgc.UpdateClusterConfig(&graylog.UrlWhitelistConfig{
+		Entries: []graylog.UrlWhitelistEntry{{Value: "https://example.com", Type: "foo"}},
+	}); err == nil {
+		t.Fatal("the type of the url whitelist entry is invalid")
+	}
+	if _, err := lgc.UpdateClusterConfig(&graylog.UrlWhitelistConfig{
+		Entries: []graylog.UrlWhitelistEntry{{Value: "https://example.com", Type: "literal"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &graylog.GenericClusterConfig{
+		Class:  "org.graylog.plugins.foo.FooConfig",
+		Values: map[string]interface{}{"enabled": true}}
+	if _, err := lgc.UpdateClusterConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	c, _, err := lgc.GetClusterConfig(cfg.Class)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.(*graylog.GenericClusterConfig).Values["enabled"] != true {
+		t.Fatalf("the cluster config isn't updated: %+v", c)
+	}
+}
+
+func TestDeleteClusterConfig(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.DeleteClusterConfig(graylog.SearchesClusterConfigClass); err != nil {
+		t.Fatal(err)
+	}
+	if sc, err := lgc.DeleteClusterConfig(graylog.SearchesClusterConfigClass); err == nil {
+		t.Fatal("the cluster config is already deleted")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetDeflector returns an index set's deflector status.
+func (lgc *Logic) GetDeflector(indexSetID string) (*graylog.DeflectorSummary, int, error) {
+	is, sc, err := lgc.GetIndexSet(indexSetID)
+	if err != nil {
+		return nil, sc, err
+	}
+	active, err := lgc.getActiveIndex(is.ID)
+	if err != nil {
+		return nil, 500, err
+	}
+	if active == nil {
+		return &graylog.DeflectorSummary{}, 200, nil
+	}
+	return &graylog.DeflectorSummary{IsUp: true, CurrentTarget: active.Name}, 200, nil
+}
+
+// CycleDeflector points an index set's deflector to a new index regardless of the rotation strategy,
+// and cleans up old indices according to the retention strategy.
+// The id of the system job which calculates the old index's range is returned.
+func (lgc *Logic) CycleDeflector(indexSetID string) (string, int, error) {
+	is, sc, err := lgc.GetIndexSet(indexSetID)
+	if err != nil {
+		return "", sc, err
+	}
+	lgc.indexMutex.Lock()
+	defer lgc.indexMutex.Unlock()
+	active, err := lgc.getActiveIndex(is.ID)
+	if err != nil {
+		return "", 500, err
+	}
+	if active == nil {
+		if _, err := lgc.addIndex(is); err != nil {
+			return "", 500, err
+		}
+		return "", 204, nil
+	}
+	id, err := lgc.cycleIndexSet(is, active)
+	if err != nil {
+		return "", 500, err
+	}
+	if err := lgc.cleanupIndexSet(is); err != nil {
+		return "", 500, err
+	}
+	return id, 204, nil
+}
@@ -0,0 +1,74 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetDeflector(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	deflector, _, err := lgc.GetDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deflector.IsUp || deflector.CurrentTarget != "hoge_0" {
+		t.Fatalf(`deflector == %+v, wanted up and "hoge_0"`, deflector)
+	}
+	if _, sc, err := lgc.GetDeflector("h"); err == nil {
+		t.Fatal(`no index set whose id is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestCycleDeflector(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	is.RetentionStrategyClass = graylog.DeletionRetentionStrategy
+	is.RetentionStrategy = graylog.NewDeletionRetentionStrategy(2)
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		jobID, sc, err := lgc.CycleDeflector(is.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sc != 204 {
+			t.Fatalf("status code == %d, wanted 204", sc)
+		}
+		if jobID == "" {
+			t.Fatal("the system job id is empty")
+		}
+	}
+	deflector, _, err := lgc.GetDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deflector.CurrentTarget != "hoge_2" {
+		t.Fatalf(`deflector.CurrentTarget == "%s", wanted "hoge_2"`, deflector.CurrentTarget)
+	}
+	if _, sc, err := lgc.GetIndex("hoge_0"); err == nil {
+		t.Fatal("hoge_0 should be deleted by the retention")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+	if _, sc, err := lgc.CycleDeflector("h"); err == nil {
+		t.Fatal(`no index set whose id is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
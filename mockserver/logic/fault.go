@@ -0,0 +1,153 @@
+package logic
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+// Fault is a rule to inject a fault into the Graylog API's responses.
+// This is useful to test how clients behave when Graylog is slow or flaky.
+// A fault matches requests by the method and the path pattern,
+// and the first matched fault is injected.
+//
+//   // the 3rd request to get a stream fails with 503
+//   lgc.InjectFault(&logic.Fault{
+//     Method: "GET", Path: "/api/streams/*", StatusCode: 503, Nth: 3})
+//   // all requests take 100 ~ 500 milliseconds
+//   lgc.InjectFault(&logic.Fault{Latency: 100, MaxLatency: 500})
+type Fault struct {
+	ID string `json:"id"`
+	// the HTTP method. If Method is empty, all methods match.
+	Method string `json:"method,omitempty"`
+	// the pattern of the path such as "/api/streams/*". The syntax is same as path.Match .
+	// If Path is empty, all paths match.
+	Path string `json:"path,omitempty"`
+	// the latency in milliseconds.
+	// If MaxLatency is greater than Latency, the latency is random between them.
+	Latency    int `json:"latency,omitempty"`
+	MaxLatency int `json:"max_latency,omitempty"`
+	// If StatusCode isn't 0, the response's status code and body are replaced.
+	StatusCode int    `json:"status_code,omitempty"`
+	Body       string `json:"body,omitempty"`
+	// If ConnectionReset is true, the connection is reset without any response.
+	ConnectionReset bool `json:"connection_reset,omitempty"`
+	// If Nth isn't 0, the fault is injected only into the Nth matched request.
+	Nth int `json:"nth,omitempty"`
+	// the number of the matched requests. This is set by the mock server.
+	Count int `json:"count"`
+}
+
+// Delay returns the latency which is injected.
+func (fault *Fault) Delay() time.Duration {
+	latency := fault.Latency
+	if fault.MaxLatency > fault.Latency {
+		latency += rand.Intn(fault.MaxLatency - fault.Latency + 1)
+	}
+	return time.Duration(latency) * time.Millisecond
+}
+
+// match returns whether the fault matches the request.
+func (fault *Fault) match(method, urlPath string) bool {
+	if fault.Method != "" && !strings.EqualFold(fault.Method, method) {
+		return false
+	}
+	if fault.Path == "" {
+		return true
+	}
+	ok, err := path.Match(fault.Path, urlPath)
+	return err == nil && ok
+}
+
+// validateFault validates a fault.
+func validateFault(fault *Fault) error {
+	if fault.Path != "" {
+		if _, err := path.Match(fault.Path, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %s: %s", fault.Path, err)
+		}
+	}
+	if fault.Latency < 0 || fault.MaxLatency < 0 {
+		return fmt.Errorf("latency must not be negative")
+	}
+	if fault.StatusCode != 0 && (fault.StatusCode < 100 || fault.StatusCode > 599) {
+		return fmt.Errorf("invalid status code %d", fault.StatusCode)
+	}
+	if fault.Nth < 0 {
+		return fmt.Errorf("nth must not be negative")
+	}
+	if fault.Latency == 0 && fault.MaxLatency == 0 && fault.StatusCode == 0 && !fault.ConnectionReset {
+		return fmt.Errorf("either latency, max_latency, status_code or connection_reset is required")
+	}
+	return nil
+}
+
+// InjectFault adds a fault.
+// The fault's id is set.
+func (lgc *Logic) InjectFault(fault *Fault) (int, error) {
+	if fault == nil {
+		return 400, fmt.Errorf("fault is nil")
+	}
+	if err := validateFault(fault); err != nil {
+		return 400, err
+	}
+	fault.ID = store.NewObjectID()
+	fault.Count = 0
+	lgc.faultsMutex.Lock()
+	defer lgc.faultsMutex.Unlock()
+	lgc.faults = append(lgc.faults, *fault)
+	return 200, nil
+}
+
+// GetFaults returns the injected faults.
+func (lgc *Logic) GetFaults() []Fault {
+	lgc.faultsMutex.Lock()
+	defer lgc.faultsMutex.Unlock()
+	faults := make([]Fault, len(lgc.faults))
+	copy(faults, lgc.faults)
+	return faults
+}
+
+// RemoveFault removes a fault.
+func (lgc *Logic) RemoveFault(id string) (int, error) {
+	lgc.faultsMutex.Lock()
+	defer lgc.faultsMutex.Unlock()
+	for i, fault := range lgc.faults {
+		if fault.ID == id {
+			lgc.faults = append(lgc.faults[:i], lgc.faults[i+1:]...)
+			return 200, nil
+		}
+	}
+	return 404, fmt.Errorf("no fault with id <%s> is found", id)
+}
+
+// ClearFaults removes all faults.
+func (lgc *Logic) ClearFaults() {
+	lgc.faultsMutex.Lock()
+	defer lgc.faultsMutex.Unlock()
+	lgc.faults = nil
+}
+
+// MatchFault returns the fault which should be injected into the request.
+// If no fault should be injected, nil is returned.
+// The matched faults' counts are incremented.
+func (lgc *Logic) MatchFault(method, urlPath string) *Fault {
+	lgc.faultsMutex.Lock()
+	defer lgc.faultsMutex.Unlock()
+	for i := range lgc.faults {
+		fault := &lgc.faults[i]
+		if !fault.match(method, urlPath) {
+			continue
+		}
+		fault.Count++
+		if fault.Nth != 0 && fault.Count != fault.Nth {
+			continue
+		}
+		f := *fault
+		return &f
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+func TestInjectFault(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.InjectFault(&logic.Fault{Path: "/api/streams"}); err == nil {
+		t.Fatal("a fault without any effect should be error")
+	}
+	if _, err := lgc.InjectFault(&logic.Fault{Path: "[", StatusCode: 500}); err == nil {
+		t.Fatal("an invalid path pattern should be error")
+	}
+	fault := &logic.Fault{Method: "get", Path: "/api/streams/*", StatusCode: 503, Nth: 2}
+	if _, err := lgc.InjectFault(fault); err != nil {
+		t.Fatal(err)
+	}
+	if fault.ID == "" {
+		t.Fatal("fault id should be set")
+	}
+	if f := lgc.MatchFault("POST", "/api/streams/foo"); f != nil {
+		t.Fatalf("the method doesn't match: %+v", f)
+	}
+	if f := lgc.MatchFault("GET", "/api/streams/foo"); f != nil {
+		t.Fatalf("the fault should be injected only into the 2nd request: %+v", f)
+	}
+	f := lgc.MatchFault("GET", "/api/streams/foo")
+	if f == nil || f.StatusCode != 503 {
+		t.Fatalf("fault = %+v, wanted the 2nd request's fault", f)
+	}
+	if f := lgc.MatchFault("GET", "/api/streams/foo"); f != nil {
+		t.Fatalf("the fault should be injected only into the 2nd request: %+v", f)
+	}
+	if faults := lgc.GetFaults(); len(faults) != 1 || faults[0].Count != 3 {
+		t.Fatalf("faults = %+v", faults)
+	}
+	if _, err := lgc.RemoveFault(fault.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.RemoveFault(fault.ID); err == nil {
+		t.Fatal("the removed fault should not be found")
+	}
+
+	if _, err := lgc.InjectFault(&logic.Fault{Latency: 10, MaxLatency: 20}); err != nil {
+		t.Fatal(err)
+	}
+	f = lgc.MatchFault("DELETE", "/api/roles/foo")
+	if f == nil {
+		t.Fatal("the fault without method and path should match all requests")
+	}
+	if d := f.Delay(); d < 10*time.Millisecond || d > 20*time.Millisecond {
+		t.Fatalf("delay = %s, wanted 10ms ~ 20ms", d)
+	}
+	lgc.ClearFaults()
+	if faults := lgc.GetFaults(); len(faults) != 0 {
+		t.Fatalf("faults = %+v, wanted empty", faults)
+	}
+}
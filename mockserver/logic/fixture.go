@@ -0,0 +1,170 @@
+package logic
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/seed"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// Option is an option of NewLogic.
+type Option func(lgc *Logic) error
+
+// WithSeed loads the seed data from a YAML or JSON fixture file.
+// See seed.Fixture about the format of the file.
+func WithSeed(path string) Option {
+	return func(lgc *Logic) error {
+		fixture, err := seed.LoadFixture(path)
+		if err != nil {
+			return err
+		}
+		lgc.fixture = fixture
+		return nil
+	}
+}
+
+// WithFixture sets the seed data.
+// The seed data is added to the initial data when the store is initialized and reset.
+func WithFixture(fixture *seed.Fixture) Option {
+	return func(lgc *Logic) error {
+		lgc.fixture = fixture
+		return nil
+	}
+}
+
+// loadFixture adds the seed data to the store.
+// The references by name are resolved to ObjectIDs.
+func (lgc *Logic) loadFixture(fixture *seed.Fixture) error {
+	for _, r := range fixture.Roles {
+		role := r
+		if _, err := lgc.AddRole(&role); err != nil {
+			return fmt.Errorf(`failed to add the role "%s": %s`, role.Name, err)
+		}
+	}
+	for _, u := range fixture.Users {
+		user := u
+		if user.Permissions == nil {
+			user.Permissions = set.NewStrSet()
+		}
+		if _, err := lgc.AddUser(&user); err != nil {
+			return fmt.Errorf(`failed to add the user "%s": %s`, user.Username, err)
+		}
+	}
+	indexSetIDs, err := lgc.loadFixtureIndexSets(fixture.IndexSets)
+	if err != nil {
+		return err
+	}
+	for _, in := range fixture.Inputs {
+		input := in
+		if _, err := lgc.AddInput(&input); err != nil {
+			return fmt.Errorf(`failed to add the input "%s": %s`, input.Title, err)
+		}
+	}
+	for _, stream := range fixture.Streams {
+		if err := lgc.loadFixtureStream(stream, indexSetIDs); err != nil {
+			return fmt.Errorf(`failed to add the stream "%s": %s`, stream.Title, err)
+		}
+	}
+	return nil
+}
+
+// loadFixtureIndexSets adds the index sets and returns the map of titles to ids.
+// The existing index sets such as the default index set can be also referred by title.
+func (lgc *Logic) loadFixtureIndexSets(indexSets []seed.FixtureIndexSet) (map[string]string, error) {
+	ids := map[string]string{}
+	iss, _, err := lgc.store.GetIndexSets(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, is := range iss {
+		ids[is.Title] = is.ID
+	}
+	def := seed.IndexSet()
+	for _, fis := range indexSets {
+		is := fis.IndexSet
+		if _, ok := ids[is.Title]; ok {
+			return nil, fmt.Errorf(`the index set title "%s" is duplicated`, is.Title)
+		}
+		if is.RotationStrategyClass == "" {
+			is.RotationStrategyClass = def.RotationStrategyClass
+			is.RotationStrategy = def.RotationStrategy
+		}
+		if is.RetentionStrategyClass == "" {
+			is.RetentionStrategyClass = def.RetentionStrategyClass
+			is.RetentionStrategy = def.RetentionStrategy
+		}
+		if is.IndexOptimizationMaxNumSegments == 0 {
+			is.IndexOptimizationMaxNumSegments = def.IndexOptimizationMaxNumSegments
+		}
+		is.Writable = fis.Writable == nil || *fis.Writable
+		isDefault := is.Default
+		if _, err := lgc.AddIndexSet(&is); err != nil {
+			return nil, fmt.Errorf(`failed to add the index set "%s": %s`, is.Title, err)
+		}
+		if isDefault {
+			if _, _, err := lgc.SetDefaultIndexSet(is.ID); err != nil {
+				return nil, fmt.Errorf(
+					`failed to set the index set "%s" as default: %s`, is.Title, err)
+			}
+		}
+		ids[is.Title] = is.ID
+	}
+	return ids, nil
+}
+
+// loadFixtureStream adds a stream with its stream rules and alert conditions.
+func (lgc *Logic) loadFixtureStream(fs seed.FixtureStream, indexSetIDs map[string]string) error {
+	stream := fs.Stream
+	if fs.IndexSet == "" {
+		id, err := lgc.store.GetDefaultIndexSetID()
+		if err != nil {
+			return err
+		}
+		stream.IndexSetID = id
+	} else {
+		id, ok := indexSetIDs[fs.IndexSet]
+		if !ok {
+			return fmt.Errorf(`no index set with title "%s" is found`, fs.IndexSet)
+		}
+		stream.IndexSetID = id
+	}
+	rules := stream.Rules
+	conds := stream.AlertConditions
+	stream.Rules = []graylog.StreamRule{}
+	stream.AlertConditions = nil
+	if _, err := lgc.AddStream(&stream); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		rule := r
+		rule.StreamID = stream.ID
+		if _, err := lgc.AddStreamRule(&rule); err != nil {
+			return err
+		}
+	}
+	if len(conds) == 0 {
+		return nil
+	}
+	for i, cond := range conds {
+		if _, err := validateAlertCondition(&cond); err != nil {
+			return fmt.Errorf(
+				`failed to add the alert condition "%s" of the stream "%s": %s`,
+				cond.Title, stream.Title, err)
+		}
+		if cond.ID == "" {
+			cond.ID = store.NewObjectID()
+		}
+		if cond.CreatedAt == "" {
+			cond.CreatedAt = lgc.now().UTC().Format(graylog.CreationDateFormat)
+		}
+		if cond.CreatorUserID == "" {
+			cond.CreatorUserID = seed.User().Username
+		}
+		conds[i] = cond
+	}
+	_, err := lgc.store.UpdateStream(&graylog.StreamUpdateParams{
+		ID: stream.ID, AlertConditions: conds})
+	return err
+}
@@ -0,0 +1,154 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/seed"
+)
+
+const fixtureYAML = `
+roles:
+- name: Reader
+  permissions: ["streams:read"]
+users:
+- username: foo
+  password: password
+  email: foo@example.com
+  full_name: foo
+  roles: [Reader]
+index_sets:
+- title: app logs
+  index_prefix: app
+  default: true
+- title: audit logs
+  index_prefix: audit
+  writable: false
+streams:
+- title: errors
+  index_set: app logs
+  rules:
+  - field: level
+    type: 1
+    value: error
+  alert_conditions:
+  - type: field_content_value
+    title: error
+    parameters:
+      field: level
+      value: error
+- title: default
+`
+
+func TestWithFixture(t *testing.T) {
+	fixture, err := seed.ParseFixture([]byte(fixtureYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lgc, err := logic.NewLogic(nil, logic.WithFixture(fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _, err := lgc.GetUser("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !user.Roles.HasAny("Reader") {
+		t.Fatalf("user roles = %v, wanted Reader", user.Roles.ToList())
+	}
+	iss, _, _, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := map[string]string{}
+	for _, is := range iss {
+		ids[is.Title] = is.ID
+		if is.Title == "audit logs" && is.Writable {
+			t.Fatal("the index set audit logs should not be writable")
+		}
+	}
+	if ids["app logs"] == "" || ids["audit logs"] == "" {
+		t.Fatalf("index sets = %+v", iss)
+	}
+	is, _, err := lgc.GetIndexSet(ids["app logs"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !is.Default {
+		t.Fatal("the index set app logs should be default")
+	}
+	streams, _, _, err := lgc.GetStreams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, stream := range streams {
+		if stream.Title != "errors" {
+			continue
+		}
+		found = true
+		if stream.IndexSetID != ids["app logs"] {
+			t.Fatalf("index set id = %s, wanted %s", stream.IndexSetID, ids["app logs"])
+		}
+		rules, _, _, err := lgc.GetStreamRules(stream.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rules) != 1 || rules[0].Field != "level" {
+			t.Fatalf("stream rules = %+v", rules)
+		}
+	}
+	if !found {
+		t.Fatal("the stream errors is not found")
+	}
+	conds, _, _, err := lgc.GetAlertConditions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conds) != 1 || conds[0].ID == "" || conds[0].Title != "error" {
+		t.Fatalf("alert conditions = %+v", conds)
+	}
+
+	// the fixture is loaded again at reset
+	if _, err := lgc.DeleteUser("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.GetUser("foo"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithFixtureError(t *testing.T) {
+	fixture, err := seed.ParseFixture([]byte(`
+streams:
+- title: errors
+  index_set: unknown
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logic.NewLogic(nil, logic.WithFixture(fixture)); err == nil {
+		t.Fatal("an unknown index set should be error")
+	}
+	fixture, err = seed.ParseFixture([]byte(`
+streams:
+- title: errors
+  alert_conditions:
+  - type: field_content_value
+    title: error
+    parameters:
+      field: level
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logic.NewLogic(nil, logic.WithFixture(fixture)); err == nil {
+		t.Fatal("an alert condition without the required parameter should be error")
+	}
+	if _, err := logic.NewLogic(nil, logic.WithSeed("nonexistent.yaml")); err == nil {
+		t.Fatal("nonexistent fixture file should be error")
+	}
+}
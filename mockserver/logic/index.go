@@ -0,0 +1,245 @@
+package logic
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+// addIndex creates a new index of an index set and returns it.
+// The new index's number is the next of the index set's last index,
+// so the new index becomes the index set's active write index.
+func (lgc *Logic) addIndex(is *graylog.IndexSet) (*store.Index, error) {
+	indices, err := lgc.store.GetIndices(is.ID)
+	if err != nil {
+		return nil, err
+	}
+	num := 0
+	if len(indices) != 0 {
+		num = indices[len(indices)-1].Number + 1
+	}
+	now := lgc.now()
+	index := &store.Index{
+		Name:       fmt.Sprintf("%s_%d", is.IndexPrefix, num),
+		IndexSetID: is.ID,
+		Number:     num,
+		CreatedAt:  now,
+	}
+	if err := lgc.store.AddIndex(index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// getActiveIndex returns an index set's active write index, which the deflector points to.
+// If the index set has no open index, nil is returned.
+func (lgc *Logic) getActiveIndex(indexSetID string) (*store.Index, error) {
+	indices, err := lgc.store.GetIndices(indexSetID)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(indices) - 1; i >= 0; i-- {
+		if !indices[i].Closed {
+			return &indices[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// newIndexInfo converts a mock index to an IndexInfo.
+func newIndexInfo(index *store.Index, is *graylog.IndexSet) *graylog.IndexInfo {
+	primary := &graylog.IndexStats{
+		Documents:      graylog.IndexDocuments{Count: index.Documents},
+		StoreSizeBytes: index.Size,
+		Segments:       is.Shards,
+	}
+	all := &graylog.IndexStats{
+		Documents:      graylog.IndexDocuments{Count: index.Documents * (1 + is.Replicas)},
+		StoreSizeBytes: index.Size * (1 + is.Replicas),
+		Segments:       is.Shards * (1 + is.Replicas),
+	}
+	routing := make([]graylog.ShardRouting, is.Shards)
+	for i := 0; i < is.Shards; i++ {
+		routing[i] = graylog.ShardRouting{
+			ID: i, State: "STARTED", Active: true, Primary: true}
+	}
+	return &graylog.IndexInfo{
+		IndexName:     index.Name,
+		PrimaryShards: primary,
+		AllShards:     all,
+		Routing:       routing,
+		IsReopened:    index.Reopened,
+	}
+}
+
+// GetIndices returns an index set's all indices.
+func (lgc *Logic) GetIndices(indexSetID string) (*graylog.AllIndices, int, error) {
+	is, sc, err := lgc.GetIndexSet(indexSetID)
+	if err != nil {
+		return nil, sc, err
+	}
+	indices, err := lgc.store.GetIndices(indexSetID)
+	if err != nil {
+		return nil, 500, err
+	}
+	all := &graylog.AllIndices{
+		All:      graylog.OpenIndicesInfo{Indices: map[string]graylog.IndexInfo{}},
+		Closed:   graylog.ClosedIndices{Indices: []string{}},
+		Reopened: graylog.ClosedIndices{Indices: []string{}},
+	}
+	for i, index := range indices {
+		if index.Closed {
+			all.Closed.Indices = append(all.Closed.Indices, index.Name)
+			continue
+		}
+		all.All.Indices[index.Name] = *(newIndexInfo(&indices[i], is))
+		if index.Reopened {
+			all.Reopened.Indices = append(all.Reopened.Indices, index.Name)
+		}
+	}
+	all.Closed.Total = len(all.Closed.Indices)
+	all.Reopened.Total = len(all.Reopened.Indices)
+	return all, 200, nil
+}
+
+// GetOpenIndices returns an index set's open indices.
+func (lgc *Logic) GetOpenIndices(indexSetID string) (*graylog.OpenIndicesInfo, int, error) {
+	all, sc, err := lgc.GetIndices(indexSetID)
+	if err != nil {
+		return nil, sc, err
+	}
+	return &all.All, sc, nil
+}
+
+// GetClosedIndices returns an index set's closed indices.
+func (lgc *Logic) GetClosedIndices(indexSetID string) (*graylog.ClosedIndices, int, error) {
+	all, sc, err := lgc.GetIndices(indexSetID)
+	if err != nil {
+		return nil, sc, err
+	}
+	return &all.Closed, sc, nil
+}
+
+// GetReopenedIndices returns an index set's reopened indices.
+func (lgc *Logic) GetReopenedIndices(indexSetID string) (*graylog.ClosedIndices, int, error) {
+	all, sc, err := lgc.GetIndices(indexSetID)
+	if err != nil {
+		return nil, sc, err
+	}
+	return &all.Reopened, sc, nil
+}
+
+// getIndex returns an index and the index set which the index belongs to.
+func (lgc *Logic) getIndex(name string) (*store.Index, *graylog.IndexSet, int, error) {
+	if name == "" {
+		return nil, nil, 400, fmt.Errorf("index name is empty")
+	}
+	index, err := lgc.store.GetIndex(name)
+	if err != nil {
+		return nil, nil, 500, err
+	}
+	if index == nil {
+		return nil, nil, 404, fmt.Errorf("index <%s> not found", name)
+	}
+	is, err := lgc.store.GetIndexSet(index.IndexSetID)
+	if err != nil {
+		return nil, nil, 500, err
+	}
+	if is == nil {
+		return nil, nil, 404, fmt.Errorf(
+			"the index set <%s> of the index <%s> is not found",
+			index.IndexSetID, name)
+	}
+	return index, is, 200, nil
+}
+
+// GetIndex returns an open index's information.
+func (lgc *Logic) GetIndex(name string) (*graylog.IndexInfo, int, error) {
+	index, is, sc, err := lgc.getIndex(name)
+	if err != nil {
+		return nil, sc, err
+	}
+	if index.Closed {
+		return nil, 404, fmt.Errorf("index <%s> is closed", name)
+	}
+	return newIndexInfo(index, is), 200, nil
+}
+
+// checkNotActiveIndex returns an error if a given index is its index set's active write index.
+func (lgc *Logic) checkNotActiveIndex(index *store.Index, action string) (int, error) {
+	active, err := lgc.getActiveIndex(index.IndexSetID)
+	if err != nil {
+		return 500, err
+	}
+	if active != nil && active.Name == index.Name {
+		return 403, fmt.Errorf(
+			"the current deflector target <%s> can't be %s", index.Name, action)
+	}
+	return 200, nil
+}
+
+// CloseIndex closes an index.
+// The index set's active write index can't be closed.
+func (lgc *Logic) CloseIndex(name string) (int, error) {
+	index, _, sc, err := lgc.getIndex(name)
+	if err != nil {
+		return sc, err
+	}
+	if sc, err := lgc.checkNotActiveIndex(index, "closed"); err != nil {
+		return sc, err
+	}
+	index.Closed = true
+	index.Reopened = false
+	if err := lgc.store.UpdateIndex(index); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// ReopenIndex reopens a closed index.
+func (lgc *Logic) ReopenIndex(name string) (int, error) {
+	index, _, sc, err := lgc.getIndex(name)
+	if err != nil {
+		return sc, err
+	}
+	if !index.Closed {
+		return 204, nil
+	}
+	index.Closed = false
+	index.Reopened = true
+	if err := lgc.store.UpdateIndex(index); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// DeleteIndex deletes an index.
+// The index set's active write index can't be deleted.
+func (lgc *Logic) DeleteIndex(name string) (int, error) {
+	index, _, sc, err := lgc.getIndex(name)
+	if err != nil {
+		return sc, err
+	}
+	if sc, err := lgc.checkNotActiveIndex(index, "deleted"); err != nil {
+		return sc, err
+	}
+	if err := lgc.store.DeleteIndex(name); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// deleteIndices deletes all indices of an index set.
+func (lgc *Logic) deleteIndices(indexSetID string) error {
+	indices, err := lgc.store.GetIndices(indexSetID)
+	if err != nil {
+		return err
+	}
+	for _, index := range indices {
+		if err := lgc.store.DeleteIndex(index.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package logic
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+// GetIndexRange returns an index's time range.
+func (lgc *Logic) GetIndexRange(name string) (*graylog.IndexRange, int, error) {
+	index, _, sc, err := lgc.getIndex(name)
+	if err != nil {
+		return nil, sc, err
+	}
+	return newIndexRange(index), 200, nil
+}
+
+// newIndexRange converts a mock index to an IndexRange.
+// The time range of an empty index is the epoch.
+func newIndexRange(index *store.Index) *graylog.IndexRange {
+	calculatedAt := index.RangeCalculatedAt
+	if calculatedAt.IsZero() {
+		calculatedAt = index.CreatedAt
+	}
+	return &graylog.IndexRange{
+		IndexName:    index.Name,
+		Begin:        formatIndexRangeTime(index.Begin),
+		End:          formatIndexRangeTime(index.End),
+		CalculatedAt: calculatedAt.UTC().Format(graylog.CreationDateFormat),
+	}
+}
+
+func formatIndexRangeTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(graylog.CreationDateFormat)
+}
+
+// calculateIndexRange calculates an index's range.
+func (lgc *Logic) calculateIndexRange(name string) error {
+	index, err := lgc.store.GetIndex(name)
+	if err != nil {
+		return err
+	}
+	if index == nil {
+		return fmt.Errorf("index <%s> not found", name)
+	}
+	index.RangeCalculatedAt = lgc.now()
+	return lgc.store.UpdateIndex(index)
+}
+
+// GetIndexRanges returns all open indices' ranges.
+func (lgc *Logic) GetIndexRanges() ([]graylog.IndexRange, int, error) {
+	iss, _, sc, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		return nil, sc, err
+	}
+	ranges := []graylog.IndexRange{}
+	for _, is := range iss {
+		indices, err := lgc.store.GetIndices(is.ID)
+		if err != nil {
+			return nil, 500, err
+		}
+		for i, index := range indices {
+			if !index.Closed {
+				ranges = append(ranges, *(newIndexRange(&indices[i])))
+			}
+		}
+	}
+	return ranges, 200, nil
+}
+
+// rebuildIndexRanges recalculates given index sets' open indices' ranges.
+func (lgc *Logic) rebuildIndexRanges(iss []graylog.IndexSet) (string, int, error) {
+	id, err := lgc.runSystemJob(
+		rebuildIndexRangesJobName, "Rebuilds index range information.", true,
+		func() error {
+			for _, is := range iss {
+				indices, err := lgc.store.GetIndices(is.ID)
+				if err != nil {
+					return err
+				}
+				for _, index := range indices {
+					if index.Closed {
+						continue
+					}
+					if err := lgc.calculateIndexRange(index.Name); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return "", 500, err
+	}
+	return id, 202, nil
+}
+
+// RebuildIndexRanges recalculates all open indices' ranges as a system job.
+// The system job's id is returned.
+func (lgc *Logic) RebuildIndexRanges() (string, int, error) {
+	iss, _, sc, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		return "", sc, err
+	}
+	return lgc.rebuildIndexRanges(iss)
+}
+
+// RebuildIndexSetIndexRanges recalculates an index set's open indices' ranges as a system job.
+// The system job's id is returned.
+func (lgc *Logic) RebuildIndexSetIndexRanges(indexSetID string) (string, int, error) {
+	is, sc, err := lgc.GetIndexSet(indexSetID)
+	if err != nil {
+		return "", sc, err
+	}
+	return lgc.rebuildIndexRanges([]graylog.IndexSet{*is})
+}
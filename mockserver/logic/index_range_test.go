@@ -0,0 +1,85 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetIndexRanges(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.CycleDeflector(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if sc, err := lgc.CloseIndex("hoge_0"); err != nil {
+		t.Fatalf("status code: %d, %v", sc, err)
+	}
+	ranges, _, err := lgc.GetIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range ranges {
+		if r.IndexName == "hoge_0" {
+			t.Fatal("the closed index's range should not be returned")
+		}
+	}
+}
+
+func TestRebuildIndexRanges(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	lgc.SetClock(func() time.Time { return now })
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Hour)
+	jobID, sc, err := lgc.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc != 202 {
+		t.Fatalf("status code == %d, wanted 202", sc)
+	}
+	if jobID == "" {
+		t.Fatal("the system job id is empty")
+	}
+	r, _, err := lgc.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.CalculatedAt != "2018-01-01T01:00:00.000Z" {
+		t.Fatalf(`r.CalculatedAt == "%s", wanted "2018-01-01T01:00:00.000Z"`, r.CalculatedAt)
+	}
+}
+
+func TestRebuildIndexSetIndexRanges(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.RebuildIndexSetIndexRanges(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, sc, err := lgc.RebuildIndexSetIndexRanges("h"); err == nil {
+		t.Fatal(`no index set whose id is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
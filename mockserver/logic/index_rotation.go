@@ -0,0 +1,235 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+var iso8601DurationRegexp = regexp.MustCompile(
+	`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// SetIndexSimulation sets whether the index rotation and retention are simulated.
+// If the simulation is enabled, messages ingested by IngestMessage are written to
+// the default index set's active index, and the index set is rotated and cleaned up
+// according to its rotation and retention strategies.
+// By default the simulation is disabled.
+//
+//   lgc.SetIndexSimulation(true)
+func (lgc *Logic) SetIndexSimulation(enabled bool) {
+	lgc.indexSimulation = enabled
+}
+
+// IndexSimulation returns whether the index rotation and retention are simulated.
+func (lgc *Logic) IndexSimulation() bool {
+	return lgc.indexSimulation
+}
+
+// IndexMessage writes a message to a given index set's active index
+// and rotates the index set and cleans up old indices if needed.
+// Unlike IngestMessage, this works even if the index simulation is disabled.
+func (lgc *Logic) IndexMessage(indexSetID string, msg map[string]interface{}) (int, error) {
+	if msg == nil {
+		return 400, fmt.Errorf("message is nil")
+	}
+	is, sc, err := lgc.GetIndexSet(indexSetID)
+	if err != nil {
+		return sc, err
+	}
+	lgc.indexMutex.Lock()
+	defer lgc.indexMutex.Unlock()
+	// the periodical rotation may have been run since the last message was written
+	if err := lgc.maintainIndexSet(is); err != nil {
+		return 500, err
+	}
+	index, err := lgc.getActiveIndex(is.ID)
+	if err != nil {
+		return 500, err
+	}
+	if index == nil {
+		if index, err = lgc.addIndex(is); err != nil {
+			return 500, err
+		}
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return 400, err
+	}
+	ts := lgc.now()
+	if index.Documents == 0 || ts.Before(index.Begin) {
+		index.Begin = ts
+	}
+	if index.Documents == 0 || ts.After(index.End) {
+		index.End = ts
+	}
+	index.Documents++
+	index.Size += len(b)
+	if err := lgc.store.UpdateIndex(index); err != nil {
+		return 500, err
+	}
+	if err := lgc.maintainIndexSet(is); err != nil {
+		return 500, err
+	}
+	return 200, nil
+}
+
+// RunIndexMaintenance rotates all index sets and cleans up old indices
+// according to their rotation and retention strategies,
+// as Graylog does periodically.
+// Finished system jobs are run beforehand.
+// Call this after advancing the clock to simulate the time based rotation.
+func (lgc *Logic) RunIndexMaintenance() (int, error) {
+	lgc.RunSystemJobs()
+	iss, _, sc, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		return sc, err
+	}
+	lgc.indexMutex.Lock()
+	defer lgc.indexMutex.Unlock()
+	for i := range iss {
+		if err := lgc.maintainIndexSet(&iss[i]); err != nil {
+			return 500, err
+		}
+	}
+	return 200, nil
+}
+
+// maintainIndexSet rotates an index set and cleans up old indices if needed.
+func (lgc *Logic) maintainIndexSet(is *graylog.IndexSet) error {
+	rotated, err := lgc.rotateIndexSet(is)
+	if err != nil {
+		return err
+	}
+	if !rotated {
+		return nil
+	}
+	return lgc.cleanupIndexSet(is)
+}
+
+// rotateIndexSet creates a new active index if the current active index should be rotated.
+func (lgc *Logic) rotateIndexSet(is *graylog.IndexSet) (bool, error) {
+	active, err := lgc.getActiveIndex(is.ID)
+	if err != nil {
+		return false, err
+	}
+	if active == nil {
+		_, err := lgc.addIndex(is)
+		return err == nil, err
+	}
+	if !lgc.shouldRotate(is, active) {
+		return false, nil
+	}
+	if _, err := lgc.cycleIndexSet(is, active); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// cycleIndexSet points the deflector to a new index
+// and calculates the old index's range as a system job.
+// The system job's id is returned.
+func (lgc *Logic) cycleIndexSet(is *graylog.IndexSet, active *store.Index) (string, error) {
+	if _, err := lgc.addIndex(is); err != nil {
+		return "", err
+	}
+	return lgc.runSystemJob(
+		setIndexReadOnlyJobName,
+		fmt.Sprintf("Makes index %s read only and calculates and adds its index range afterwards.", active.Name),
+		false, func() error {
+			return lgc.calculateIndexRange(active.Name)
+		})
+}
+
+// shouldRotate returns whether the active index should be rotated according to the rotation strategy.
+func (lgc *Logic) shouldRotate(is *graylog.IndexSet, active *store.Index) bool {
+	strategy := is.RotationStrategy
+	if strategy == nil {
+		return false
+	}
+	switch is.RotationStrategyClass {
+	case graylog.MessageCountRotationStrategy:
+		return strategy.MaxDocsPerIndex > 0 && active.Documents > strategy.MaxDocsPerIndex
+	case graylog.SizeBasedRotationStrategy:
+		return strategy.MaxSize > 0 && active.Size > strategy.MaxSize
+	case graylog.TimeBasedRotationStrategy:
+		d, err := parseISO8601Duration(strategy.RotationPeriod)
+		if err != nil {
+			lgc.Logger().WithFields(log.Fields{
+				"error": err, "index_set_id": is.ID,
+				"rotation_period": strategy.RotationPeriod,
+			}).Warn("invalid rotation period")
+			return false
+		}
+		return d > 0 && lgc.now().Sub(active.CreatedAt) >= d
+	}
+	return false
+}
+
+// cleanupIndexSet deletes or closes old indices according to the retention strategy.
+// As Graylog does, reopened indices and the active index aren't cleaned up.
+func (lgc *Logic) cleanupIndexSet(is *graylog.IndexSet) error {
+	strategy := is.RetentionStrategy
+	if strategy == nil || strategy.MaxNumberOfIndices <= 0 {
+		return nil
+	}
+	if is.RetentionStrategyClass != graylog.DeletionRetentionStrategy &&
+		is.RetentionStrategyClass != graylog.ClosingRetentionStrategy {
+		return nil
+	}
+	indices, err := lgc.store.GetIndices(is.ID)
+	if err != nil {
+		return err
+	}
+	// indices are sorted by the number, so the last one is the active index
+	targets := []store.Index{}
+	for _, index := range indices {
+		if !index.Closed && !index.Reopened {
+			targets = append(targets, index)
+		}
+	}
+	removeCount := len(targets) - strategy.MaxNumberOfIndices
+	for i := 0; i < removeCount && i < len(targets)-1; i++ {
+		index := targets[i]
+		if is.RetentionStrategyClass == graylog.DeletionRetentionStrategy {
+			if err := lgc.store.DeleteIndex(index.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		index.Closed = true
+		if err := lgc.store.UpdateIndex(&index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseISO8601Duration parses an ISO 8601 duration such as "P1D" and "PT6H".
+// A year is regarded as 365 days and a month is regarded as 30 days.
+func parseISO8601Duration(s string) (time.Duration, error) {
+	m := iso8601DurationRegexp.FindStringSubmatch(s)
+	if m == nil || s == "P" || s[len(s)-1] == 'T' {
+		return 0, fmt.Errorf("invalid ISO 8601 duration: %s", s)
+	}
+	day := 24 * time.Hour
+	units := []time.Duration{
+		365 * day, 30 * day, 7 * day, day, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
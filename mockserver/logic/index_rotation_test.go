@@ -0,0 +1,189 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestIndexMessageMessageCountRotation(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	is.RotationStrategy = graylog.NewMessageCountRotationStrategy(2)
+	is.RetentionStrategy = graylog.NewDeletionRetentionStrategy(2)
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	// 3 messages per index
+	for i := 0; i < 9; i++ {
+		if _, err := lgc.IndexMessage(is.ID, map[string]interface{}{"message": "hello"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	indices, _, err := lgc.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// hoge_0 and hoge_1 are deleted by the retention
+	for _, name := range []string{"hoge_2", "hoge_3"} {
+		if _, ok := indices.All.Indices[name]; !ok {
+			t.Fatalf("index %s should exist: %v", name, indices.All.Indices)
+		}
+	}
+	if len(indices.All.Indices) != 2 {
+		t.Fatalf("len(indices.All.Indices) == %d, wanted 2", len(indices.All.Indices))
+	}
+	stats, _, err := lgc.GetIndexSetStats(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Indices != 2 || stats.Documents != 3 {
+		t.Fatalf("stats == %+v, wanted 2 indices and 3 documents", stats)
+	}
+	if _, err := lgc.IndexMessage(is.ID, nil); err == nil {
+		t.Fatal("message is required")
+	}
+	if _, err := lgc.IndexMessage("h", map[string]interface{}{}); err == nil {
+		t.Fatal(`no index set whose id is "h"`)
+	}
+}
+
+func TestIndexMessageSizeBasedRotation(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	is.RotationStrategyClass = graylog.SizeBasedRotationStrategy
+	is.RotationStrategy = graylog.NewSizeBasedRotationStrategy(10)
+	is.RetentionStrategyClass = graylog.NoopRetentionStrategy
+	is.RetentionStrategy = graylog.NewNoopRetentionStrategy(1)
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := lgc.IndexMessage(is.ID, map[string]interface{}{"message": "hello world"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats, _, err := lgc.GetIndexSetStats(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// each message exceeds the max size and the noop retention keeps all indices
+	if stats.Indices != 4 || stats.Documents != 3 {
+		t.Fatalf("stats == %+v, wanted 4 indices and 3 documents", stats)
+	}
+}
+
+func TestRunIndexMaintenanceTimeBasedRotation(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	lgc.SetClock(func() time.Time { return now })
+	is := testutil.IndexSet("hoge")
+	is.RotationStrategyClass = graylog.TimeBasedRotationStrategy
+	is.RotationStrategy = graylog.NewTimeBasedRotationStrategy("PT6H")
+	is.RetentionStrategyClass = graylog.ClosingRetentionStrategy
+	is.RetentionStrategy = graylog.NewClosingRetentionStrategy(2)
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.IndexMessage(is.ID, map[string]interface{}{"message": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		now = now.Add(6 * time.Hour)
+		if _, err := lgc.RunIndexMaintenance(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	indices, _, err := lgc.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices.Closed.Total != 2 {
+		t.Fatalf("indices.Closed.Total == %d, wanted 2", indices.Closed.Total)
+	}
+	if len(indices.All.Indices) != 2 {
+		t.Fatalf("len(indices.All.Indices) == %d, wanted 2", len(indices.All.Indices))
+	}
+	r, _, err := lgc.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Begin != "2018-07-01T00:00:00.000Z" {
+		t.Fatalf(`r.Begin == "%s", wanted "2018-07-01T00:00:00.000Z"`, r.Begin)
+	}
+	// closed indices aren't counted
+	stats, _, err := lgc.GetIndexSetStats(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Indices != 2 || stats.Documents != 0 {
+		t.Fatalf("stats == %+v, wanted 2 indices and 0 documents", stats)
+	}
+
+	// a reopened index isn't cleaned up by the retention
+	if _, err := lgc.ReopenIndex("hoge_0"); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(6 * time.Hour)
+	if _, err := lgc.RunIndexMaintenance(); err != nil {
+		t.Fatal(err)
+	}
+	indices, _, err = lgc.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices.Reopened.Total != 1 {
+		t.Fatalf("indices.Reopened.Total == %d, wanted 1", indices.Reopened.Total)
+	}
+}
+
+func TestIngestMessageIndexSimulation(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := lgc.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	total, _, err := lgc.GetTotalIndexSetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lgc.IndexSimulation() {
+		t.Fatal("the index simulation should be disabled by default")
+	}
+	if _, _, err := lgc.IngestMessage(input.ID, map[string]interface{}{"message": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	stats, _, err := lgc.GetTotalIndexSetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Documents != total.Documents {
+		t.Fatal("the message should not be written if the simulation is disabled")
+	}
+	lgc.SetIndexSimulation(true)
+	if _, _, err := lgc.IngestMessage(input.ID, map[string]interface{}{"message": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	stats, _, err = lgc.GetTotalIndexSetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Documents != total.Documents+1 {
+		t.Fatalf("stats.Documents == %d, wanted %d", stats.Documents, total.Documents+1)
+	}
+}
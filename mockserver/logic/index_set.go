@@ -59,6 +59,14 @@ func (lgc *Logic) AddIndexSet(is *graylog.IndexSet) (int, error) {
 			is.IndexPrefix)
 	}
 	is.SetCreateDefaultValues()
+	if lgc.profile.FieldTypeRefreshInterval {
+		if is.FieldTypeRefreshInterval == 0 {
+			is.FieldTypeRefreshInterval = defaultFieldTypeRefreshInterval
+		}
+	} else if is.FieldTypeRefreshInterval != 0 {
+		return 400, fmt.Errorf(
+			"field_type_refresh_interval isn't supported by Graylog %s", lgc.profile.Name)
+	}
 	if err := validator.CreateValidator.Struct(is); err != nil {
 		return 400, err
 	}
@@ -66,6 +74,10 @@ func (lgc *Logic) AddIndexSet(is *graylog.IndexSet) (int, error) {
 	if err := lgc.store.AddIndexSet(is); err != nil {
 		return 500, err
 	}
+	// Graylog creates the index set's first index
+	if _, err := lgc.addIndex(is); err != nil {
+		return 500, err
+	}
 	return 200, nil
 }
 
@@ -77,6 +89,10 @@ func (lgc *Logic) UpdateIndexSet(prms *graylog.IndexSetUpdateParams) (*graylog.I
 	if err := validator.UpdateValidator.Struct(prms); err != nil {
 		return nil, 400, err
 	}
+	if !lgc.profile.FieldTypeRefreshInterval && prms.FieldTypeRefreshInterval != nil {
+		return nil, 400, fmt.Errorf(
+			"field_type_refresh_interval isn't supported by Graylog %s", lgc.profile.Name)
+	}
 	ok, err := lgc.HasIndexSet(prms.ID)
 	if err != nil {
 		lgc.Logger().WithFields(log.Fields{
@@ -134,6 +150,9 @@ func (lgc *Logic) DeleteIndexSet(id string) (int, error) {
 	if err := lgc.store.DeleteIndexSet(id); err != nil {
 		return 500, err
 	}
+	if err := lgc.deleteIndices(id); err != nil {
+		return 500, err
+	}
 	return 204, nil
 }
 
@@ -0,0 +1,148 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetIndices(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	indices, _, err := lgc.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := indices.All.Indices["hoge_0"]; !ok {
+		t.Fatalf("the index hoge_0 should be created: %v", indices.All.Indices)
+	}
+	if len(indices.Closed.Indices) != 0 {
+		t.Fatalf("len(indices.Closed.Indices) == %d, wanted 0", len(indices.Closed.Indices))
+	}
+	stats, _, err := lgc.GetIndexSetStats(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Indices != len(indices.All.Indices) {
+		t.Fatalf("stats.Indices == %d, wanted %d", stats.Indices, len(indices.All.Indices))
+	}
+	if _, sc, err := lgc.GetIndices("h"); err == nil {
+		t.Fatal(`no index set whose id is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+
+	if _, err := lgc.DeleteIndexSet(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, sc, err := lgc.GetIndex("hoge_0"); err == nil {
+		t.Fatal("the index set's indices should be deleted")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestGetIndex(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	index, _, err := lgc.GetIndex("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Routing) != is.Shards {
+		t.Fatalf("len(index.Routing) == %d, wanted %d", len(index.Routing), is.Shards)
+	}
+	if _, sc, err := lgc.GetIndex(""); err == nil {
+		t.Fatal("index name is required")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+}
+
+func TestCloseIndex(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if sc, err := lgc.CloseIndex("hoge_0"); err == nil {
+		t.Fatal("the active write index can't be closed")
+	} else if sc != 403 {
+		t.Fatalf("status code == %d, wanted 403", sc)
+	}
+	if sc, err := lgc.CloseIndex("h"); err == nil {
+		t.Fatal(`no index whose name is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestReopenIndex(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	// reopening an open index does nothing
+	if _, err := lgc.ReopenIndex("hoge_0"); err != nil {
+		t.Fatal(err)
+	}
+	if sc, err := lgc.ReopenIndex("h"); err == nil {
+		t.Fatal(`no index whose name is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestDeleteIndex(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if sc, err := lgc.DeleteIndex("hoge_0"); err == nil {
+		t.Fatal("the active write index can't be deleted")
+	} else if sc != 403 {
+		t.Fatalf("status code == %d, wanted 403", sc)
+	}
+}
+
+func TestGetIndexRange(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	r, _, err := lgc.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Begin != "1970-01-01T00:00:00.000Z" {
+		t.Fatalf(`r.Begin == "%s", wanted the epoch`, r.Begin)
+	}
+}
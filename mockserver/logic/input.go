@@ -38,9 +38,25 @@ func (lgc *Logic) AddInput(input *graylog.Input) (int, error) {
 	if err := validator.CreateValidator.Struct(input); err != nil {
 		return 400, err
 	}
+	if err := validator.ValidateInputAttrs(input.Attrs); err != nil {
+		return 400, err
+	}
+	if sc, err := lgc.checkInputNode(input.Node); err != nil {
+		return sc, err
+	}
+	if !input.Global && input.Node == "" {
+		// a non-global input is launched on the node which serves the API
+		current, sc, err := lgc.GetCurrentNode()
+		if err != nil {
+			return sc, err
+		}
+		input.Node = current.NodeID
+	}
 	if err := lgc.store.AddInput(input); err != nil {
 		return 500, err
 	}
+	// Graylog launches an input when the input is created
+	lgc.setInputState(input.ID, graylog.InputStateStarting, "")
 	return 200, nil
 }
 
@@ -54,6 +70,12 @@ func (lgc *Logic) UpdateInput(prms *graylog.InputUpdateParams) (*graylog.Input,
 	if err := validator.UpdateValidator.Struct(prms); err != nil {
 		return nil, 400, err
 	}
+	if err := validator.ValidateInputAttrs(prms.Attrs); err != nil {
+		return nil, 400, err
+	}
+	if sc, err := lgc.checkInputNode(prms.Node); err != nil {
+		return nil, sc, err
+	}
 	ok, err := lgc.HasInput(prms.ID)
 	if err != nil {
 		return nil, 500, err
@@ -84,6 +106,7 @@ func (lgc *Logic) DeleteInput(id string) (int, error) {
 	if err := lgc.store.DeleteInput(id); err != nil {
 		return 500, err
 	}
+	lgc.deleteInputState(id)
 	return 200, nil
 }
 
@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// inputState is an input's runtime state.
+// Input states aren't persisted because Graylog keeps them in memory.
+type inputState struct {
+	state           graylog.InputState
+	startedAt       time.Time
+	detailedMessage string
+}
+
+// SetInputStartupTime sets how long an input stays in the STARTING state after it is started.
+// By default this is 0, which means an input becomes RUNNING immediately.
+//
+//   lgc.SetInputStartupTime(100 * time.Millisecond)
+func (lgc *Logic) SetInputStartupTime(d time.Duration) {
+	lgc.inputStatesMutex.Lock()
+	defer lgc.inputStatesMutex.Unlock()
+	lgc.inputStartupTime = d
+}
+
+// newInputStateSummary converts an inputState to a InputStateSummary.
+// An input which has no state is regarded as running since the mock server started.
+func (lgc *Logic) newInputStateSummary(input *graylog.Input) *graylog.InputStateSummary {
+	lgc.inputStatesMutex.RLock()
+	defer lgc.inputStatesMutex.RUnlock()
+	s, ok := lgc.inputStates[input.ID]
+	if !ok {
+		return &graylog.InputStateSummary{
+			ID: input.ID, State: graylog.InputStateRunning,
+			StartedAt: input.CreatedAt, MessageInput: input}
+	}
+	state := s.state
+	if state == graylog.InputStateStarting && lgc.now().Sub(s.startedAt) >= lgc.inputStartupTime {
+		state = graylog.InputStateRunning
+	}
+	summary := &graylog.InputStateSummary{
+		ID: input.ID, State: state, DetailedMessage: s.detailedMessage,
+		MessageInput: input}
+	if state != graylog.InputStateStopped {
+		summary.StartedAt = s.startedAt.UTC().Format(graylog.CreationDateFormat)
+	}
+	return summary
+}
+
+func (lgc *Logic) setInputState(id string, state graylog.InputState, msg string) {
+	lgc.inputStatesMutex.Lock()
+	defer lgc.inputStatesMutex.Unlock()
+	lgc.inputStates[id] = inputState{
+		state: state, startedAt: lgc.now(), detailedMessage: msg}
+}
+
+func (lgc *Logic) deleteInputState(id string) {
+	lgc.inputStatesMutex.Lock()
+	defer lgc.inputStatesMutex.Unlock()
+	delete(lgc.inputStates, id)
+}
+
+// GetInputStates returns all inputs' states.
+func (lgc *Logic) GetInputStates() ([]graylog.InputStateSummary, int, error) {
+	inputs, _, sc, err := lgc.GetInputs()
+	if err != nil {
+		return nil, sc, err
+	}
+	states := make([]graylog.InputStateSummary, len(inputs))
+	for i := range inputs {
+		states[i] = *(lgc.newInputStateSummary(&inputs[i]))
+	}
+	return states, 200, nil
+}
+
+// GetInputState returns an input's state.
+func (lgc *Logic) GetInputState(id string) (*graylog.InputStateSummary, int, error) {
+	input, sc, err := lgc.GetInput(id)
+	if err != nil {
+		return nil, sc, err
+	}
+	return lgc.newInputStateSummary(input), 200, nil
+}
+
+// StartInput starts an input.
+// The input is STARTING for the startup time and then becomes RUNNING.
+// A FAILED input is restarted.
+func (lgc *Logic) StartInput(id string) (int, error) {
+	if _, sc, err := lgc.GetInput(id); err != nil {
+		return sc, err
+	}
+	lgc.setInputState(id, graylog.InputStateStarting, "")
+	return 200, nil
+}
+
+// StopInput stops an input.
+func (lgc *Logic) StopInput(id string) (int, error) {
+	if _, sc, err := lgc.GetInput(id); err != nil {
+		return sc, err
+	}
+	lgc.setInputState(id, graylog.InputStateStopped, "")
+	return 200, nil
+}
+
+// RestartInput restarts an input.
+// Graylog (re-)starts an input with the same API as StartInput,
+// so a running input goes back to the STARTING state.
+func (lgc *Logic) RestartInput(id string) (int, error) {
+	return lgc.StartInput(id)
+}
+
+// FailInput makes an input FAILED with a given message.
+// This is used to test how clients handle a failed input.
+// The input stays FAILED until it is started again,
+// and the notification "input_failed_to_start" is raised.
+//
+//   lgc.FailInput(input.ID, "failed to bind the port")
+func (lgc *Logic) FailInput(id, msg string) (int, error) {
+	if _, sc, err := lgc.GetInput(id); err != nil {
+		return sc, err
+	}
+	if msg == "" {
+		msg = fmt.Sprintf("the input <%s> failed", id)
+	}
+	lgc.setInputState(id, graylog.InputStateFailed, msg)
+	lgc.raiseNotification(
+		graylog.NotificationTypeInputFailedToStart, graylog.NotificationSeverityNormal,
+		map[string]interface{}{"input_id": id, "reason": msg})
+	return 200, nil
+}
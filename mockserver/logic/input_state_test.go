@@ -0,0 +1,121 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetInputStates(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	states, _, err := server.GetInputStates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 1 {
+		t.Fatalf("len(states) == %d, wanted 1", len(states))
+	}
+	if states[0].State != graylog.InputStateRunning {
+		t.Fatalf(`state = "%s", wanted "%s"`, states[0].State, graylog.InputStateRunning)
+	}
+}
+
+func TestStartInput(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetInputStartupTime(time.Hour)
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err := server.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateStarting {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateStarting)
+	}
+	server.SetInputStartupTime(0)
+	if _, err := server.StartInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err = server.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateRunning {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateRunning)
+	}
+	if _, err := server.StartInput("h"); err == nil {
+		t.Fatal(`no input whose id is "h"`)
+	}
+}
+
+func TestStopInput(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.StopInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err := server.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateStopped {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateStopped)
+	}
+	if _, err := server.RestartInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err = server.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateRunning {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateRunning)
+	}
+	if _, err := server.StopInput("h"); err == nil {
+		t.Fatal(`no input whose id is "h"`)
+	}
+}
+
+func TestFailInput(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.FailInput(input.ID, "failed to bind the port"); err != nil {
+		t.Fatal(err)
+	}
+	state, _, err := server.GetInputState(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.State != graylog.InputStateFailed {
+		t.Fatalf(`state = "%s", wanted "%s"`, state.State, graylog.InputStateFailed)
+	}
+	if state.DetailedMessage != "failed to bind the port" {
+		t.Fatalf(`detailed message = "%s", wanted "failed to bind the port"`, state.DetailedMessage)
+	}
+	if _, err := server.FailInput("h", ""); err == nil {
+		t.Fatal(`no input whose id is "h"`)
+	}
+}
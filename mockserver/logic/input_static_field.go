@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+var (
+	staticFieldKeyRegexp = regexp.MustCompile(`^[\w\.\-@]*$`)
+	// reservedFields is the set of message fields which Graylog sets by itself.
+	reservedFields = set.NewStrSet(
+		"_id", "message", "source", "timestamp", "full_message", "streams",
+		"gl2_source_node", "gl2_source_input", "gl2_source_radio",
+		"gl2_source_radio_input", "gl2_source_collector",
+		"gl2_source_collector_input", "gl2_remote_ip", "gl2_remote_port",
+		"gl2_remote_hostname")
+)
+
+// AddInputStaticField adds a static field to an input.
+func (lgc *Logic) AddInputStaticField(id, key, value string) (int, error) {
+	if key == "" {
+		return 400, fmt.Errorf("static field key is empty")
+	}
+	if !staticFieldKeyRegexp.MatchString(key) {
+		return 400, fmt.Errorf("invalid key: '%s'", key)
+	}
+	if reservedFields.Has(key) {
+		return 400, fmt.Errorf(
+			"cannot add static field. field [%s] is reserved", key)
+	}
+	if _, sc, err := lgc.GetInput(id); err != nil {
+		return sc, err
+	}
+	if err := lgc.store.AddInputStaticField(id, key, value); err != nil {
+		return 500, err
+	}
+	return 201, nil
+}
+
+// DeleteInputStaticField deletes a static field from an input.
+func (lgc *Logic) DeleteInputStaticField(id, key string) (int, error) {
+	input, sc, err := lgc.GetInput(id)
+	if err != nil {
+		return sc, err
+	}
+	if _, ok := input.StaticFields[key]; !ok {
+		return 404, fmt.Errorf(
+			"the input <%s> has no static field <%s>", id, key)
+	}
+	if err := lgc.store.DeleteInputStaticField(id, key); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
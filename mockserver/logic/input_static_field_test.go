@@ -0,0 +1,64 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestAddInputStaticField(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	act, _, err := server.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act.StaticFields["env"] != "test" {
+		t.Fatalf(`StaticFields["env"] = "%s", wanted "test"`, act.StaticFields["env"])
+	}
+	if _, err := server.AddInputStaticField(input.ID, "", "test"); err == nil {
+		t.Fatal("key is required")
+	}
+	if _, err := server.AddInputStaticField(input.ID, "foo bar", "test"); err == nil {
+		t.Fatal("key should be invalid")
+	}
+	if _, err := server.AddInputStaticField(input.ID, "source", "test"); err == nil {
+		t.Fatal("source is a reserved field")
+	}
+	if _, err := server.AddInputStaticField("h", "env", "test"); err == nil {
+		t.Fatal(`no input whose id is "h"`)
+	}
+}
+
+func TestDeleteInputStaticField(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.DeleteInputStaticField(input.ID, "env"); err == nil {
+		t.Fatal(`static field "env" should be already deleted`)
+	}
+	if _, err := server.DeleteInputStaticField("h", "env"); err == nil {
+		t.Fatal(`no input whose id is "h"`)
+	}
+}
@@ -24,6 +24,28 @@ func TestAddInput(t *testing.T) {
 	if _, err := server.AddInput(nil); err == nil {
 		t.Fatal("input is nil")
 	}
+	input = testutil.Input()
+	input.Attrs.(*graylog.InputBeatsAttrs).Port = 70000
+	if sc, err := server.AddInput(input); err == nil {
+		t.Fatal("port should be invalid")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+	input = testutil.Input()
+	input.Node = "unknown"
+	if sc, err := server.AddInput(input); err == nil {
+		t.Fatal("the node should be unknown")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+	input = testutil.Input()
+	input.Node = ""
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if input.Node == "" {
+		t.Fatal("the current node should be set to the non-global input")
+	}
 }
 
 func TestGetInputs(t *testing.T) {
@@ -0,0 +1,364 @@
+package logic
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// LDAPDirectory is an in-memory stand-in of the LDAP server.
+// The mock server doesn't connect to a real LDAP server
+// but binds to and searches the LDAPDirectory.
+//
+//   lgc.SetLDAPDirectory(&logic.LDAPDirectory{
+//   	URI: "ldap://localhost:389",
+//   	SystemUsername: "cn=admin,dc=example,dc=com",
+//   	SystemPassword: "admin",
+//   	Users: []logic.LDAPUser{{
+//   		UID: "foo", Password: "password", Groups: []string{"admins"},
+//   	}},
+//   })
+type LDAPDirectory struct {
+	// If URI is empty, any ldap or ldaps URI can connect to the directory.
+	URI string
+	// If SystemUsername is empty, the anonymous bind is allowed.
+	SystemUsername string
+	SystemPassword string
+	Users          []LDAPUser
+}
+
+// LDAPUser is an entry of LDAPDirectory.
+type LDAPUser struct {
+	UID      string
+	Password string
+	FullName string
+	Email    string
+	// the names of the LDAP groups which the user belongs to
+	Groups []string
+}
+
+// SetLDAPDirectory sets the in-memory LDAP directory.
+// If dir is nil, the mock server fails to connect to any LDAP server.
+func (lgc *Logic) SetLDAPDirectory(dir *LDAPDirectory) {
+	lgc.ldapMutex.Lock()
+	defer lgc.ldapMutex.Unlock()
+	lgc.ldapDirectory = dir
+}
+
+// bindLDAP connects and binds to the LDAP directory.
+// The caller must hold ldapMutex.
+func (lgc *Logic) bindLDAP(uri, username, password string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid ldap uri %s: %s", uri, err)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return fmt.Errorf(`the scheme of the ldap uri must be "ldap" or "ldaps": %s`, uri)
+	}
+	dir := lgc.ldapDirectory
+	if dir == nil || (dir.URI != "" && dir.URI != uri) {
+		return fmt.Errorf("failed to connect to the ldap server %s", uri)
+	}
+	if dir.SystemUsername == "" && username == "" {
+		return nil
+	}
+	if dir.SystemUsername != username || dir.SystemPassword != password {
+		return fmt.Errorf("invalid credentials of the system user")
+	}
+	return nil
+}
+
+// searchLDAPUser returns the LDAP user whose uid is a given principal.
+// If the user isn't found, nil is returned.
+// The caller must hold ldapMutex.
+func (lgc *Logic) searchLDAPUser(principal string) *LDAPUser {
+	for i, user := range lgc.ldapDirectory.Users {
+		if user.UID == principal {
+			return &lgc.ldapDirectory.Users[i]
+		}
+	}
+	return nil
+}
+
+// ldapEntry returns an LDAP user's attributes.
+func ldapEntry(user *LDAPUser, searchBase string) map[string]string {
+	entry := map[string]string{
+		"dn":  fmt.Sprintf("uid=%s,%s", user.UID, searchBase),
+		"uid": user.UID,
+	}
+	if user.FullName != "" {
+		entry["cn"] = user.FullName
+	}
+	if user.Email != "" {
+		entry["mail"] = user.Email
+	}
+	return entry
+}
+
+// checkRoles returns an error if some roles don't exist.
+func (lgc *Logic) checkRoles(roles ...string) (int, error) {
+	for _, role := range roles {
+		if role == "" {
+			continue
+		}
+		ok, err := lgc.HasRole(role)
+		if err != nil {
+			return 500, err
+		}
+		if !ok {
+			return 400, fmt.Errorf(`no role with name "%s"`, role)
+		}
+	}
+	return 200, nil
+}
+
+// GetLDAPSettings returns the LDAP settings.
+// The system password isn't returned, and whether it is set is returned instead.
+// If the LDAP settings aren't set, nil is returned.
+func (lgc *Logic) GetLDAPSettings() (*graylog.LDAPSettings, int, error) {
+	settings, err := lgc.store.GetLDAPSettings()
+	if err != nil {
+		return nil, 500, err
+	}
+	if settings == nil {
+		return nil, 204, nil
+	}
+	settings.SystemPasswordSet = settings.SystemPassword != ""
+	settings.SystemPassword = ""
+	return settings, 200, nil
+}
+
+// UpdateLDAPSettings updates the LDAP settings.
+// If the system password is empty, the current password is kept.
+// If the group mapping is nil, the current group mapping is kept.
+func (lgc *Logic) UpdateLDAPSettings(settings *graylog.LDAPSettings) (int, error) {
+	if settings == nil {
+		return 400, fmt.Errorf("ldap settings is nil")
+	}
+	for k, v := range map[string]string{
+		"ldap_uri":               settings.LDAPURI,
+		"search_base":            settings.SearchBase,
+		"search_pattern":         settings.SearchPattern,
+		"display_name_attribute": settings.DisplayNameAttribute,
+	} {
+		if v == "" {
+			return 400, fmt.Errorf("%s is required", k)
+		}
+	}
+	if u, err := url.Parse(settings.LDAPURI); err != nil {
+		return 400, fmt.Errorf("invalid ldap_uri: %s", err)
+	} else if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return 400, fmt.Errorf(`the scheme of ldap_uri must be "ldap" or "ldaps"`)
+	}
+	if sc, err := lgc.checkRoles(settings.DefaultGroup); err != nil {
+		return sc, err
+	}
+	if sc, err := lgc.checkRoles(settings.AdditionalDefaultGroups.ToList()...); err != nil {
+		return sc, err
+	}
+	s := *settings
+	s.SystemPasswordSet = false
+	current, err := lgc.store.GetLDAPSettings()
+	if err != nil {
+		return 500, err
+	}
+	if current != nil {
+		if s.SystemPassword == "" {
+			s.SystemPassword = current.SystemPassword
+		}
+		if s.GroupMapping == nil {
+			s.GroupMapping = current.GroupMapping
+		}
+	}
+	for _, role := range s.GroupMapping {
+		if sc, err := lgc.checkRoles(role); err != nil {
+			return sc, err
+		}
+	}
+	if err := lgc.store.SetLDAPSettings(&s); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// DeleteLDAPSettings deletes the LDAP settings.
+func (lgc *Logic) DeleteLDAPSettings() (int, error) {
+	if err := lgc.store.DeleteLDAPSettings(); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// TestLDAPSettings tests the connection to the LDAP server and the login of a user.
+// Even if the test fails, an error isn't returned but the result is returned.
+func (lgc *Logic) TestLDAPSettings(
+	req *graylog.LDAPTestConfigRequest,
+) (*graylog.LDAPTestConfigResponse, int, error) {
+	if req == nil {
+		return nil, 400, fmt.Errorf("request body is nil")
+	}
+	password := req.SystemPassword
+	if password == "" {
+		current, err := lgc.store.GetLDAPSettings()
+		if err != nil {
+			return nil, 500, err
+		}
+		if current != nil {
+			password = current.SystemPassword
+		}
+	}
+	lgc.ldapMutex.RLock()
+	defer lgc.ldapMutex.RUnlock()
+	resp := &graylog.LDAPTestConfigResponse{}
+	if err := lgc.bindLDAP(req.LDAPURI, req.SystemUsername, password); err != nil {
+		resp.SystemMessage = err.Error()
+		return resp, 200, nil
+	}
+	resp.Connected = true
+	if req.TestConnectOnly {
+		return resp, 200, nil
+	}
+	user := lgc.searchLDAPUser(req.Principal)
+	if user == nil {
+		resp.SystemMessage = fmt.Sprintf("no ldap entry found for principal %s", req.Principal)
+		return resp, 200, nil
+	}
+	resp.Entry = ldapEntry(user, req.SearchBase)
+	resp.UserDN = resp.Entry["dn"]
+	resp.Groups = set.NewStrSet(user.Groups...)
+	resp.LoginAuthenticated = req.Password != "" && req.Password == user.Password
+	return resp, 200, nil
+}
+
+// GetLDAPGroups returns the names of all groups of the LDAP server.
+func (lgc *Logic) GetLDAPGroups() ([]string, int, error) {
+	settings, err := lgc.store.GetLDAPSettings()
+	if err != nil {
+		return nil, 500, err
+	}
+	if settings == nil || !settings.Enabled {
+		return nil, 400, fmt.Errorf("LDAP is disabled")
+	}
+	lgc.ldapMutex.RLock()
+	defer lgc.ldapMutex.RUnlock()
+	if err := lgc.bindLDAP(
+		settings.LDAPURI, settings.SystemUsername, settings.SystemPassword,
+	); err != nil {
+		return nil, 500, err
+	}
+	groups := set.NewStrSet()
+	for _, user := range lgc.ldapDirectory.Users {
+		groups.Adds(user.Groups...)
+	}
+	arr := groups.ToList()
+	sort.Strings(arr)
+	return arr, 200, nil
+}
+
+// GetLDAPGroupRoleMapping returns the map of LDAP groups to Graylog roles.
+func (lgc *Logic) GetLDAPGroupRoleMapping() (map[string]string, int, error) {
+	settings, err := lgc.store.GetLDAPSettings()
+	if err != nil {
+		return nil, 500, err
+	}
+	if settings == nil || settings.GroupMapping == nil {
+		return map[string]string{}, 200, nil
+	}
+	return settings.GroupMapping, 200, nil
+}
+
+// UpdateLDAPGroupRoleMapping updates the map of LDAP groups to Graylog roles.
+func (lgc *Logic) UpdateLDAPGroupRoleMapping(mapping map[string]string) (int, error) {
+	for _, role := range mapping {
+		if sc, err := lgc.checkRoles(role); err != nil {
+			return sc, err
+		}
+	}
+	settings, err := lgc.store.GetLDAPSettings()
+	if err != nil {
+		return 500, err
+	}
+	if settings == nil {
+		settings = &graylog.LDAPSettings{}
+	}
+	settings.GroupMapping = mapping
+	if err := lgc.store.SetLDAPSettings(settings); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// authenticateLDAPUser authenticates a user with the LDAP settings and the LDAP directory.
+// When the authentication succeeds, the external user is created or updated
+// and the roles are assigned according to the default groups and the group mapping.
+// user is the current external user and nil if the user doesn't exist yet.
+func (lgc *Logic) authenticateLDAPUser(
+	user *graylog.User, name, password string,
+) (*graylog.User, int, error) {
+	authErr := fmt.Errorf("authentication failure")
+	settings, err := lgc.store.GetLDAPSettings()
+	if err != nil {
+		return nil, 500, err
+	}
+	if settings == nil || !settings.Enabled {
+		return nil, 401, authErr
+	}
+	lgc.ldapMutex.RLock()
+	if err := lgc.bindLDAP(
+		settings.LDAPURI, settings.SystemUsername, settings.SystemPassword,
+	); err != nil {
+		lgc.ldapMutex.RUnlock()
+		lgc.Logger().WithField("error", err).Warn("failed to bind to the ldap server")
+		return nil, 401, authErr
+	}
+	var entry LDAPUser
+	if u := lgc.searchLDAPUser(name); u != nil {
+		entry = *u
+	}
+	lgc.ldapMutex.RUnlock()
+	if entry.UID == "" || entry.Password != password {
+		return nil, 401, authErr
+	}
+
+	roles := set.NewStrSet()
+	if settings.DefaultGroup != "" {
+		roles.Add(settings.DefaultGroup)
+	}
+	roles.AddSet(settings.AdditionalDefaultGroups)
+	for _, group := range entry.Groups {
+		if role, ok := settings.GroupMapping[group]; ok {
+			roles.Add(role)
+		}
+	}
+	fullName := entry.FullName
+	if fullName == "" {
+		fullName = name
+	}
+	email := entry.Email
+	if email == "" {
+		email = name + "@localhost"
+	}
+	if user == nil {
+		user = &graylog.User{
+			Username: name, FullName: fullName, Email: email,
+			External: true, Roles: roles, Permissions: set.NewStrSet(),
+		}
+		user.SetDefaultValues()
+		if err := lgc.store.AddUser(user); err != nil {
+			return nil, 500, err
+		}
+		return user, 200, nil
+	}
+	if err := lgc.store.UpdateUser(&graylog.UserUpdateParams{
+		Username: name, FullName: &fullName, Email: &email, Roles: roles,
+	}); err != nil {
+		return nil, 500, err
+	}
+	user.FullName = fullName
+	user.Email = email
+	user.Roles = roles
+	return user, 200, nil
+}
@@ -0,0 +1,279 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+func newLDAPSettings() *graylog.LDAPSettings {
+	return &graylog.LDAPSettings{
+		Enabled:              true,
+		SystemUsername:       "cn=admin,dc=example,dc=com",
+		SystemPassword:       "admin",
+		LDAPURI:              "ldap://localhost:389",
+		SearchBase:           "cn=users,dc=example,dc=com",
+		SearchPattern:        "(&(objectClass=inetOrgPerson)(uid={0}))",
+		DisplayNameAttribute: "cn",
+		DefaultGroup:         "Admin",
+	}
+}
+
+func newLDAPDirectory() *logic.LDAPDirectory {
+	return &logic.LDAPDirectory{
+		URI:            "ldap://localhost:389",
+		SystemUsername: "cn=admin,dc=example,dc=com",
+		SystemPassword: "admin",
+		Users: []logic.LDAPUser{{
+			UID: "foo", Password: "password", FullName: "Foo",
+			Groups: []string{"admins", "developers"},
+		}},
+	}
+}
+
+func TestGetLDAPSettings(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err := lgc.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings != nil {
+		t.Fatal("ldap settings should not be set")
+	}
+	if _, err := lgc.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err = lgc.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.SystemPassword != "" {
+		t.Fatal("the system password should not be returned")
+	}
+	if !settings.SystemPasswordSet {
+		t.Fatal("system_password_set should be true")
+	}
+}
+
+func TestUpdateLDAPSettings(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.UpdateLDAPSettings(nil); err == nil {
+		t.Fatal("ldap settings is nil")
+	}
+	settings := newLDAPSettings()
+	settings.LDAPURI = "http://localhost:389"
+	if _, err := lgc.UpdateLDAPSettings(settings); err == nil {
+		t.Fatal("the scheme of ldap_uri should be invalid")
+	}
+	settings = newLDAPSettings()
+	settings.DefaultGroup = "foo"
+	if sc, err := lgc.UpdateLDAPSettings(settings); err == nil {
+		t.Fatal(`no role with name "foo"`)
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+	if _, err := lgc.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	// the current password is kept
+	settings = newLDAPSettings()
+	settings.SystemPassword = ""
+	if _, err := lgc.UpdateLDAPSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetLDAPDirectory(newLDAPDirectory())
+	resp, _, err := lgc.TestLDAPSettings(&graylog.LDAPTestConfigRequest{
+		SystemUsername: settings.SystemUsername, LDAPURI: settings.LDAPURI,
+		TestConnectOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Connected {
+		t.Fatalf("failed to connect: %s", resp.SystemMessage)
+	}
+}
+
+func TestDeleteLDAPSettings(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.DeleteLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+	settings, _, err := lgc.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings != nil {
+		t.Fatal("ldap settings should be deleted")
+	}
+}
+
+func TestTestLDAPSettings(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := newLDAPSettings()
+	req := &graylog.LDAPTestConfigRequest{
+		SystemUsername: settings.SystemUsername,
+		SystemPassword: settings.SystemPassword,
+		LDAPURI:        settings.LDAPURI,
+		SearchBase:     settings.SearchBase,
+		SearchPattern:  settings.SearchPattern,
+		Principal:      "foo",
+		Password:       "password",
+	}
+	resp, _, err := lgc.TestLDAPSettings(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Connected {
+		t.Fatal("no ldap directory is set")
+	}
+	lgc.SetLDAPDirectory(newLDAPDirectory())
+	resp, _, err = lgc.TestLDAPSettings(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Connected || !resp.LoginAuthenticated {
+		t.Fatalf("resp == %+v", resp)
+	}
+	if resp.UserDN != "uid=foo,cn=users,dc=example,dc=com" {
+		t.Fatalf(`resp.UserDN == "%s"`, resp.UserDN)
+	}
+	if !resp.Groups.HasAll("admins", "developers") {
+		t.Fatalf("resp.Groups == %v", resp.Groups)
+	}
+	req.Password = "bar"
+	resp, _, err = lgc.TestLDAPSettings(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.LoginAuthenticated {
+		t.Fatal("the password is wrong")
+	}
+	req.SystemPassword = "bar"
+	resp, _, err = lgc.TestLDAPSettings(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Connected {
+		t.Fatal("the system password is wrong")
+	}
+}
+
+func TestGetLDAPGroups(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, sc, err := lgc.GetLDAPGroups(); err == nil {
+		t.Fatal("LDAP is disabled")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+	if _, err := lgc.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetLDAPDirectory(newLDAPDirectory())
+	groups, _, err := lgc.GetLDAPGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("len(groups) == %d, wanted 2", len(groups))
+	}
+}
+
+func TestUpdateLDAPGroupRoleMapping(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.UpdateLDAPGroupRoleMapping(
+		map[string]string{"admins": "foo"}); err == nil {
+		t.Fatal(`no role with name "foo"`)
+	}
+	if _, err := lgc.UpdateLDAPGroupRoleMapping(
+		map[string]string{"admins": "Admin"}); err != nil {
+		t.Fatal(err)
+	}
+	mapping, _, err := lgc.GetLDAPGroupRoleMapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping["admins"] != "Admin" {
+		t.Fatalf("mapping == %v", mapping)
+	}
+	// the group mapping is kept when the settings are updated
+	if _, err := lgc.UpdateLDAPSettings(newLDAPSettings()); err != nil {
+		t.Fatal(err)
+	}
+	mapping, _, err = lgc.GetLDAPGroupRoleMapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping["admins"] != "Admin" {
+		t.Fatalf("mapping == %v", mapping)
+	}
+}
+
+func TestAuthenticateLDAPUser(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetLDAPDirectory(newLDAPDirectory())
+	if _, _, err := lgc.Authenticate("foo", "password"); err == nil {
+		t.Fatal("LDAP is disabled")
+	}
+	settings := newLDAPSettings()
+	settings.DefaultGroup = ""
+	settings.GroupMapping = map[string]string{"admins": "Admin"}
+	if _, err := lgc.UpdateLDAPSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	if _, sc, err := lgc.Authenticate("foo", "bar"); err == nil {
+		t.Fatal("the password is wrong")
+	} else if sc != 401 {
+		t.Fatalf("status code == %d, wanted 401", sc)
+	}
+	user, _, err := lgc.Authenticate("foo", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !user.External {
+		t.Fatal("the user should be external")
+	}
+	if !user.Roles.Has("Admin") {
+		t.Fatalf("user.Roles == %v", user.Roles)
+	}
+	// the external user is created
+	u, _, err := lgc.GetUser("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.FullName != "Foo" {
+		t.Fatalf(`u.FullName == "%s", wanted "Foo"`, u.FullName)
+	}
+	// the external user is authenticated with the LDAP directory again
+	if _, _, err := lgc.Authenticate("foo", "password"); err != nil {
+		t.Fatal(err)
+	}
+	// a local user isn't authenticated with the LDAP directory
+	if _, _, err := lgc.Authenticate("admin", "password"); err == nil {
+		t.Fatal("the password of the local user is wrong")
+	}
+}
@@ -2,9 +2,12 @@ package logic
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/seed"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
 )
@@ -13,7 +16,47 @@ import (
 // This is embedded to mockserver.Server.
 type Logic struct {
 	authEnabled bool
-	streamRules map[string]map[string]graylog.StreamRule
+
+	// dataMutex makes a sequence of store operations atomic.
+	dataMutex sync.RWMutex
+
+	inputStates      map[string]inputState
+	inputStartupTime time.Duration
+	inputStatesMutex sync.RWMutex
+
+	clock           func() time.Time
+	indexSimulation bool
+	indexMutex      sync.Mutex
+
+	systemJobs        map[string]*systemJob
+	systemJobDuration time.Duration
+	systemJobsMutex   sync.RWMutex
+
+	ingestion      ingestionStats
+	ingestionMutex sync.Mutex
+
+	notificationMutex sync.Mutex
+
+	ldapDirectory *LDAPDirectory
+	ldapMutex     sync.RWMutex
+
+	nodes      []graylog.Node
+	nodesMutex sync.RWMutex
+	startedAt  time.Time
+
+	faults      []Fault
+	faultsMutex sync.Mutex
+
+	requestLog    requestLog
+	expectations  []*Expectation
+	strict        bool
+	requestsMutex sync.Mutex
+
+	// the behavior of the emulated Graylog version
+	profile Profile
+
+	// the seed data which is added to the initial data
+	fixture *seed.Fixture
 
 	store  store.Store
 	logger *log.Logger
@@ -35,13 +78,22 @@ func (lgc *Logic) Logger() *log.Logger {
 // NewLogic returns new Server.
 // The argument `store` is the store which the server uses.
 // If `store` is nil, the default plain store is used and data is not persisted.
-func NewLogic(store store.Store) (*Logic, error) {
+// The options are applied before the initial data is set.
+//
+//   lgc, err := logic.NewLogic(nil, logic.WithSeed("fixtures.yaml"))
+func NewLogic(store store.Store, opts ...Option) (*Logic, error) {
 	if store == nil {
 		store = plain.NewStore("")
 	}
 	lgc := &Logic{
 		// indexSetStats: map[string]graylog.IndexSetStats{},
-		streamRules: map[string]map[string]graylog.StreamRule{},
+		inputStates: map[string]inputState{},
+		systemJobs:  map[string]*systemJob{},
+		nodes:       []graylog.Node{*(seed.Node())},
+		ingestion:   ingestionStats{inputCount: map[string]int64{}},
+		startedAt:   time.Now(),
+		requestLog:  requestLog{size: defaultRequestLogSize},
+		profile:     profiles[defaultProfile],
 
 		store:  store,
 		logger: log.New(),
@@ -51,10 +103,98 @@ func NewLogic(store store.Store) (*Logic, error) {
 	// By Default logLevel is warn,
 	// because debug and info logs are often noisy at unit tests.
 	lgc.logger.SetLevel(log.WarnLevel)
+	for _, opt := range opts {
+		if err := opt(lgc); err != nil {
+			return nil, err
+		}
+	}
 	err := lgc.InitData()
 	return lgc, err
 }
 
+// SetClock sets the function which returns the current time.
+// This is useful to test time based behaviors such as the time based index rotation.
+// If clock is nil, time.Now is used.
+//
+//   now := time.Now()
+//   lgc.SetClock(func() time.Time { return now })
+//   // a day passes
+//   now = now.Add(24 * time.Hour)
+func (lgc *Logic) SetClock(clock func() time.Time) {
+	lgc.clock = clock
+}
+
+// now returns the current time with the clock.
+func (lgc *Logic) now() time.Time {
+	if lgc.clock == nil {
+		return time.Now()
+	}
+	return lgc.clock()
+}
+
+// Lock locks the data of the mock server for writing.
+// Many methods access the store in several steps,
+// for example AddStream checks the index set and then adds the stream,
+// and they aren't atomic by themselves.
+// The handler of each API call holds the lock while the call is processed,
+// so concurrent API calls are isolated from each other.
+// To change the data by Logic's methods while the server is running, use Update,
+// which holds the lock and saves the data.
+// Snapshot, Restore and Reset lock the data by themselves.
+func (lgc *Logic) Lock() {
+	lgc.dataMutex.Lock()
+}
+
+// Unlock unlocks the data locked by Lock.
+func (lgc *Logic) Unlock() {
+	lgc.dataMutex.Unlock()
+}
+
+// RLock locks the data of the mock server for reading.
+func (lgc *Logic) RLock() {
+	lgc.dataMutex.RLock()
+}
+
+// RUnlock unlocks the data locked by RLock.
+func (lgc *Logic) RUnlock() {
+	lgc.dataMutex.RUnlock()
+}
+
+// Update calls f while the data is locked for writing and saves the data if f succeeds,
+// so f is isolated from the concurrent API calls.
+// Don't call Update in f.
+//
+//   err := server.Update(func() error {
+//   	stream, _, err := server.GetStream(id)
+//   	if err != nil {
+//   		return err
+//   	}
+//   	stream.Title = "foo"
+//   	_, _, err = server.UpdateStream(stream.NewUpdateParams())
+//   	return err
+//   })
+func (lgc *Logic) Update(f func() error) error {
+	lgc.Lock()
+	defer lgc.Unlock()
+	if err := f(); err != nil {
+		return err
+	}
+	return lgc.Save()
+}
+
+// View calls f while the data is locked for reading.
+// f must not change the data.
+//
+//   err := server.View(func() error {
+//   	streams, _, _, err := server.GetStreams()
+//   	...
+//   })
+func (lgc *Logic) View(f func() error) error {
+	lgc.RLock()
+	defer lgc.RUnlock()
+	return f()
+}
+
 // SetStore sets a store to the mock server.
 func (lgc *Logic) SetStore(store store.Store) {
 	lgc.store = store
@@ -1,12 +1,14 @@
 package logic_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
 )
 
 func TestLogger(t *testing.T) {
@@ -38,6 +40,44 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestUpdate(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	lgc, err := logic.NewLogic(plain.NewStore(tmpfile.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.Update(func() error {
+		return fmt.Errorf("failure")
+	}); err == nil {
+		t.Fatal("the error of f should be returned")
+	}
+	role := testutil.Role()
+	if err := lgc.Update(func() error {
+		_, err := lgc.AddRole(role)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// the data is saved
+	s := plain.NewStore(tmpfile.Name())
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := s.GetRole(role.Name); err != nil || r == nil {
+		t.Fatalf("the role should be saved: %v", err)
+	}
+	if err := lgc.View(func() error {
+		_, _, err := lgc.GetRole(role.Name)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestLoad(t *testing.T) {
 	lgc, err := logic.NewLogic(nil)
 	if err != nil {
@@ -89,4 +129,31 @@ func TestAuthorize(t *testing.T) {
 	if _, err := lgc.Authorize(nobody, "users:read", "admin"); err == nil {
 		t.Fatal("authorization should be failure")
 	}
+	// an empty id is a type-level check, which is denied rather than an error
+	sc, err := lgc.Authorize(nobody, "roles:read", "")
+	if err == nil {
+		t.Fatal("authorization should be failure")
+	}
+	if sc != 403 {
+		t.Fatalf("status code = %d, wanted 403: %v", sc, err)
+	}
+}
+
+func TestNewLogicInitializedStore(t *testing.T) {
+	store := plain.NewStore("")
+	if _, err := logic.NewLogic(store); err != nil {
+		t.Fatal(err)
+	}
+	// the store which has been initialized isn't initialized again
+	lgc, err := logic.NewLogic(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss, _, _, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(iss) != 1 {
+		t.Fatalf("len(iss) = %d, wanted 1", len(iss))
+	}
 }
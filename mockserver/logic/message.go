@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// IngestMessage receives a message at a given input as if it were sent to the input.
+// The input's static fields are added to the message unless the message already has the field,
+// and the processed message is returned.
+// The message is counted by the metrics such as the throughput.
+// If the index simulation is enabled, the message is written to the default index set.
+//
+//   msg, _, err := lgc.IngestMessage(input.ID, map[string]interface{}{
+//   	"message": "hello", "source": "example.com",
+//   })
+func (lgc *Logic) IngestMessage(
+	inputID string, fields map[string]interface{},
+) (map[string]interface{}, int, error) {
+	if fields == nil {
+		return nil, 400, fmt.Errorf("message is nil")
+	}
+	input, sc, err := lgc.GetInput(inputID)
+	if err != nil {
+		return nil, sc, err
+	}
+	msg := make(map[string]interface{}, len(fields)+len(input.StaticFields)+1)
+	for k, v := range fields {
+		msg[k] = v
+	}
+	for k, v := range input.StaticFields {
+		if _, ok := msg[k]; !ok {
+			msg[k] = v
+		}
+	}
+	msg["gl2_source_input"] = input.ID
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return nil, 400, err
+	}
+	lgc.recordIngestion(input.ID, len(b))
+	if !lgc.indexSimulation {
+		return msg, 200, nil
+	}
+	id, err := lgc.store.GetDefaultIndexSetID()
+	if err != nil {
+		return nil, 500, err
+	}
+	if sc, err := lgc.IndexMessage(id, msg); err != nil {
+		return nil, sc, err
+	}
+	return msg, 200, nil
+}
@@ -0,0 +1,45 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestIngestMessage(t *testing.T) {
+	server, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := server.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.AddInputStaticField(input.ID, "dc", "tokyo"); err != nil {
+		t.Fatal(err)
+	}
+	msg, _, err := server.IngestMessage(input.ID, map[string]interface{}{
+		"message": "hello", "dc": "osaka"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg["env"] != "test" {
+		t.Fatalf(`msg["env"] = "%v", wanted "test"`, msg["env"])
+	}
+	if msg["dc"] != "osaka" {
+		t.Fatalf(`the static field must not overwrite the message's field: msg["dc"] = "%v", wanted "osaka"`, msg["dc"])
+	}
+	if msg["gl2_source_input"] != input.ID {
+		t.Fatalf(`msg["gl2_source_input"] = "%v", wanted "%s"`, msg["gl2_source_input"], input.ID)
+	}
+	if _, _, err := server.IngestMessage(input.ID, nil); err == nil {
+		t.Fatal("message is required")
+	}
+	if _, _, err := server.IngestMessage("h", map[string]interface{}{}); err == nil {
+		t.Fatal(`no input whose id is "h"`)
+	}
+}
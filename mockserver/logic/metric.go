@@ -0,0 +1,227 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+const (
+	inputThroughputMetricName  = "org.graylog2.throughput.input.1-sec-interval"
+	outputThroughputMetricName = "org.graylog2.throughput.output.1-sec-interval"
+	inputMeterMetricName       = "org.graylog2.throughput.input"
+	outputMeterMetricName      = "org.graylog2.throughput.output"
+	inputCounterMetricName     = "org.graylog2.throughput.input.total"
+	processTimeMetricName      = "org.graylog2.shared.buffers.processors.ProcessBufferProcessor.processTime"
+	journalSizeMetricName      = "org.graylog2.journal.size"
+
+	// ingestionWindow is how long ingestion records are kept to calculate the rates.
+	ingestionWindow = 15 * time.Minute
+
+	journalSizeLimit = 5 * 1024 * 1024 * 1024
+)
+
+// ingestionRecord is a record of a message ingested by IngestMessage.
+type ingestionRecord struct {
+	inputID string
+	at      time.Time
+}
+
+// ingestionStats is the message ingestion counters which the metrics are calculated from.
+// The counters aren't persisted because Graylog's metrics are reset when Graylog restarts.
+type ingestionStats struct {
+	count      int64
+	size       int64
+	inputCount map[string]int64
+	// records in the last ingestionWindow, in the ingested order
+	records []ingestionRecord
+}
+
+// recordIngestion updates the ingestion counters.
+func (lgc *Logic) recordIngestion(inputID string, size int) {
+	lgc.ingestionMutex.Lock()
+	defer lgc.ingestionMutex.Unlock()
+	now := lgc.now()
+	stats := &lgc.ingestion
+	stats.count++
+	stats.size += int64(size)
+	stats.inputCount[inputID]++
+	stats.records = append(stats.records, ingestionRecord{inputID: inputID, at: now})
+	// drop the records out of the window
+	i := 0
+	for ; i < len(stats.records); i++ {
+		if now.Sub(stats.records[i].at) < ingestionWindow {
+			break
+		}
+	}
+	stats.records = stats.records[i:]
+}
+
+// countIngestion returns the number of messages ingested in a given duration.
+// If inputID isn't empty, only the input's messages are counted.
+// The caller must hold ingestionMutex.
+func (lgc *Logic) countIngestion(inputID string, d time.Duration) int {
+	now := lgc.now()
+	cnt := 0
+	for i := len(lgc.ingestion.records) - 1; i >= 0; i-- {
+		r := lgc.ingestion.records[i]
+		if now.Sub(r.at) >= d {
+			break
+		}
+		if inputID == "" || r.inputID == inputID {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// newMeter returns a meter of the ingested messages.
+// If inputID isn't empty, the meter of the input's messages is returned.
+// Unlike Graylog, the rates are not exponentially weighted moving averages
+// but simple averages in the last 1, 5 and 15 minutes.
+// The caller must hold ingestionMutex.
+func (lgc *Logic) newMeter(inputID string) *graylog.Meter {
+	count := lgc.ingestion.count
+	if inputID != "" {
+		count = lgc.ingestion.inputCount[inputID]
+	}
+	meter := &graylog.Meter{
+		Count:   count,
+		M1Rate:  float64(lgc.countIngestion(inputID, time.Minute)) / 60,
+		M5Rate:  float64(lgc.countIngestion(inputID, 5*time.Minute)) / 300,
+		M15Rate: float64(lgc.countIngestion(inputID, 15*time.Minute)) / 900,
+		Units:   "events/second",
+	}
+	if elapsed := lgc.now().Sub(lgc.startedAt).Seconds(); elapsed > 0 {
+		meter.MeanRate = float64(count) / elapsed
+	}
+	return meter
+}
+
+// inputMeterName returns the name of an input's incoming messages meter.
+func inputMeterName(input *graylog.Input) string {
+	return fmt.Sprintf("%s.%s.incomingMessages", input.Type(), input.ID)
+}
+
+// getMetric returns a metric.
+// If the metric isn't found, nil is returned.
+// The mock server supports the following metrics.
+//
+//   org.graylog2.throughput.input.1-sec-interval (gauge)
+//   org.graylog2.throughput.output.1-sec-interval (gauge)
+//   org.graylog2.throughput.input (meter)
+//   org.graylog2.throughput.output (meter)
+//   org.graylog2.throughput.input.total (counter)
+//   org.graylog2.shared.buffers.processors.ProcessBufferProcessor.processTime (timer)
+//   org.graylog2.journal.size (gauge)
+//   <input type>.<input id>.incomingMessages (meter)
+func (lgc *Logic) getMetric(name string) (*graylog.Metric, error) {
+	lgc.ingestionMutex.Lock()
+	defer lgc.ingestionMutex.Unlock()
+	metric := &graylog.Metric{FullName: name}
+	switch name {
+	case inputThroughputMetricName, outputThroughputMetricName:
+		metric.Type = graylog.MetricTypeGauge
+		metric.Gauge = &graylog.Gauge{Value: lgc.countIngestion("", time.Second)}
+	case inputMeterMetricName, outputMeterMetricName:
+		metric.Type = graylog.MetricTypeMeter
+		metric.Meter = lgc.newMeter("")
+	case inputCounterMetricName:
+		metric.Type = graylog.MetricTypeCounter
+		metric.Counter = &graylog.Counter{Count: lgc.ingestion.count}
+	case processTimeMetricName:
+		// the mock server processes a message instantly
+		meter := lgc.newMeter("")
+		metric.Type = graylog.MetricTypeTimer
+		metric.Timer = &graylog.Timer{
+			Count: meter.Count, MeanRate: meter.MeanRate,
+			M1Rate: meter.M1Rate, M5Rate: meter.M5Rate, M15Rate: meter.M15Rate,
+			DurationUnits: "microseconds", RateUnits: "events/second"}
+	case journalSizeMetricName:
+		metric.Type = graylog.MetricTypeGauge
+		metric.Gauge = &graylog.Gauge{Value: lgc.ingestion.size}
+	default:
+		inputs, _, err := lgc.store.GetInputs()
+		if err != nil {
+			return nil, err
+		}
+		for i := range inputs {
+			if inputMeterName(&inputs[i]) == name {
+				metric.Type = graylog.MetricTypeMeter
+				metric.Meter = lgc.newMeter(inputs[i].ID)
+				break
+			}
+		}
+		if metric.Type == "" {
+			return nil, nil
+		}
+	}
+	metric.Name = name[strings.LastIndex(name, ".")+1:]
+	return metric, nil
+}
+
+// GetMetric returns a metric.
+func (lgc *Logic) GetMetric(name string) (*graylog.Metric, int, error) {
+	if name == "" {
+		return nil, 400, fmt.Errorf("metric name is empty")
+	}
+	metric, err := lgc.getMetric(name)
+	if err != nil {
+		return nil, 500, err
+	}
+	if metric == nil {
+		return nil, 404, fmt.Errorf("no metric <%s> found", name)
+	}
+	return metric, 200, nil
+}
+
+// GetMetrics returns given metrics.
+// Unknown metrics are ignored as Graylog does.
+func (lgc *Logic) GetMetrics(names []string) ([]graylog.Metric, int, error) {
+	metrics := []graylog.Metric{}
+	for _, name := range names {
+		metric, err := lgc.getMetric(name)
+		if err != nil {
+			return nil, 500, err
+		}
+		if metric != nil {
+			metrics = append(metrics, *metric)
+		}
+	}
+	return metrics, 200, nil
+}
+
+// GetThroughput returns the number of messages ingested in the last second.
+func (lgc *Logic) GetThroughput() (int, int, error) {
+	lgc.ingestionMutex.Lock()
+	defer lgc.ingestionMutex.Unlock()
+	return lgc.countIngestion("", time.Second), 200, nil
+}
+
+// GetJournal returns the message journal's status.
+// The mock server's journal keeps all ingested messages and commits them instantly.
+func (lgc *Logic) GetJournal() (*graylog.JournalSummary, int, error) {
+	lgc.ingestionMutex.Lock()
+	defer lgc.ingestionMutex.Unlock()
+	throughput := lgc.countIngestion("", time.Second)
+	return &graylog.JournalSummary{
+		Enabled:               true,
+		AppendEventsPerSecond: throughput,
+		ReadEventsPerSecond:   throughput,
+		JournalSize:           lgc.ingestion.size,
+		JournalSizeLimit:      journalSizeLimit,
+		NumberOfSegments:      1,
+		OldestSegment:         lgc.startedAt.UTC().Format(graylog.CreationDateFormat),
+		JournalConfig: &graylog.JournalConfig{
+			Directory:     "/usr/share/graylog/data/journal",
+			SegmentSize:   100 * 1024 * 1024,
+			SegmentAge:    int64(time.Hour / time.Millisecond),
+			MaxSize:       journalSizeLimit,
+			MaxAge:        int64(12 * time.Hour / time.Millisecond),
+			FlushInterval: 1000000,
+			FlushAge:      int64(time.Minute / time.Millisecond),
+		},
+	}, 200, nil
+}
@@ -0,0 +1,112 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetThroughput(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	lgc.SetClock(func() time.Time { return now })
+	input := testutil.Input()
+	if _, err := lgc.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, _, err := lgc.IngestMessage(input.ID, map[string]interface{}{"message": "hello"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	throughput, _, err := lgc.GetThroughput()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if throughput != 3 {
+		t.Fatalf("throughput == %d, wanted 3", throughput)
+	}
+	now = now.Add(2 * time.Second)
+	throughput, _, err = lgc.GetThroughput()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if throughput != 0 {
+		t.Fatalf("throughput == %d, wanted 0", throughput)
+	}
+	journal, _, err := lgc.GetJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if journal.JournalSize == 0 {
+		t.Fatal("journal.JournalSize should be the size of the ingested messages")
+	}
+}
+
+func TestGetMetric(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := lgc.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.IngestMessage(input.ID, map[string]interface{}{"message": "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	metric, _, err := lgc.GetMetric("org.graylog2.throughput.input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metric.Meter == nil || metric.Meter.Count != 1 {
+		t.Fatalf("metric.Meter == %+v, wanted the count 1", metric.Meter)
+	}
+	if metric.Meter.M1Rate == 0 {
+		t.Fatal("metric.Meter.M1Rate should be positive")
+	}
+	metric, _, err = lgc.GetMetric(input.Type() + "." + input.ID + ".incomingMessages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metric.Meter.Count != 1 {
+		t.Fatalf("metric.Meter.Count == %d, wanted 1", metric.Meter.Count)
+	}
+	metric, _, err = lgc.GetMetric("org.graylog2.throughput.input.total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metric.Counter == nil || metric.Counter.Count != 1 {
+		t.Fatalf("metric.Counter == %+v, wanted the count 1", metric.Counter)
+	}
+	if _, sc, err := lgc.GetMetric("foo"); err == nil {
+		t.Fatal(`no metric "foo"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestGetMetrics(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics, _, err := lgc.GetMetrics([]string{
+		"org.graylog2.throughput.input",
+		"org.graylog2.shared.buffers.processors.ProcessBufferProcessor.processTime",
+		"foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("len(metrics) == %d, wanted 2", len(metrics))
+	}
+	if metrics[1].Timer == nil {
+		t.Fatal("metrics[1].Timer is nil")
+	}
+}
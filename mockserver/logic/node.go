@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/seed"
+)
+
+// SetNodes sets the simulated nodes of the cluster.
+// The first node is the node which serves the API.
+// Nodes aren't persisted because Graylog nodes register themselves when they start.
+// If no node or more than one node is the master,
+// the notification "no_master" or "multi_master" is raised.
+//
+//   lgc.SetNodes(
+//   	graylog.Node{NodeID: "2ad6b340-3e5f-4a96-ae81-040cfb8b6024", IsMaster: true},
+//   	graylog.Node{NodeID: "5a1e6c2f-3b7d-4f0e-9d3f-8a4e2b1c0d9e"})
+func (lgc *Logic) SetNodes(nodes ...graylog.Node) error {
+	if len(nodes) == 0 {
+		return fmt.Errorf("at least one node is required")
+	}
+	ids := map[string]bool{}
+	arr := make([]graylog.Node, len(nodes))
+	masters := 0
+	for i, node := range nodes {
+		if node.NodeID == "" {
+			return fmt.Errorf("node id is empty")
+		}
+		if ids[node.NodeID] {
+			return fmt.Errorf("the node id <%s> is duplicated", node.NodeID)
+		}
+		ids[node.NodeID] = true
+		if node.ClusterID == "" {
+			node.ClusterID = seed.ClusterID
+		}
+		if node.ShortNodeID == "" && len(node.NodeID) >= 8 {
+			node.ShortNodeID = node.NodeID[:8]
+		}
+		if node.Type == "" {
+			node.Type = "server"
+		}
+		if node.IsMaster {
+			masters++
+		}
+		arr[i] = node
+	}
+	lgc.nodesMutex.Lock()
+	lgc.nodes = arr
+	lgc.nodesMutex.Unlock()
+	switch {
+	case masters == 0:
+		lgc.raiseNotification(
+			graylog.NotificationTypeNoMaster, graylog.NotificationSeverityUrgent, nil)
+	case masters > 1:
+		lgc.raiseNotification(
+			graylog.NotificationTypeMultiMaster, graylog.NotificationSeverityUrgent, nil)
+	}
+	return nil
+}
+
+// newNode returns a copy of a node whose last_seen is now.
+func (lgc *Logic) newNode(node graylog.Node) *graylog.Node {
+	node.LastSeen = lgc.now().UTC().Format(graylog.CreationDateFormat)
+	return &node
+}
+
+// GetNodes returns all nodes of the cluster.
+func (lgc *Logic) GetNodes() ([]graylog.Node, int, error) {
+	lgc.nodesMutex.RLock()
+	defer lgc.nodesMutex.RUnlock()
+	nodes := make([]graylog.Node, len(lgc.nodes))
+	for i, node := range lgc.nodes {
+		nodes[i] = *(lgc.newNode(node))
+	}
+	return nodes, 200, nil
+}
+
+// GetNode returns a node.
+func (lgc *Logic) GetNode(id string) (*graylog.Node, int, error) {
+	if id == "" {
+		return nil, 400, fmt.Errorf("node id is empty")
+	}
+	lgc.nodesMutex.RLock()
+	defer lgc.nodesMutex.RUnlock()
+	for _, node := range lgc.nodes {
+		if node.NodeID == id {
+			return lgc.newNode(node), 200, nil
+		}
+	}
+	return nil, 404, fmt.Errorf("node <%s> not found", id)
+}
+
+// GetCurrentNode returns the node which serves the API.
+func (lgc *Logic) GetCurrentNode() (*graylog.Node, int, error) {
+	lgc.nodesMutex.RLock()
+	defer lgc.nodesMutex.RUnlock()
+	return lgc.newNode(lgc.nodes[0]), 200, nil
+}
+
+// HasNode returns whether the node exists.
+func (lgc *Logic) HasNode(id string) bool {
+	lgc.nodesMutex.RLock()
+	defer lgc.nodesMutex.RUnlock()
+	for _, node := range lgc.nodes {
+		if node.NodeID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSystemOverview returns the overview of the node which serves the API.
+func (lgc *Logic) GetSystemOverview() (*graylog.SystemOverview, int, error) {
+	node, sc, err := lgc.GetCurrentNode()
+	if err != nil {
+		return nil, sc, err
+	}
+	return &graylog.SystemOverview{
+		Facility:        "graylog-server",
+		Codename:        "Noir",
+		NodeID:          node.NodeID,
+		ClusterID:       node.ClusterID,
+		Version:         lgc.profile.Version,
+		StartedAt:       lgc.startedAt.UTC().Format(graylog.CreationDateFormat),
+		IsProcessing:    true,
+		Hostname:        node.Hostname,
+		Lifecycle:       "running",
+		LBStatus:        "alive",
+		Timezone:        "UTC",
+		OperatingSystem: "Linux",
+	}, 200, nil
+}
+
+// checkInputNode returns an error if an input's node doesn't exist.
+// An empty node is ignored.
+func (lgc *Logic) checkInputNode(node string) (int, error) {
+	if node != "" && !lgc.HasNode(node) {
+		return 400, fmt.Errorf("node <%s> not found", node)
+	}
+	return 200, nil
+}
@@ -0,0 +1,95 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestSetNodes(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.SetNodes(); err == nil {
+		t.Fatal("at least one node is required")
+	}
+	if err := lgc.SetNodes(graylog.Node{}); err == nil {
+		t.Fatal("node id is required")
+	}
+	if err := lgc.SetNodes(
+		graylog.Node{NodeID: "foo"}, graylog.Node{NodeID: "foo"}); err == nil {
+		t.Fatal("node ids should be unique")
+	}
+	if err := lgc.SetNodes(
+		graylog.Node{NodeID: "5a1e6c2f-3b7d-4f0e-9d3f-8a4e2b1c0d9e", IsMaster: true},
+		graylog.Node{NodeID: "7c3d1f0a-2b4e-4c6d-8e9f-0a1b2c3d4e5f"}); err != nil {
+		t.Fatal(err)
+	}
+	nodes, _, err := lgc.GetNodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("len(nodes) == %d, wanted 2", len(nodes))
+	}
+	if nodes[1].ShortNodeID != "7c3d1f0a" {
+		t.Fatalf(`nodes[1].ShortNodeID == "%s", wanted "7c3d1f0a"`, nodes[1].ShortNodeID)
+	}
+	input := testutil.Input()
+	input.Node = "7c3d1f0a-2b4e-4c6d-8e9f-0a1b2c3d4e5f"
+	if _, err := lgc.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	input = testutil.Input()
+	if sc, err := lgc.AddInput(input); err == nil {
+		t.Fatalf("the node <%s> should be removed", input.Node)
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+}
+
+func TestGetNode(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, _, err := lgc.GetCurrentNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !current.IsMaster {
+		t.Fatal("the default node should be the master")
+	}
+	node, _, err := lgc.GetNode(current.NodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.NodeID != current.NodeID {
+		t.Fatalf(`node.NodeID == "%s", wanted "%s"`, node.NodeID, current.NodeID)
+	}
+	if _, sc, err := lgc.GetNode("h"); err == nil {
+		t.Fatal(`no node whose id is "h"`)
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestGetSystemOverview(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overview, _, err := lgc.GetSystemOverview()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.Lifecycle != "running" {
+		t.Fatalf(`overview.Lifecycle == "%s", wanted "running"`, overview.Lifecycle)
+	}
+	if overview.Version == "" {
+		t.Fatal("overview.Version is empty")
+	}
+}
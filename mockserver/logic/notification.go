@@ -0,0 +1,101 @@
+package logic
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// isNotificationType returns whether a given type is a known notification type.
+func isNotificationType(typ graylog.NotificationType) bool {
+	for _, t := range graylog.NotificationTypes() {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// RaiseNotification raises a system notification.
+// As Graylog does, a notification isn't raised if a notification of the same type exists,
+// and whether the notification is raised is returned.
+// If the severity, the timestamp or the node id isn't set,
+// "normal", the current time and the current node's id are set.
+//
+//   lgc.RaiseNotification(&graylog.Notification{
+//   	Type: graylog.NotificationTypeOutdatedVersion,
+//   	Severity: graylog.NotificationSeverityNormal,
+//   	Details: map[string]interface{}{"current_version": "2.4.6"},
+//   })
+func (lgc *Logic) RaiseNotification(notification *graylog.Notification) (bool, int, error) {
+	if notification == nil {
+		return false, 400, fmt.Errorf("notification is nil")
+	}
+	if !isNotificationType(notification.Type) {
+		return false, 400, fmt.Errorf("invalid notification type: %s", notification.Type)
+	}
+	if notification.Severity == "" {
+		notification.Severity = graylog.NotificationSeverityNormal
+	}
+	if notification.Severity != graylog.NotificationSeverityNormal &&
+		notification.Severity != graylog.NotificationSeverityUrgent {
+		return false, 400, fmt.Errorf("invalid notification severity: %s", notification.Severity)
+	}
+	if notification.Timestamp == "" {
+		notification.Timestamp = lgc.now().UTC().Format(graylog.CreationDateFormat)
+	}
+	if notification.NodeID == "" {
+		node, sc, err := lgc.GetCurrentNode()
+		if err != nil {
+			return false, sc, err
+		}
+		notification.NodeID = node.NodeID
+	}
+	lgc.notificationMutex.Lock()
+	defer lgc.notificationMutex.Unlock()
+	n, err := lgc.store.GetNotification(notification.Type)
+	if err != nil {
+		return false, 500, err
+	}
+	if n != nil {
+		return false, 200, nil
+	}
+	if err := lgc.store.AddNotification(notification); err != nil {
+		return false, 500, err
+	}
+	return true, 201, nil
+}
+
+// GetNotifications returns all system notifications.
+func (lgc *Logic) GetNotifications() ([]graylog.Notification, int, error) {
+	notifications, err := lgc.store.GetNotifications()
+	if err != nil {
+		return nil, 500, err
+	}
+	return notifications, 200, nil
+}
+
+// DeleteNotification deletes a system notification of a given type.
+// Even if no notification of the type exists, no error is returned as Graylog does.
+func (lgc *Logic) DeleteNotification(typ graylog.NotificationType) (int, error) {
+	if !isNotificationType(typ) {
+		return 404, fmt.Errorf("no such notification type: %s", typ)
+	}
+	if err := lgc.store.DeleteNotification(typ); err != nil {
+		return 500, err
+	}
+	return 204, nil
+}
+
+// raiseNotification raises a notification produced by a simulated failure.
+// The failure is logged if the notification can't be raised.
+func (lgc *Logic) raiseNotification(
+	typ graylog.NotificationType, severity graylog.NotificationSeverity,
+	details map[string]interface{},
+) {
+	if _, _, err := lgc.RaiseNotification(&graylog.Notification{
+		Type: typ, Severity: severity, Details: details,
+	}); err != nil {
+		lgc.Logger().WithField("error", err).Warnf("failed to raise the notification %s", typ)
+	}
+}
@@ -0,0 +1,102 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestRaiseNotification(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, _, err := lgc.RaiseNotification(&graylog.Notification{
+		Type: graylog.NotificationTypeOutdatedVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("the notification should be raised")
+	}
+	ok, _, err = lgc.RaiseNotification(&graylog.Notification{
+		Type: graylog.NotificationTypeOutdatedVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("the notification of the same type should not be raised")
+	}
+	notifications, _, err := lgc.GetNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("len(notifications) == %d, wanted 1", len(notifications))
+	}
+	n := notifications[0]
+	if n.Severity != graylog.NotificationSeverityNormal || n.NodeID == "" || n.Timestamp == "" {
+		t.Fatalf("the default values should be set: %+v", n)
+	}
+	if _, sc, err := lgc.RaiseNotification(&graylog.Notification{Type: "foo"}); err == nil {
+		t.Fatal("the notification type should be invalid")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+}
+
+func TestDeleteNotification(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.RaiseNotification(&graylog.Notification{
+		Type: graylog.NotificationTypeOutdatedVersion}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.DeleteNotification(graylog.NotificationTypeOutdatedVersion); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.DeleteNotification(graylog.NotificationTypeOutdatedVersion); err != nil {
+		t.Fatal(err)
+	}
+	if sc, err := lgc.DeleteNotification("foo"); err == nil {
+		t.Fatal("the notification type should be invalid")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+}
+
+func TestSimulatedNotifications(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := testutil.Input()
+	if _, err := lgc.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lgc.FailInput(input.ID, "failed to bind the port"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.SetNodes(graylog.Node{NodeID: "5a1e6c2f-3b7d-4f0e-9d3f-8a4e2b1c0d9e"}); err != nil {
+		t.Fatal(err)
+	}
+	notifications, _, err := lgc.GetNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := map[graylog.NotificationType]bool{}
+	for _, n := range notifications {
+		types[n.Type] = true
+	}
+	for _, typ := range []graylog.NotificationType{
+		graylog.NotificationTypeInputFailedToStart, graylog.NotificationTypeNoMaster,
+	} {
+		if !types[typ] {
+			t.Fatalf("the notification %s should be raised: %v", typ, notifications)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// defaultProfile is the profile which the mock server emulates by default.
+const defaultProfile = "2.4"
+
+// defaultFieldTypeRefreshInterval is the default field_type_refresh_interval of index sets in milliseconds.
+const defaultFieldTypeRefreshInterval = 5000
+
+// Profile is the behavior of a Graylog version which the mock server emulates.
+// Response shapes, endpoints and validation differ across Graylog versions.
+// The builtin profiles provide the same routes because the routes which the mock server implements
+// exist in both Graylog 2.4 and 3.0, so UnavailableRoutes is only for custom profiles.
+type Profile struct {
+	// the profile name such as "2.4"
+	Name string `json:"name"`
+	// the version which Get System Overview API returns
+	Version string `json:"version"`
+	// If StreamIndexSetRequired is true, index_set_id is required to create a stream.
+	// Otherwise a stream without index_set_id is assigned to the default index set.
+	StreamIndexSetRequired bool `json:"stream_index_set_required"`
+	// If FieldTypeRefreshInterval is true, index sets have field_type_refresh_interval,
+	// which was introduced with the field types at Graylog 3.0.
+	// Otherwise field_type_refresh_interval is rejected.
+	FieldTypeRefreshInterval bool `json:"field_type_refresh_interval"`
+	// the routes which the version doesn't provide, such as "GET /api/alerts/conditions".
+	// The path is a pattern whose syntax is same as path.Match .
+	// Requests to the unavailable routes fail with 404.
+	UnavailableRoutes []string `json:"unavailable_routes,omitempty"`
+}
+
+// profiles are the builtin profiles.
+// They don't set UnavailableRoutes.
+// For example the event definitions API, which replaces the alert conditions API, was introduced at Graylog 3.1,
+// so both 2.4 and 3.0 provide the alert conditions API.
+var profiles = map[string]Profile{
+	"2.4": {
+		Name:                   "2.4",
+		Version:                "2.4.3+2c41897",
+		StreamIndexSetRequired: true,
+	},
+	"3.0": {
+		Name:                     "3.0",
+		Version:                  "3.0.2+1686930",
+		FieldTypeRefreshInterval: true,
+	},
+}
+
+// GetProfile returns a builtin profile.
+func GetProfile(name string) (*Profile, error) {
+	profile, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf(
+			"unsupported Graylog version %s. The version must be either %s",
+			name, strings.Join(names, ", "))
+	}
+	return &profile, nil
+}
+
+// WithGraylogVersion sets the builtin profile of the Graylog version such as "3.0".
+// By default the profile "2.4" is used.
+//
+//   lgc, err := logic.NewLogic(nil, logic.WithGraylogVersion("3.0"))
+func WithGraylogVersion(version string) Option {
+	return func(lgc *Logic) error {
+		profile, err := GetProfile(version)
+		if err != nil {
+			return err
+		}
+		lgc.profile = *profile
+		return nil
+	}
+}
+
+// WithProfile sets a custom profile.
+func WithProfile(profile Profile) Option {
+	return func(lgc *Logic) error {
+		for _, route := range profile.UnavailableRoutes {
+			if _, _, err := parseRoute(route); err != nil {
+				return err
+			}
+		}
+		lgc.profile = profile
+		return nil
+	}
+}
+
+// Profile returns the profile which the mock server emulates.
+func (lgc *Logic) Profile() Profile {
+	return lgc.profile
+}
+
+// IsRouteAvailable returns whether the emulated version provides the route.
+func (lgc *Logic) IsRouteAvailable(method, urlPath string) bool {
+	for _, route := range lgc.profile.UnavailableRoutes {
+		m, p, err := parseRoute(route)
+		if err != nil {
+			continue
+		}
+		if m != "" && !strings.EqualFold(m, method) {
+			continue
+		}
+		if ok, err := path.Match(p, urlPath); err == nil && ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseRoute parses a route such as "GET /api/alerts/conditions".
+// If the method is omitted, all methods match.
+func parseRoute(route string) (string, string, error) {
+	fields := strings.Fields(route)
+	var m, p string
+	switch len(fields) {
+	case 1:
+		p = fields[0]
+	case 2:
+		m, p = fields[0], fields[1]
+	default:
+		return "", "", fmt.Errorf("invalid route %s", route)
+	}
+	if _, err := path.Match(p, ""); err != nil {
+		return "", "", fmt.Errorf("invalid route %s: %s", route, err)
+	}
+	return m, p, nil
+}
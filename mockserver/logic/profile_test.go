@@ -0,0 +1,96 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+	"github.com/suzuki-shunsuke/go-ptr"
+)
+
+func TestWithGraylogVersion(t *testing.T) {
+	if _, err := logic.NewLogic(nil, logic.WithGraylogVersion("1.0")); err == nil {
+		t.Fatal("unsupported version should be error")
+	}
+
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := lgc.Profile().Name; name != "2.4" {
+		t.Fatalf("profile = %s, wanted 2.4 by default", name)
+	}
+	if _, err := lgc.AddStream(testutil.Stream()); err == nil {
+		t.Fatal("index_set_id should be required at 2.4")
+	}
+	is := testutil.IndexSet("foo")
+	is.FieldTypeRefreshInterval = 1000
+	if _, err := lgc.AddIndexSet(is); err == nil {
+		t.Fatal("field_type_refresh_interval should not be supported at 2.4")
+	}
+
+	lgc, err = logic.NewLogic(nil, logic.WithGraylogVersion("3.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	overview, _, err := lgc.GetSystemOverview()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.Version != lgc.Profile().Version {
+		t.Fatalf("version = %s, wanted %s", overview.Version, lgc.Profile().Version)
+	}
+	stream := testutil.Stream()
+	if _, err := lgc.AddStream(stream); err != nil {
+		t.Fatal(err)
+	}
+	iss, _, _, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defIS := iss[0]
+	if stream.IndexSetID != defIS.ID {
+		t.Fatalf("index set id = %s, wanted the default index set %s", stream.IndexSetID, defIS.ID)
+	}
+	if defIS.FieldTypeRefreshInterval != 5000 {
+		t.Fatalf("field_type_refresh_interval = %d, wanted 5000", defIS.FieldTypeRefreshInterval)
+	}
+	prms := defIS.NewUpdateParams()
+	prms.FieldTypeRefreshInterval = ptr.PInt(1000)
+	updated, _, err := lgc.UpdateIndexSet(prms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.FieldTypeRefreshInterval != 1000 {
+		t.Fatalf("field_type_refresh_interval = %d, wanted 1000", updated.FieldTypeRefreshInterval)
+	}
+}
+
+func TestIsRouteAvailable(t *testing.T) {
+	if _, err := logic.NewLogic(nil, logic.WithProfile(logic.Profile{
+		UnavailableRoutes: []string{"GET /api/[", "foo bar baz"},
+	})); err == nil {
+		t.Fatal("invalid routes should be error")
+	}
+	lgc, err := logic.NewLogic(nil, logic.WithProfile(logic.Profile{
+		Name: "custom", UnavailableRoutes: []string{"GET /api/alerts/conditions", "/api/system/ldap/*"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []struct {
+		method string
+		path   string
+		exp    bool
+	}{
+		{"GET", "/api/alerts/conditions", false},
+		{"POST", "/api/alerts/conditions", true},
+		{"PUT", "/api/system/ldap/settings", false},
+		{"GET", "/api/streams", true},
+	}
+	for _, d := range data {
+		if ok := lgc.IsRouteAvailable(d.method, d.path); ok != d.exp {
+			t.Fatalf("IsRouteAvailable(%s, %s) = %t, wanted %t", d.method, d.path, ok, d.exp)
+		}
+	}
+}
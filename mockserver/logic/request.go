@@ -0,0 +1,207 @@
+package logic
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultRequestLogSize = 1000
+
+// RecordedRequest is a request to the Graylog API which the mock server records.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+	// the authenticated user's name. If the request isn't authenticated, User is empty.
+	User       string    `json:"user,omitempty"`
+	StatusCode int       `json:"status_code"`
+	Time       time.Time `json:"time"`
+}
+
+// requestLog is a ring buffer of recorded requests.
+type requestLog struct {
+	requests []RecordedRequest
+	// the index of the oldest request
+	head int
+	size int
+}
+
+// Expectation is an expected call to the Graylog API.
+// By default an expectation is satisfied if the call is made at least once.
+//
+//   server.ExpectCall("POST", "/api/streams").Times(1)
+//   server.ExpectCall("GET", "/api/streams/*")
+//   ...
+//   if err := server.VerifyExpectations(); err != nil {
+//     t.Fatal(err)
+//   }
+type Expectation struct {
+	method string
+	path   string
+	times  int
+	calls  int
+	mutex  sync.Mutex
+}
+
+// Times sets how many times the call should be made.
+func (exp *Expectation) Times(n int) *Expectation {
+	exp.mutex.Lock()
+	defer exp.mutex.Unlock()
+	exp.times = n
+	return exp
+}
+
+// Calls returns how many times the call is made.
+func (exp *Expectation) Calls() int {
+	exp.mutex.Lock()
+	defer exp.mutex.Unlock()
+	return exp.calls
+}
+
+// String returns the call such as "GET /api/streams/*".
+func (exp *Expectation) String() string {
+	return exp.method + " " + exp.path
+}
+
+// match returns whether the request matches the expectation.
+func (exp *Expectation) match(method, urlPath string) bool {
+	if exp.method != "" && !strings.EqualFold(exp.method, method) {
+		return false
+	}
+	ok, err := path.Match(exp.path, urlPath)
+	return err == nil && ok
+}
+
+// verify returns an error if the expectation isn't satisfied.
+func (exp *Expectation) verify() error {
+	exp.mutex.Lock()
+	defer exp.mutex.Unlock()
+	if exp.times == 0 {
+		if exp.calls == 0 {
+			return fmt.Errorf("%s is expected but not called", exp)
+		}
+		return nil
+	}
+	if exp.calls != exp.times {
+		return fmt.Errorf(
+			"%s is expected to be called %d times but called %d times",
+			exp, exp.times, exp.calls)
+	}
+	return nil
+}
+
+// SetRequestLogSize sets the max number of recorded requests.
+// When the number of requests exceeds it, the oldest request is dropped.
+// The recorded requests are removed.
+// By default the size is 1000.
+func (lgc *Logic) SetRequestLogSize(size int) {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	lgc.requestLog = requestLog{size: size}
+}
+
+// RecordRequest records a request.
+// This is called by the mock server's handlers, so usually you don't have to call this.
+func (lgc *Logic) RecordRequest(req RecordedRequest) {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	rl := &lgc.requestLog
+	if rl.size <= 0 {
+		return
+	}
+	if len(rl.requests) < rl.size {
+		rl.requests = append(rl.requests, req)
+		return
+	}
+	rl.requests[rl.head] = req
+	rl.head = (rl.head + 1) % rl.size
+}
+
+// Requests returns the recorded requests from oldest to newest.
+func (lgc *Logic) Requests() []RecordedRequest {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	rl := &lgc.requestLog
+	reqs := make([]RecordedRequest, 0, len(rl.requests))
+	reqs = append(reqs, rl.requests[rl.head:]...)
+	return append(reqs, rl.requests[:rl.head]...)
+}
+
+// ClearRequests removes the recorded requests.
+func (lgc *Logic) ClearRequests() {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	lgc.requestLog = requestLog{size: lgc.requestLog.size}
+}
+
+// ExpectCall adds an expected call.
+// The argument `urlPath` is the pattern of the path such as "/api/streams/*".
+// The syntax is same as path.Match .
+// If method is empty, all methods match.
+func (lgc *Logic) ExpectCall(method, urlPath string) *Expectation {
+	exp := &Expectation{method: method, path: urlPath}
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	lgc.expectations = append(lgc.expectations, exp)
+	return exp
+}
+
+// VerifyExpectations returns an error if some expectations aren't satisfied.
+func (lgc *Logic) VerifyExpectations() error {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	msgs := []string{}
+	for _, exp := range lgc.expectations {
+		if err := exp.verify(); err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "\n"))
+}
+
+// ClearExpectations removes all expectations.
+func (lgc *Logic) ClearExpectations() {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	lgc.expectations = nil
+}
+
+// SetStrict sets the strict mode.
+// In the strict mode, calls which don't match any expectations fail with 500.
+//
+//   server.SetStrict(true)
+//   server.ExpectCall("GET", "/api/streams")
+func (lgc *Logic) SetStrict(strict bool) {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	lgc.strict = strict
+}
+
+// CheckCall counts the call for the matched expectations.
+// In the strict mode, if the call doesn't match any expectations an error is returned.
+// This is called by the mock server's handlers, so usually you don't have to call this.
+func (lgc *Logic) CheckCall(method, urlPath string) error {
+	lgc.requestsMutex.Lock()
+	defer lgc.requestsMutex.Unlock()
+	matched := false
+	for _, exp := range lgc.expectations {
+		if !exp.match(method, urlPath) {
+			continue
+		}
+		matched = true
+		exp.mutex.Lock()
+		exp.calls++
+		exp.mutex.Unlock()
+	}
+	if lgc.strict && !matched {
+		return fmt.Errorf("unexpected call: %s %s", method, urlPath)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package logic_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+)
+
+func TestRequests(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetRequestLogSize(2)
+	for _, p := range []string{"/api/roles", "/api/users", "/api/streams"} {
+		lgc.RecordRequest(logic.RecordedRequest{Method: "GET", Path: p})
+	}
+	reqs := lgc.Requests()
+	if len(reqs) != 2 || reqs[0].Path != "/api/users" || reqs[1].Path != "/api/streams" {
+		t.Fatalf("requests = %+v, wanted the latest 2 requests", reqs)
+	}
+	lgc.ClearRequests()
+	if reqs := lgc.Requests(); len(reqs) != 0 {
+		t.Fatalf("requests = %+v, wanted empty", reqs)
+	}
+}
+
+func TestExpectCall(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := lgc.ExpectCall("POST", "/api/streams").Times(2)
+	lgc.ExpectCall("GET", "/api/streams/*")
+	if err := lgc.VerifyExpectations(); err == nil {
+		t.Fatal("expectations should not be satisfied")
+	}
+	if err := lgc.CheckCall("POST", "/api/streams"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.CheckCall("GET", "/api/streams/foo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.VerifyExpectations(); err == nil {
+		t.Fatal("POST /api/streams should be called twice")
+	}
+	if err := lgc.CheckCall("post", "/api/streams"); err != nil {
+		t.Fatal(err)
+	}
+	if exp.Calls() != 2 {
+		t.Fatalf("calls = %d, wanted 2", exp.Calls())
+	}
+	if err := lgc.VerifyExpectations(); err != nil {
+		t.Fatal(err)
+	}
+	// unexpected calls are allowed unless the strict mode
+	if err := lgc.CheckCall("DELETE", "/api/streams/foo"); err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetStrict(true)
+	if err := lgc.CheckCall("DELETE", "/api/streams/foo"); err == nil {
+		t.Fatal("unexpected calls should fail in the strict mode")
+	}
+	lgc.ClearExpectations()
+	if err := lgc.VerifyExpectations(); err != nil {
+		t.Fatal(err)
+	}
+}
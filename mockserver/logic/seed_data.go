@@ -5,7 +5,21 @@ import (
 )
 
 // InitData sets an initial data.
+// If the seed data is set by WithSeed or WithFixture, it is added to the initial data.
+// If the store already has the default index set,
+// the store is regarded as initialized and the data isn't set.
+// This prevents the persistent store such as BoltDB from being initialized twice.
 func (lgc *Logic) InitData() error {
+	id, err := lgc.store.GetDefaultIndexSetID()
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		ok, err := lgc.store.HasIndexSet(id)
+		if err != nil || ok {
+			return err
+		}
+	}
 	role := seed.Role()
 	if _, err := lgc.AddRole(role); err != nil {
 		return err
@@ -21,7 +35,7 @@ func (lgc *Logic) InitData() error {
 	if _, err := lgc.AddIndexSet(is); err != nil {
 		return err
 	}
-	is, _, err := lgc.SetDefaultIndexSet(is.ID)
+	is, _, err = lgc.SetDefaultIndexSet(is.ID)
 	if err != nil {
 		return err
 	}
@@ -32,6 +46,17 @@ func (lgc *Logic) InitData() error {
 	}
 	rule := seed.StreamRule()
 	rule.StreamID = stream.ID
-	_, err = lgc.AddStreamRule(rule)
-	return err
+	if _, err := lgc.AddStreamRule(rule); err != nil {
+		return err
+	}
+	if _, err := lgc.UpdateClusterConfig(seed.MessageProcessorsConfig()); err != nil {
+		return err
+	}
+	if _, err := lgc.UpdateClusterConfig(seed.SearchesClusterConfig()); err != nil {
+		return err
+	}
+	if lgc.fixture == nil {
+		return nil
+	}
+	return lgc.loadFixture(lgc.fixture)
 }
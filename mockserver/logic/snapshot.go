@@ -0,0 +1,271 @@
+package logic
+
+import (
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+// Snapshot is a copy of the data of the mock server's store.
+// Snapshot is encoded to JSON, so it can be saved and restored via the HTTP API.
+// Runtime states such as input states, system jobs and ingestion statistics aren't included.
+type Snapshot struct {
+	Users             []graylog.User                    `json:"users"`
+	Roles             []graylog.Role                    `json:"roles"`
+	Inputs            []graylog.Input                   `json:"inputs"`
+	IndexSets         []graylog.IndexSet                `json:"index_sets"`
+	DefaultIndexSetID string                            `json:"default_index_set_id"`
+	Indices           []store.Index                     `json:"indices"`
+	Streams           []graylog.Stream                  `json:"streams"`
+	StreamRules       []graylog.StreamRule              `json:"stream_rules"`
+	Notifications     []graylog.Notification            `json:"notifications"`
+	ClusterConfigs    map[string]map[string]interface{} `json:"cluster_configs"`
+	LDAPSettings      *graylog.LDAPSettings             `json:"ldap_settings"`
+}
+
+// Snapshot returns a copy of the data of the store.
+// API calls which change the data wait until the copy is made, so the snapshot is consistent.
+//
+//   snapshot, err := server.Snapshot()
+//   // run a test which changes the data
+//   err = server.Restore(snapshot)
+func (lgc *Logic) Snapshot() (Snapshot, error) {
+	lgc.RLock()
+	defer lgc.RUnlock()
+	var (
+		snapshot Snapshot
+		err      error
+	)
+	s := lgc.store
+	if snapshot.Users, err = s.GetUsers(); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Roles, _, err = s.GetRoles(); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Inputs, _, err = s.GetInputs(); err != nil {
+		return snapshot, err
+	}
+	if snapshot.IndexSets, _, err = s.GetIndexSets(0, 0); err != nil {
+		return snapshot, err
+	}
+	if snapshot.DefaultIndexSetID, err = s.GetDefaultIndexSetID(); err != nil {
+		return snapshot, err
+	}
+	snapshot.Indices = []store.Index{}
+	for _, is := range snapshot.IndexSets {
+		indices, err := s.GetIndices(is.ID)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.Indices = append(snapshot.Indices, indices...)
+	}
+	if snapshot.Streams, _, err = s.GetStreams(); err != nil {
+		return snapshot, err
+	}
+	snapshot.StreamRules = []graylog.StreamRule{}
+	for _, stream := range snapshot.Streams {
+		rules, _, err := s.GetStreamRules(stream.ID)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.StreamRules = append(snapshot.StreamRules, rules...)
+	}
+	if snapshot.Notifications, err = s.GetNotifications(); err != nil {
+		return snapshot, err
+	}
+	classes, err := s.GetClusterConfigClasses()
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.ClusterConfigs = make(map[string]map[string]interface{}, len(classes))
+	for _, class := range classes {
+		cfg, err := s.GetClusterConfig(class)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.ClusterConfigs[class] = cfg
+	}
+	snapshot.LDAPSettings, err = s.GetLDAPSettings()
+	return snapshot, err
+}
+
+// Restore replaces the data of the store with the snapshot
+// and resets the runtime states such as input states and system jobs.
+// The snapshot isn't changed, but the inputs' creation dates are updated
+// because the store sets them when inputs are added.
+// API calls wait until the data are restored.
+func (lgc *Logic) Restore(snapshot Snapshot) error {
+	lgc.Lock()
+	defer lgc.Unlock()
+	if err := lgc.clear(); err != nil {
+		return err
+	}
+	s := lgc.store
+	for i := range snapshot.Roles {
+		v := snapshot.Roles[i]
+		if err := s.AddRole(&v); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.Users {
+		v := snapshot.Users[i]
+		if err := s.AddUser(&v); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.Inputs {
+		v := snapshot.Inputs[i]
+		if err := s.AddInput(&v); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.IndexSets {
+		v := snapshot.IndexSets[i]
+		if err := s.AddIndexSet(&v); err != nil {
+			return err
+		}
+	}
+	if snapshot.DefaultIndexSetID != "" {
+		if err := s.SetDefaultIndexSetID(snapshot.DefaultIndexSetID); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.Indices {
+		v := snapshot.Indices[i]
+		if err := s.AddIndex(&v); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.Streams {
+		v := snapshot.Streams[i]
+		if err := s.AddStream(&v); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.StreamRules {
+		v := snapshot.StreamRules[i]
+		if err := s.AddStreamRule(&v); err != nil {
+			return err
+		}
+	}
+	for i := range snapshot.Notifications {
+		v := snapshot.Notifications[i]
+		if err := s.AddNotification(&v); err != nil {
+			return err
+		}
+	}
+	for class, cfg := range snapshot.ClusterConfigs {
+		if err := s.SetClusterConfig(class, cfg); err != nil {
+			return err
+		}
+	}
+	if snapshot.LDAPSettings != nil {
+		settings := *snapshot.LDAPSettings
+		return s.SetLDAPSettings(&settings)
+	}
+	return nil
+}
+
+// Reset removes all data of the store and sets the initial data by InitData.
+// The runtime states such as input states and system jobs are also reset.
+// API calls wait until the data are reset.
+func (lgc *Logic) Reset() error {
+	lgc.Lock()
+	defer lgc.Unlock()
+	if err := lgc.clear(); err != nil {
+		return err
+	}
+	return lgc.InitData()
+}
+
+// clear removes all data of the store and resets the runtime states.
+func (lgc *Logic) clear() error {
+	s := lgc.store
+	users, err := s.GetUsers()
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := s.DeleteUser(user.Username); err != nil {
+			return err
+		}
+	}
+	roles, _, err := s.GetRoles()
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if err := s.DeleteRole(role.Name); err != nil {
+			return err
+		}
+	}
+	inputs, _, err := s.GetInputs()
+	if err != nil {
+		return err
+	}
+	for _, input := range inputs {
+		if err := s.DeleteInput(input.ID); err != nil {
+			return err
+		}
+	}
+	indexSets, _, err := s.GetIndexSets(0, 0)
+	if err != nil {
+		return err
+	}
+	for _, is := range indexSets {
+		indices, err := s.GetIndices(is.ID)
+		if err != nil {
+			return err
+		}
+		for _, index := range indices {
+			if err := s.DeleteIndex(index.Name); err != nil {
+				return err
+			}
+		}
+		if err := s.DeleteIndexSet(is.ID); err != nil {
+			return err
+		}
+	}
+	streams, _, err := s.GetStreams()
+	if err != nil {
+		return err
+	}
+	for _, stream := range streams {
+		// the stream's rules are deleted with the stream
+		if err := s.DeleteStream(stream.ID); err != nil {
+			return err
+		}
+	}
+	notifications, err := s.GetNotifications()
+	if err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		if err := s.DeleteNotification(notification.Type); err != nil {
+			return err
+		}
+	}
+	classes, err := s.GetClusterConfigClasses()
+	if err != nil {
+		return err
+	}
+	for _, class := range classes {
+		if err := s.DeleteClusterConfig(class); err != nil {
+			return err
+		}
+	}
+	if err := s.DeleteLDAPSettings(); err != nil {
+		return err
+	}
+
+	lgc.inputStatesMutex.Lock()
+	lgc.inputStates = map[string]inputState{}
+	lgc.inputStatesMutex.Unlock()
+	lgc.systemJobsMutex.Lock()
+	lgc.systemJobs = map[string]*systemJob{}
+	lgc.systemJobsMutex.Unlock()
+	lgc.ingestionMutex.Lock()
+	lgc.ingestion = ingestionStats{inputCount: map[string]int64{}}
+	lgc.ingestionMutex.Unlock()
+	return nil
+}
@@ -0,0 +1,97 @@
+package logic_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestSnapshot(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := lgc.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Users) != 2 || len(snapshot.IndexSets) != 1 || len(snapshot.StreamRules) != 1 {
+		t.Fatalf("snapshot = %+v, wanted the initial data", snapshot)
+	}
+	if snapshot.DefaultIndexSetID != snapshot.IndexSets[0].ID {
+		t.Fatalf("default index set id = %s, wanted %s", snapshot.DefaultIndexSetID, snapshot.IndexSets[0].ID)
+	}
+	// the snapshot can be encoded to JSON
+	b, err := json.Marshal(&snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := logic.Snapshot{}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := lgc.AddRole(testutil.Role()); err != nil {
+		t.Fatal(err)
+	}
+	input := snapshot.Inputs[0]
+	if _, err := lgc.DeleteInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.Restore(decoded); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := lgc.HasRole(testutil.Role().Name); err != nil || ok {
+		t.Fatalf("the added role should be removed: %v, %v", ok, err)
+	}
+	in, _, err := lgc.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in.Type() != input.Type() {
+		t.Fatalf("input type = %s, wanted %s", in.Type(), input.Type())
+	}
+	rules, _, _, err := lgc.GetStreamRules(snapshot.Streams[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("len(rules) = %d, wanted 1", len(rules))
+	}
+	if _, _, err := lgc.Authenticate("admin", "admin"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.SetDefaultIndexSet(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := lgc.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	iss, _, _, err := lgc.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(iss) != 1 || iss[0].IndexPrefix == "hoge" || !iss[0].Default {
+		t.Fatalf("index sets = %+v, wanted only the initial default index set", iss)
+	}
+	users, _, err := lgc.GetUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("len(users) = %d, wanted 2", len(users))
+	}
+}
@@ -31,6 +31,16 @@ func (lgc *Logic) GetStream(id string) (*graylog.Stream, int, error) {
 
 // AddStream adds a stream to the Server.
 func (lgc *Logic) AddStream(stream *graylog.Stream) (int, error) {
+	if stream == nil {
+		return 400, fmt.Errorf("stream is nil")
+	}
+	if stream.IndexSetID == "" && !lgc.profile.StreamIndexSetRequired {
+		id, err := lgc.store.GetDefaultIndexSetID()
+		if err != nil {
+			return 500, err
+		}
+		stream.IndexSetID = id
+	}
 	if err := validator.CreateValidator.Struct(stream); err != nil {
 		return 400, err
 	}
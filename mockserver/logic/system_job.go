@@ -0,0 +1,190 @@
+package logic
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/seed"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+const (
+	rebuildIndexRangesJobName = "org.graylog2.indexer.ranges.RebuildIndexRangesJob"
+	setIndexReadOnlyJobName   = "org.graylog2.indexer.indices.jobs.SetIndexReadOnlyAndCalculateRangeJob"
+)
+
+// systemJob is a long-running operation such as an index ranges rebuild.
+// System jobs aren't persisted because Graylog keeps them in memory.
+type systemJob struct {
+	id          string
+	name        string
+	description string
+	cancelable  bool
+	startedAt   time.Time
+	run         func() error
+}
+
+// SetSystemJobDuration sets how long a system job takes to finish.
+// While a system job is running, its progress increases from 0 to 100 percent
+// in proportion to the elapsed time, and the job's work is done when the job finishes.
+// By default this is 0, which means a system job finishes immediately.
+//
+//   lgc.SetSystemJobDuration(time.Second)
+func (lgc *Logic) SetSystemJobDuration(d time.Duration) {
+	lgc.systemJobsMutex.Lock()
+	defer lgc.systemJobsMutex.Unlock()
+	lgc.systemJobDuration = d
+}
+
+// runSystemJob starts a system job and returns its id.
+// If the system job duration is 0, the job is done synchronously
+// and the job's error is returned.
+func (lgc *Logic) runSystemJob(
+	name, description string, cancelable bool, f func() error,
+) (string, error) {
+	job := &systemJob{
+		id: store.NewObjectID(), name: name, description: description,
+		cancelable: cancelable, startedAt: lgc.now(), run: f}
+	lgc.systemJobsMutex.Lock()
+	if lgc.systemJobDuration <= 0 {
+		lgc.systemJobsMutex.Unlock()
+		return job.id, f()
+	}
+	lgc.systemJobs[job.id] = job
+	lgc.systemJobsMutex.Unlock()
+	return job.id, nil
+}
+
+// percentComplete returns a system job's progress.
+// The caller must hold systemJobsMutex.
+func (lgc *Logic) percentComplete(job *systemJob) int {
+	if lgc.systemJobDuration <= 0 {
+		return 100
+	}
+	p := int(lgc.now().Sub(job.startedAt) * 100 / lgc.systemJobDuration)
+	if p > 100 {
+		return 100
+	}
+	if p < 0 {
+		return 0
+	}
+	return p
+}
+
+// RunSystemJobs does the work of the system jobs which have finished
+// and removes them, as Graylog's job runner does.
+// The number of the finished jobs is returned.
+// The jobs change the data, so the caller must hold the lock with Lock
+// and save the data afterwards.
+// The mock server runs the finished jobs periodically and before each API call which changes the data,
+// so usually you don't have to call this.
+// Call this after advancing the clock to make finished jobs take effect.
+//
+//   server.Lock()
+//   defer server.Unlock()
+//   if server.RunSystemJobs() != 0 {
+//   	err := server.Save()
+//   }
+func (lgc *Logic) RunSystemJobs() int {
+	lgc.indexMutex.Lock()
+	defer lgc.indexMutex.Unlock()
+	lgc.systemJobsMutex.Lock()
+	finished := []*systemJob{}
+	for id, job := range lgc.systemJobs {
+		if lgc.percentComplete(job) >= 100 {
+			finished = append(finished, job)
+			delete(lgc.systemJobs, id)
+		}
+	}
+	lgc.systemJobsMutex.Unlock()
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].startedAt.Before(finished[j].startedAt)
+	})
+	for _, job := range finished {
+		if err := job.run(); err != nil {
+			lgc.Logger().WithFields(log.Fields{
+				"error": err, "id": job.id, "name": job.name,
+			}).Warn("system job failed")
+		}
+	}
+	return len(finished)
+}
+
+// getRunningSystemJob returns a system job which hasn't finished.
+// A finished job whose work hasn't been done by RunSystemJobs yet isn't returned.
+// The caller must hold systemJobsMutex.
+func (lgc *Logic) getRunningSystemJob(id string) (*systemJob, bool) {
+	job, ok := lgc.systemJobs[id]
+	if !ok || lgc.percentComplete(job) >= 100 {
+		return nil, false
+	}
+	return job, true
+}
+
+// newSystemJob converts a systemJob to a SystemJob.
+// The caller must hold systemJobsMutex.
+func (lgc *Logic) newSystemJob(job *systemJob) *graylog.SystemJob {
+	return &graylog.SystemJob{
+		ID:               job.id,
+		Name:             job.name,
+		Description:      job.description,
+		NodeID:           seed.NodeID,
+		StartedAt:        job.startedAt.UTC().Format(graylog.CreationDateFormat),
+		PercentComplete:  lgc.percentComplete(job),
+		IsCancelable:     job.cancelable,
+		ProvidesProgress: true,
+	}
+}
+
+// GetSystemJobs returns all running system jobs.
+// This doesn't run the finished jobs, so this doesn't change the data.
+func (lgc *Logic) GetSystemJobs() ([]graylog.SystemJob, int, error) {
+	lgc.systemJobsMutex.RLock()
+	defer lgc.systemJobsMutex.RUnlock()
+	jobs := make([]graylog.SystemJob, 0, len(lgc.systemJobs))
+	for id := range lgc.systemJobs {
+		if job, ok := lgc.getRunningSystemJob(id); ok {
+			jobs = append(jobs, *(lgc.newSystemJob(job)))
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt < jobs[j].StartedAt
+	})
+	return jobs, 200, nil
+}
+
+// GetSystemJob returns a running system job.
+// A finished system job isn't found, as Graylog does.
+func (lgc *Logic) GetSystemJob(id string) (*graylog.SystemJob, int, error) {
+	if id == "" {
+		return nil, 400, fmt.Errorf("system job id is empty")
+	}
+	lgc.systemJobsMutex.RLock()
+	defer lgc.systemJobsMutex.RUnlock()
+	job, ok := lgc.getRunningSystemJob(id)
+	if !ok {
+		return nil, 404, fmt.Errorf("no system job with ID <%s> found", id)
+	}
+	return lgc.newSystemJob(job), 200, nil
+}
+
+// CancelSystemJob cancels a running system job, so the job's work isn't done.
+func (lgc *Logic) CancelSystemJob(id string) (*graylog.SystemJob, int, error) {
+	if id == "" {
+		return nil, 400, fmt.Errorf("system job id is empty")
+	}
+	lgc.systemJobsMutex.Lock()
+	defer lgc.systemJobsMutex.Unlock()
+	job, ok := lgc.getRunningSystemJob(id)
+	if !ok {
+		return nil, 404, fmt.Errorf("no system job with ID <%s> found", id)
+	}
+	if !job.cancelable {
+		return nil, 400, fmt.Errorf("the system job <%s> is not cancelable", id)
+	}
+	delete(lgc.systemJobs, id)
+	return lgc.newSystemJob(job), 200, nil
+}
@@ -0,0 +1,128 @@
+package logic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestGetSystemJobs(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	lgc.SetClock(func() time.Time { return now })
+	lgc.SetSystemJobDuration(10 * time.Second)
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	jobID, _, err := lgc.RebuildIndexSetIndexRanges(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(5 * time.Second)
+	jobs, _, err := lgc.GetSystemJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("len(jobs) == %d, wanted 1", len(jobs))
+	}
+	if jobs[0].ID != jobID {
+		t.Fatalf(`jobs[0].ID == "%s", wanted "%s"`, jobs[0].ID, jobID)
+	}
+	if jobs[0].PercentComplete != 50 {
+		t.Fatalf("jobs[0].PercentComplete == %d, wanted 50", jobs[0].PercentComplete)
+	}
+	r, _, err := lgc.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.CalculatedAt != "2018-01-01T00:00:00.000Z" {
+		t.Fatalf("the range should not be calculated until the job finishes: %s", r.CalculatedAt)
+	}
+	now = now.Add(5 * time.Second)
+	if _, sc, err := lgc.GetSystemJob(jobID); err == nil {
+		t.Fatal("the finished job should not be found")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+	r, _, err = lgc.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.CalculatedAt != "2018-01-01T00:00:00.000Z" {
+		t.Fatalf("reading the system jobs should not run the finished job: %s", r.CalculatedAt)
+	}
+	if n := lgc.RunSystemJobs(); n != 1 {
+		t.Fatalf("RunSystemJobs() == %d, wanted 1", n)
+	}
+	r, _, err = lgc.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.CalculatedAt != "2018-01-01T00:00:10.000Z" {
+		t.Fatalf(`r.CalculatedAt == "%s", wanted "2018-01-01T00:00:10.000Z"`, r.CalculatedAt)
+	}
+}
+
+func TestGetSystemJob(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetSystemJobDuration(time.Hour)
+	jobID, _, err := lgc.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, _, err := lgc.GetSystemJob(jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !job.IsCancelable {
+		t.Fatal("the rebuild job should be cancelable")
+	}
+	if _, sc, err := lgc.GetSystemJob(""); err == nil {
+		t.Fatal("id is required")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+}
+
+func TestCancelSystemJob(t *testing.T) {
+	lgc, err := logic.NewLogic(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lgc.SetSystemJobDuration(time.Hour)
+	is := testutil.IndexSet("hoge")
+	if _, err := lgc.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	jobID, _, err := lgc.RebuildIndexRanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lgc.CancelSystemJob(jobID); err != nil {
+		t.Fatal(err)
+	}
+	if _, sc, err := lgc.GetSystemJob(jobID); err == nil {
+		t.Fatal("the canceled job should not be found")
+	} else if sc != 404 {
+		t.Fatalf("status code == %d, wanted 404", sc)
+	}
+	cycleJobID, _, err := lgc.CycleDeflector(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, sc, err := lgc.CancelSystemJob(cycleJobID); err == nil {
+		t.Fatal("the job should not be cancelable")
+	} else if sc != 400 {
+		t.Fatalf("status code == %d, wanted 400", sc)
+	}
+}
@@ -0,0 +1,132 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"gopkg.in/yaml.v2"
+)
+
+// Fixture is the seed data which is loaded from a YAML or JSON file.
+// The fields are same as Graylog API's request bodies,
+// but objects refer to other objects by name instead of ObjectID.
+//
+//   roles:
+//   - name: Reader
+//     permissions: ["streams:read"]
+//   users:
+//   - username: foo
+//     password: password
+//     email: foo@example.com
+//     full_name: foo
+//     roles: [Reader]
+//   index_sets:
+//   - title: app logs
+//     index_prefix: app
+//   streams:
+//   - title: errors
+//     index_set: app logs
+//     rules:
+//     - field: level
+//       type: 1
+//       value: error
+type Fixture struct {
+	Roles     []graylog.Role    `json:"roles"`
+	Users     []graylog.User    `json:"users"`
+	IndexSets []FixtureIndexSet `json:"index_sets"`
+	Inputs    []graylog.Input   `json:"inputs"`
+	Streams   []FixtureStream   `json:"streams"`
+}
+
+// FixtureIndexSet is an index set of Fixture.
+// The rotation and retention strategies are same as the default index set's ones if they are omitted.
+// If `default` is true, the index set becomes the default index set.
+type FixtureIndexSet struct {
+	graylog.IndexSet
+	// If Writable is nil, the index set is writable.
+	Writable *bool `json:"writable"`
+}
+
+// FixtureStream is a stream of Fixture.
+// The stream rules and alert conditions are created with the stream.
+type FixtureStream struct {
+	graylog.Stream
+	// the title of the index set which the stream belongs to.
+	// If IndexSet is empty, the default index set is used.
+	IndexSet string `json:"index_set"`
+}
+
+// LoadFixture reads a YAML or JSON fixture file.
+func LoadFixture(path string) (*Fixture, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture, err := ParseFixture(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the fixture file %s: %s", path, err)
+	}
+	return fixture, nil
+}
+
+// ParseFixture parses YAML or JSON as Fixture.
+// JSON is parsed as YAML because YAML is a superset of JSON.
+// Unknown fields are rejected to find typos.
+func ParseFixture(b []byte) (*Fixture, error) {
+	var data interface{}
+	if err := yaml.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	data, err := convertYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	// convert to JSON to use the json tags of the graylog package
+	j, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	fixture := &Fixture{}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(fixture); err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
+
+// convertYAML converts maps which the yaml package decodes
+// to maps whose keys are strings so that they can be encoded to JSON.
+func convertYAML(data interface{}) (interface{}, error) {
+	switch d := data.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("the key of an object must be a string: %v", k)
+			}
+			val, err := convertYAML(v)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = val
+		}
+		return m, nil
+	case []interface{}:
+		arr := make([]interface{}, len(d))
+		for i, v := range d {
+			val, err := convertYAML(v)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = val
+		}
+		return arr, nil
+	default:
+		return data, nil
+	}
+}
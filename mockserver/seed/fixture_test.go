@@ -0,0 +1,69 @@
+package seed_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/seed"
+)
+
+func TestParseFixture(t *testing.T) {
+	fixture, err := seed.ParseFixture([]byte(`
+roles:
+- name: Reader
+  permissions: ["streams:read"]
+index_sets:
+- title: app logs
+  index_prefix: app
+  writable: false
+streams:
+- title: errors
+  index_set: app logs
+  rules:
+  - field: level
+    type: 1
+    value: error
+  alert_conditions:
+  - type: field_content_value
+    title: error
+    parameters:
+      field: level
+      value: error
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.Roles) != 1 || fixture.Roles[0].Name != "Reader" {
+		t.Fatalf("roles = %+v", fixture.Roles)
+	}
+	if len(fixture.IndexSets) != 1 || fixture.IndexSets[0].IndexPrefix != "app" {
+		t.Fatalf("index sets = %+v", fixture.IndexSets)
+	}
+	if w := fixture.IndexSets[0].Writable; w == nil || *w {
+		t.Fatal("writable should be false")
+	}
+	if len(fixture.Streams) != 1 {
+		t.Fatalf("streams = %+v", fixture.Streams)
+	}
+	stream := fixture.Streams[0]
+	if stream.IndexSet != "app logs" || len(stream.Rules) != 1 || len(stream.AlertConditions) != 1 {
+		t.Fatalf("stream = %+v", stream)
+	}
+	if stream.AlertConditions[0].Parameters.Field != "level" {
+		t.Fatalf("alert condition = %+v", stream.AlertConditions[0])
+	}
+
+	fixture, err = seed.ParseFixture([]byte(`{"users": [{"username": "foo", "roles": ["Reader"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.Users) != 1 || fixture.Users[0].Username != "foo" {
+		t.Fatalf("users = %+v", fixture.Users)
+	}
+
+	if _, err := seed.ParseFixture([]byte("roles:\n- nme: Reader\n")); err == nil {
+		t.Fatal("an unknown field should be rejected")
+	}
+	if _, err := seed.LoadFixture("nonexistent.yaml"); err == nil {
+		t.Fatal("nonexistent file should be error")
+	}
+}
@@ -5,6 +5,26 @@ import (
 	"github.com/suzuki-shunsuke/go-set"
 )
 
+const (
+	// NodeID is the id of the mock server's node.
+	NodeID = "2ad6b340-3e5f-4a96-ae81-040cfb8b6024"
+	// ClusterID is the id of the mock server's cluster.
+	ClusterID = "c0fe7b5a-3b3c-4a7e-8d3e-0d0ef1a3b8c4"
+)
+
+// Node returns the mock server's node.
+func Node() *graylog.Node {
+	return &graylog.Node{
+		ClusterID:        ClusterID,
+		NodeID:           NodeID,
+		ShortNodeID:      NodeID[:8],
+		Type:             "server",
+		TransportAddress: "http://127.0.0.1:9000/api/",
+		Hostname:         "graylog",
+		IsMaster:         true,
+	}
+}
+
 // Role returns a Role.
 func Role() *graylog.Role {
 	return &graylog.Role{
@@ -41,7 +61,7 @@ func Nobody() *graylog.User {
 func Input() *graylog.Input {
 	return &graylog.Input{
 		Title: "test",
-		Node:  "2ad6b340-3e5f-4a96-ae81-040cfb8b6024",
+		Node:  NodeID,
 		Attrs: &graylog.InputBeatsAttrs{
 			BindAddress:    "0.0.0.0",
 			Port:           514,
@@ -100,3 +120,44 @@ func StreamRule() *graylog.StreamRule {
 		Field: "tag",
 	}
 }
+
+// MessageProcessorsConfig returns the default message processors configuration.
+func MessageProcessorsConfig() *graylog.MessageProcessorsConfig {
+	return &graylog.MessageProcessorsConfig{
+		ProcessorOrder: []string{
+			"org.graylog2.messageprocessors.MessageFilterChainProcessor"},
+		DisabledProcessors: []string{},
+	}
+}
+
+// SearchesClusterConfig returns the default search configuration.
+func SearchesClusterConfig() *graylog.SearchesClusterConfig {
+	return &graylog.SearchesClusterConfig{
+		QueryTimeRangeLimit: "P0D",
+		RelativeTimerangeOptions: map[string]string{
+			"PT5M":  "Search in the last 5 minutes",
+			"PT15M": "Search in the last 15 minutes",
+			"PT30M": "Search in the last 30 minutes",
+			"PT1H":  "Search in the last 1 hour",
+			"PT2H":  "Search in the last 2 hours",
+			"PT8H":  "Search in the last 8 hours",
+			"P1D":   "Search in the last 1 day",
+			"P2D":   "Search in the last 2 days",
+			"P5D":   "Search in the last 5 days",
+			"P7D":   "Search in the last 7 days",
+			"P14D":  "Search in the last 14 days",
+			"P30D":  "Search in the last 30 days",
+			"PT0S":  "Search in all messages",
+		},
+		SurroundingTimerangeOptions: map[string]string{
+			"PT1S":  "1 second",
+			"PT5S":  "5 seconds",
+			"PT10S": "10 seconds",
+			"PT30S": "30 seconds",
+			"PT1M":  "1 minute",
+			"PT5M":  "5 minutes",
+		},
+		SurroundingFilterFields: []string{
+			"file", "source", "gl2_source_input", "source_file"},
+	}
+}
@@ -3,7 +3,10 @@ package mockserver
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"net/http/httptest"
+	"sync"
+	"time"
 
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/handler"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/logic"
@@ -18,8 +21,14 @@ type Server struct {
 	*logic.Logic `json:"-"`
 	server       *httptest.Server
 	endpoint     string
+
+	stopJobRunner chan struct{}
+	jobRunnerWG   sync.WaitGroup
 }
 
+// systemJobRunInterval is the interval at which the server runs the finished system jobs.
+const systemJobRunInterval = 100 * time.Millisecond
+
 // NewServer returns new Server but doesn't start it.
 // The argument `addr` is the port number which the server uses.
 //
@@ -28,15 +37,19 @@ type Server struct {
 // If addr is an empty string, the free port is assigned automatially.
 // The argument `store` is the store which the server uses.
 // If `store` is nil, the default plain store is used and data is not persisted.
+// The options are passed to logic.NewLogic.
+//
+//   server, err := mockserver.NewServer(":8000", nil, mockserver.WithSeed("fixtures.yaml"))
+//
 // To start the server, call the Start method.
 //
 //   server.Start()
 //   defer server.Close()
-func NewServer(addr string, store store.Store) (*Server, error) {
+func NewServer(addr string, store store.Store, opts ...logic.Option) (*Server, error) {
 	if store == nil {
 		store = plain.NewStore("")
 	}
-	srv, err := logic.NewLogic(store)
+	srv, err := logic.NewLogic(store, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -55,17 +68,81 @@ func NewServer(addr string, store store.Store) (*Server, error) {
 	return ms, nil
 }
 
+// WithSeed loads the seed data from a YAML or JSON fixture file.
+// See https://godoc.org/github.com/suzuki-shunsuke/go-graylog/mockserver/seed#Fixture
+func WithSeed(path string) logic.Option {
+	return logic.WithSeed(path)
+}
+
+// WithGraylogVersion sets the Graylog version such as "3.0" which the mock server emulates.
+// See https://godoc.org/github.com/suzuki-shunsuke/go-graylog/mockserver/logic#Profile
+func WithGraylogVersion(version string) logic.Option {
+	return logic.WithGraylogVersion(version)
+}
+
 // Start starts a server from NewUnstartedServer.
+// While the server is running, the finished system jobs are run periodically
+// as Graylog's job runner does.
 func (ms *Server) Start() {
 	ms.server.Start()
+	ms.stopJobRunner = make(chan struct{})
+	ms.jobRunnerWG.Add(1)
+	go ms.runSystemJobs(ms.stopJobRunner)
 }
 
 // Close shuts down the server and blocks until all outstanding requests on this server have completed.
 func (ms *Server) Close() {
 	ms.Logger().Info("Close Server")
+	if ms.stopJobRunner != nil {
+		close(ms.stopJobRunner)
+		ms.stopJobRunner = nil
+		ms.jobRunnerWG.Wait()
+	}
 	ms.server.Close()
 }
 
+// runSystemJobs runs the finished system jobs and saves the data periodically until stop is closed.
+func (ms *Server) runSystemJobs(stop <-chan struct{}) {
+	defer ms.jobRunnerWG.Done()
+	ticker := time.NewTicker(systemJobRunInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ms.runFinishedSystemJobs()
+		}
+	}
+}
+
+// runFinishedSystemJobs runs the finished system jobs while the data is locked.
+func (ms *Server) runFinishedSystemJobs() {
+	ms.Lock()
+	defer ms.Unlock()
+	if ms.RunSystemJobs() == 0 {
+		return
+	}
+	if err := ms.Save(); err != nil {
+		ms.Logger().WithField("error", err).Warn("failed to save the data after running the system jobs")
+	}
+}
+
+// Handler returns the server's HTTP handler.
+func (ms *Server) Handler() http.Handler {
+	return ms.server.Config.Handler
+}
+
+// SetHandler sets the server's HTTP handler.
+// This is used to wrap the mock server's handler, for example to proxy requests to a real Graylog.
+// Call this before the server starts.
+//
+//   rec, err := cassette.NewRecorder("https://graylog.example.com/api", "cassette.json", server.Handler())
+//   server.SetHandler(rec)
+func (ms *Server) SetHandler(h http.Handler) {
+	ms.server.Config.Handler = h
+}
+
 // Endpoint returns the endpoint url.
 //
 //   server, err := mockserver.NewServer(":8000", nil)
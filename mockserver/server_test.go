@@ -2,8 +2,11 @@ package mockserver_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/suzuki-shunsuke/go-graylog/client"
 	"github.com/suzuki-shunsuke/go-graylog/mockserver"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
 )
 
 func TestNewServer(t *testing.T) {
@@ -20,3 +23,43 @@ func TestNewServer(t *testing.T) {
 		t.Fatal("endpoint is empty")
 	}
 }
+
+func TestServerRunSystemJobs(t *testing.T) {
+	server, err := mockserver.NewServer("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Start()
+	defer server.Close()
+	server.SetAuth(false)
+	cl, err := client.NewClient(server.Endpoint(), "admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if _, err := cl.CreateIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	r, _, err := cl.GetIndexRange("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetSystemJobDuration(50 * time.Millisecond)
+	jobID, _, err := cl.RebuildIndexSetIndexRanges(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the finished job is run in the background without any API call which changes the data
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+		calculated, _, err := cl.GetIndexRange("hoge_0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calculated.CalculatedAt != r.CalculatedAt {
+			return
+		}
+	}
+	t.Fatalf("the system job <%s> should be run in the background", jobID)
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/permission"
+)
+
+// Authorize authorizes the user with the roles of the store.
+// The user's permissions and the roles' permissions are matched
+// with Graylog's wildcard permission semantics.
+// Store implementations can use this function to implement Store.Authorize.
+func Authorize(s Store, user *graylog.User, scope string, args ...string) (bool, error) {
+	if user == nil {
+		return true, nil
+	}
+	// empty arguments such as an empty id are omitted,
+	// because "scope:action:" isn't a valid permission
+	parts := []string{scope}
+	for _, arg := range args {
+		if arg != "" {
+			parts = append(parts, arg)
+		}
+	}
+	perm := strings.Join(parts, ":")
+	// check user permissions
+	if user.Permissions != nil {
+		ok, err := permission.Permitted(user.Permissions.ToList(), perm)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	// check user roles
+	if user.Roles == nil {
+		return false, nil
+	}
+	for k := range user.Roles.ToMap(false) {
+		// get role
+		role, err := s.GetRole(k)
+		if err != nil {
+			return false, err
+		}
+		// check role permissions
+		if role == nil || role.Permissions == nil {
+			continue
+		}
+		ok, err := permission.Permitted(role.Permissions.ToList(), perm)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,23 @@
+package bolt
+
+import (
+	"encoding/json"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// GetAlertConditions returns Alert Conditions.
+func (s *Store) GetAlertConditions() ([]graylog.AlertCondition, int, error) {
+	var arr []graylog.AlertCondition
+	if err := s.each(alertConditionsBucket, func(v []byte) error {
+		cond := graylog.AlertCondition{}
+		if err := json.Unmarshal(v, &cond); err != nil {
+			return err
+		}
+		arr = append(arr, cond)
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return arr, len(arr), nil
+}
@@ -0,0 +1,49 @@
+package bolt
+
+import (
+	"fmt"
+
+	bbolt "go.etcd.io/bbolt"
+)
+
+// SetClusterConfig sets a cluster configuration to the store.
+func (s *Store) SetClusterConfig(class string, cfg map[string]interface{}) error {
+	if class == "" {
+		return fmt.Errorf("config class is empty")
+	}
+	if cfg == nil {
+		return fmt.Errorf("cluster config is nil")
+	}
+	return s.put(clusterConfigsBucket, class, cfg)
+}
+
+// GetClusterConfig returns a cluster configuration.
+func (s *Store) GetClusterConfig(class string) (map[string]interface{}, error) {
+	cfg := map[string]interface{}{}
+	ok, err := s.get(clusterConfigsBucket, class, &cfg)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// GetClusterConfigClasses returns the classes of all cluster configurations sorted by the name.
+func (s *Store) GetClusterConfigClasses() ([]string, error) {
+	arr := []string{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		// keys are sorted in byte order
+		return tx.Bucket(clusterConfigsBucket).ForEach(func(k, _ []byte) error {
+			arr = append(arr, string(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
+// DeleteClusterConfig deletes a cluster configuration from the store.
+func (s *Store) DeleteClusterConfig(class string) error {
+	return s.delete(clusterConfigsBucket, class)
+}
@@ -0,0 +1,7 @@
+/*
+Package bolt provides the implementation of store.Store interface with BoltDB.
+
+The data is written to the BoltDB file at each operation,
+so the data is persisted even if the mock server stops unexpectedly.
+*/
+package bolt
@@ -0,0 +1,75 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// AddIndex adds an index to the store.
+func (s *Store) AddIndex(index *st.Index) error {
+	if index == nil {
+		return fmt.Errorf("index is nil")
+	}
+	if index.Name == "" {
+		return fmt.Errorf("index name is empty")
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket(indicesBucket).Get([]byte(index.Name)) != nil {
+			return fmt.Errorf("index <%s> already exists", index.Name)
+		}
+		return putValue(tx, indicesBucket, index.Name, index)
+	})
+}
+
+// GetIndex returns an index.
+func (s *Store) GetIndex(name string) (*st.Index, error) {
+	index := &st.Index{}
+	ok, err := s.get(indicesBucket, name, index)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return index, nil
+}
+
+// GetIndices returns an index set's indices sorted by the number.
+func (s *Store) GetIndices(indexSetID string) ([]st.Index, error) {
+	arr := []st.Index{}
+	if err := s.each(indicesBucket, func(v []byte) error {
+		index := st.Index{}
+		if err := json.Unmarshal(v, &index); err != nil {
+			return err
+		}
+		if index.IndexSetID == indexSetID {
+			arr = append(arr, index)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].Number < arr[j].Number
+	})
+	return arr, nil
+}
+
+// UpdateIndex updates an index.
+func (s *Store) UpdateIndex(index *st.Index) error {
+	if index == nil {
+		return fmt.Errorf("index is nil")
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket(indicesBucket).Get([]byte(index.Name)) == nil {
+			return fmt.Errorf("no index <%s> is found", index.Name)
+		}
+		return putValue(tx, indicesBucket, index.Name, index)
+	})
+}
+
+// DeleteIndex removes an index from the store.
+func (s *Store) DeleteIndex(name string) error {
+	return s.delete(indicesBucket, name)
+}
@@ -0,0 +1,237 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// Index sets are keyed by the sequence number to keep the order of addition.
+
+// getIndexSets returns all index sets in the order of addition.
+func getIndexSets(tx *bbolt.Tx) ([]graylog.IndexSet, error) {
+	arr := []graylog.IndexSet{}
+	err := tx.Bucket(indexSetsBucket).ForEach(func(_, v []byte) error {
+		is := graylog.IndexSet{}
+		if err := json.Unmarshal(v, &is); err != nil {
+			return err
+		}
+		arr = append(arr, is)
+		return nil
+	})
+	return arr, err
+}
+
+// findIndexSet returns an index set and its key.
+// If no index set with given id is found, returns nil and not returns an error.
+func findIndexSet(tx *bbolt.Tx, id string) ([]byte, *graylog.IndexSet, error) {
+	c := tx.Bucket(indexSetsBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		is := &graylog.IndexSet{}
+		if err := json.Unmarshal(v, is); err != nil {
+			return nil, nil, err
+		}
+		if is.ID == id {
+			return k, is, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// getDefaultIndexSetID returns a default index set id.
+func getDefaultIndexSetID(tx *bbolt.Tx) string {
+	return string(tx.Bucket(settingsBucket).Get([]byte(defaultIndexSetIDKey)))
+}
+
+// HasIndexSet returns whether the index set exists.
+func (s *Store) HasIndexSet(id string) (bool, error) {
+	is, err := s.GetIndexSet(id)
+	return is != nil, err
+}
+
+// GetIndexSet returns an index set.
+func (s *Store) GetIndexSet(id string) (*graylog.IndexSet, error) {
+	var is *graylog.IndexSet
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		_, is, err = findIndexSet(tx, id)
+		if is != nil {
+			is.Default = getDefaultIndexSetID(tx) == id
+		}
+		return err
+	})
+	return is, err
+}
+
+// GetDefaultIndexSetID returns a default index set id.
+func (s *Store) GetDefaultIndexSetID() (string, error) {
+	id := ""
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		id = getDefaultIndexSetID(tx)
+		return nil
+	})
+	return id, err
+}
+
+// SetDefaultIndexSetID sets a default index set id.
+func (s *Store) SetDefaultIndexSetID(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		_, is, err := findIndexSet(tx, id)
+		if err != nil {
+			return err
+		}
+		if is == nil {
+			return fmt.Errorf("no index set with id <%s> is not found", id)
+		}
+		if !is.Writable {
+			return fmt.Errorf("default index set must be writable")
+		}
+		return tx.Bucket(settingsBucket).Put([]byte(defaultIndexSetIDKey), []byte(id))
+	})
+}
+
+// AddIndexSet adds an index set to the store.
+func (s *Store) AddIndexSet(is *graylog.IndexSet) error {
+	if is == nil {
+		return fmt.Errorf("index set is nil")
+	}
+	if is.ID == "" {
+		is.ID = st.NewObjectID()
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(indexSetsBucket)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(is)
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		return b.Put(key, v)
+	})
+}
+
+// UpdateIndexSet updates an index set at the Mock Server.
+func (s *Store) UpdateIndexSet(prms *graylog.IndexSetUpdateParams) (*graylog.IndexSet, error) {
+	id := prms.ID
+	var is *graylog.IndexSet
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		var (
+			key []byte
+			err error
+		)
+		key, is, err = findIndexSet(tx, id)
+		if err != nil {
+			return err
+		}
+		if is == nil {
+			return fmt.Errorf("no index set with id <%s>", id)
+		}
+		is.Title = prms.Title
+		is.IndexPrefix = prms.IndexPrefix
+		is.RotationStrategyClass = prms.RotationStrategyClass
+		is.RotationStrategy = prms.RotationStrategy
+		is.RetentionStrategyClass = prms.RetentionStrategyClass
+		is.RetentionStrategy = prms.RetentionStrategy
+		is.IndexAnalyzer = prms.IndexAnalyzer
+		is.Shards = prms.Shards
+		is.IndexOptimizationMaxNumSegments = prms.IndexOptimizationMaxNumSegments
+		if prms.Description != nil {
+			is.Description = *prms.Description
+		}
+		if prms.Replicas != nil {
+			is.Replicas = *prms.Replicas
+		}
+		if prms.IndexOptimizationDisabled != nil {
+			is.IndexOptimizationDisabled = *prms.IndexOptimizationDisabled
+		}
+		if prms.Writable != nil {
+			is.Writable = *prms.Writable
+		}
+		if prms.FieldTypeRefreshInterval != nil {
+			is.FieldTypeRefreshInterval = *prms.FieldTypeRefreshInterval
+		}
+		v, err := json.Marshal(is)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(indexSetsBucket).Put(key, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return is, nil
+}
+
+// DeleteIndexSet removes a index set from the Mock Server.
+func (s *Store) DeleteIndexSet(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		key, is, err := findIndexSet(tx, id)
+		if err != nil || is == nil {
+			return err
+		}
+		return tx.Bucket(indexSetsBucket).Delete(key)
+	})
+}
+
+// GetIndexSets returns a list of all index sets.
+func (s *Store) GetIndexSets(skip, limit int) ([]graylog.IndexSet, int, error) {
+	var (
+		indexSets []graylog.IndexSet
+		defID     string
+	)
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		indexSets, err = getIndexSets(tx)
+		defID = getDefaultIndexSetID(tx)
+		return err
+	}); err != nil {
+		return nil, 0, err
+	}
+	total := len(indexSets)
+	if skip < 0 {
+		skip = 0
+	} else if skip > total {
+		skip = total
+	}
+	size := total - skip
+	if limit > 0 && limit < size {
+		size = limit
+	}
+	arr := make([]graylog.IndexSet, size)
+	for i := 0; i < size; i++ {
+		is := indexSets[i+skip]
+		is.Default = defID == is.ID
+		arr[i] = is
+	}
+	return arr, total, nil
+}
+
+// IsConflictIndexPrefix returns true if indexPrefix would conflict with an existing index set.
+func (s *Store) IsConflictIndexPrefix(id, prefix string) (bool, error) {
+	var indexSets []graylog.IndexSet
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		indexSets, err = getIndexSets(tx)
+		return err
+	}); err != nil {
+		return false, err
+	}
+	for _, is := range indexSets {
+		if id != is.ID && strings.HasPrefix(prefix, is.IndexPrefix) {
+			return true, nil
+		}
+		if id != is.ID && strings.HasPrefix(is.IndexPrefix, prefix) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
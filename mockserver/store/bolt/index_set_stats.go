@@ -0,0 +1,90 @@
+package bolt
+
+import (
+	"encoding/json"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// GetIndexSetStats returns an index set stats.
+// Closed indices aren't counted.
+func (s *Store) GetIndexSetStats(id string) (*graylog.IndexSetStats, error) {
+	ok, err := s.HasIndexSet(id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	stats := &graylog.IndexSetStats{}
+	if err := s.each(indicesBucket, func(v []byte) error {
+		index := st.Index{}
+		if err := json.Unmarshal(v, &index); err != nil {
+			return err
+		}
+		if index.IndexSetID == id {
+			addIndexStats(stats, index.Documents, index.Size, index.Closed)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetIndexSetStatsMap returns all of index set stats.
+func (s *Store) GetIndexSetStatsMap() (map[string]graylog.IndexSetStats, error) {
+	m := map[string]graylog.IndexSetStats{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		indexSets, err := getIndexSets(tx)
+		if err != nil {
+			return err
+		}
+		for _, is := range indexSets {
+			m[is.ID] = graylog.IndexSetStats{}
+		}
+		return tx.Bucket(indicesBucket).ForEach(func(_, v []byte) error {
+			index := st.Index{}
+			if err := json.Unmarshal(v, &index); err != nil {
+				return err
+			}
+			stats, ok := m[index.IndexSetID]
+			if !ok {
+				return nil
+			}
+			addIndexStats(&stats, index.Documents, index.Size, index.Closed)
+			m[index.IndexSetID] = stats
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetTotalIndexSetStats returns all index set's statistics.
+func (s *Store) GetTotalIndexSetStats() (*graylog.IndexSetStats, error) {
+	m, err := s.GetIndexSetStatsMap()
+	if err != nil {
+		return nil, err
+	}
+	indexSetStats := &graylog.IndexSetStats{}
+	for _, stats := range m {
+		indexSetStats.Indices += stats.Indices
+		indexSetStats.Documents += stats.Documents
+		indexSetStats.Size += stats.Size
+	}
+	return indexSetStats, nil
+}
+
+func addIndexStats(stats *graylog.IndexSetStats, documents, size int, closed bool) {
+	if closed {
+		return
+	}
+	stats.Indices++
+	stats.Documents += documents
+	stats.Size += size
+}
@@ -0,0 +1,123 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// HasInput returns whether the input exists.
+func (s *Store) HasInput(id string) (bool, error) {
+	return s.has(inputsBucket, id)
+}
+
+// GetInput returns an input.
+func (s *Store) GetInput(id string) (*graylog.Input, error) {
+	input := &graylog.Input{}
+	ok, err := s.get(inputsBucket, id, input)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return input, nil
+}
+
+// AddInput adds an input to the store.
+func (s *Store) AddInput(input *graylog.Input) error {
+	if input == nil {
+		return fmt.Errorf("input is nil")
+	}
+	if input.ID == "" {
+		input.ID = st.NewObjectID()
+	}
+	input.CreatedAt = time.Now().Format("2006-01-02T15:04:05.000Z")
+	return s.put(inputsBucket, input.ID, input)
+}
+
+// UpdateInput updates an input at the Store.
+// Required: Title, Type, Attrs
+// Allowed: Global, Node
+func (s *Store) UpdateInput(prms *graylog.InputUpdateParams) (*graylog.Input, error) {
+	input := &graylog.Input{}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		ok, err := getValue(tx, inputsBucket, prms.ID, input)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("the input <%s> is not found", prms.ID)
+		}
+		input.Title = prms.Title
+		input.Attrs = prms.Attrs
+		if prms.Global != nil {
+			input.Global = *prms.Global
+		}
+		if prms.Node != "" {
+			input.Node = prms.Node
+		}
+		return putValue(tx, inputsBucket, input.ID, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// DeleteInput deletes an input from the store.
+func (s *Store) DeleteInput(id string) error {
+	return s.delete(inputsBucket, id)
+}
+
+// GetInputs returns inputs.
+func (s *Store) GetInputs() ([]graylog.Input, int, error) {
+	arr := []graylog.Input{}
+	if err := s.each(inputsBucket, func(v []byte) error {
+		input := graylog.Input{}
+		if err := json.Unmarshal(v, &input); err != nil {
+			return err
+		}
+		arr = append(arr, input)
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return arr, len(arr), nil
+}
+
+// updateInputStaticFields updates an input's static fields in a transaction.
+func (s *Store) updateInputStaticFields(id string, f func(fields map[string]string)) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		input := &graylog.Input{}
+		ok, err := getValue(tx, inputsBucket, id, input)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("the input <%s> is not found", id)
+		}
+		fields := make(map[string]string, len(input.StaticFields)+1)
+		for k, v := range input.StaticFields {
+			fields[k] = v
+		}
+		f(fields)
+		input.StaticFields = fields
+		return putValue(tx, inputsBucket, id, input)
+	})
+}
+
+// AddInputStaticField adds a static field to an input.
+func (s *Store) AddInputStaticField(id, key, value string) error {
+	return s.updateInputStaticFields(id, func(fields map[string]string) {
+		fields[key] = value
+	})
+}
+
+// DeleteInputStaticField deletes a static field from an input.
+func (s *Store) DeleteInputStaticField(id, key string) error {
+	return s.updateInputStaticFields(id, func(fields map[string]string) {
+		delete(fields, key)
+	})
+}
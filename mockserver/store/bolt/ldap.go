@@ -0,0 +1,31 @@
+package bolt
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// SetLDAPSettings sets the LDAP settings to the store.
+func (s *Store) SetLDAPSettings(settings *graylog.LDAPSettings) error {
+	if settings == nil {
+		return fmt.Errorf("ldap settings is nil")
+	}
+	return s.put(settingsBucket, ldapSettingsKey, settings)
+}
+
+// GetLDAPSettings returns the LDAP settings.
+// If the LDAP settings aren't set, returns nil and not returns an error.
+func (s *Store) GetLDAPSettings() (*graylog.LDAPSettings, error) {
+	settings := &graylog.LDAPSettings{}
+	ok, err := s.get(settingsBucket, ldapSettingsKey, settings)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// DeleteLDAPSettings deletes the LDAP settings from the store.
+func (s *Store) DeleteLDAPSettings() error {
+	return s.delete(settingsBucket, ldapSettingsKey)
+}
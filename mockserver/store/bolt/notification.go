@@ -0,0 +1,58 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// AddNotification adds a notification to the store.
+// A notification of the same type is overwritten.
+func (s *Store) AddNotification(notification *graylog.Notification) error {
+	if notification == nil {
+		return fmt.Errorf("notification is nil")
+	}
+	if notification.Type == "" {
+		return fmt.Errorf("notification type is empty")
+	}
+	return s.put(notificationsBucket, string(notification.Type), notification)
+}
+
+// GetNotification returns a notification of a given type.
+func (s *Store) GetNotification(typ graylog.NotificationType) (*graylog.Notification, error) {
+	notification := &graylog.Notification{}
+	ok, err := s.get(notificationsBucket, string(typ), notification)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// GetNotifications returns all notifications sorted by the timestamp.
+func (s *Store) GetNotifications() ([]graylog.Notification, error) {
+	arr := []graylog.Notification{}
+	if err := s.each(notificationsBucket, func(v []byte) error {
+		notification := graylog.Notification{}
+		if err := json.Unmarshal(v, &notification); err != nil {
+			return err
+		}
+		arr = append(arr, notification)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		if arr[i].Timestamp == arr[j].Timestamp {
+			return arr[i].Type < arr[j].Type
+		}
+		return arr[i].Timestamp < arr[j].Timestamp
+	})
+	return arr, nil
+}
+
+// DeleteNotification deletes a notification of a given type.
+func (s *Store) DeleteNotification(typ graylog.NotificationType) error {
+	return s.delete(notificationsBucket, string(typ))
+}
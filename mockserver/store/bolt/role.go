@@ -0,0 +1,81 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// HasRole returns whether the role exists.
+func (s *Store) HasRole(name string) (bool, error) {
+	return s.has(rolesBucket, name)
+}
+
+// GetRole returns a Role.
+// If no role with given name is found, returns nil and not returns an error.
+func (s *Store) GetRole(name string) (*graylog.Role, error) {
+	role := &graylog.Role{}
+	ok, err := s.get(rolesBucket, name, role)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return role, nil
+}
+
+// GetRoles returns Roles.
+func (s *Store) GetRoles() ([]graylog.Role, int, error) {
+	arr := []graylog.Role{}
+	if err := s.each(rolesBucket, func(v []byte) error {
+		role := graylog.Role{}
+		if err := json.Unmarshal(v, &role); err != nil {
+			return err
+		}
+		arr = append(arr, role)
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return arr, len(arr), nil
+}
+
+// AddRole adds a new role to the store.
+func (s *Store) AddRole(role *graylog.Role) error {
+	if role == nil {
+		return fmt.Errorf("role is nil")
+	}
+	return s.put(rolesBucket, role.Name, role)
+}
+
+// UpdateRole updates a role at the store.
+func (s *Store) UpdateRole(name string, prms *graylog.RoleUpdateParams) (*graylog.Role, error) {
+	role := &graylog.Role{}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		ok, err := getValue(tx, rolesBucket, name, role)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(`no role with name "%s"`, name)
+		}
+		if prms.Description != nil {
+			role.Description = *prms.Description
+		}
+		role.Permissions = prms.Permissions
+		role.Name = prms.Name
+		if err := tx.Bucket(rolesBucket).Delete([]byte(name)); err != nil {
+			return err
+		}
+		return putValue(tx, rolesBucket, role.Name, role)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteRole deletes a role from store.
+func (s *Store) DeleteRole(name string) error {
+	return s.delete(rolesBucket, name)
+}
@@ -0,0 +1,155 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+var (
+	usersBucket           = []byte("users")
+	rolesBucket           = []byte("roles")
+	inputsBucket          = []byte("inputs")
+	indexSetsBucket       = []byte("index_sets")
+	indicesBucket         = []byte("indices")
+	streamsBucket         = []byte("streams")
+	streamRulesBucket     = []byte("stream_rules")
+	alertConditionsBucket = []byte("alert_conditions")
+	notificationsBucket   = []byte("notifications")
+	clusterConfigsBucket  = []byte("cluster_configs")
+	tokensBucket          = []byte("tokens")
+	settingsBucket        = []byte("settings")
+
+	buckets = [][]byte{
+		usersBucket, rolesBucket, inputsBucket, indexSetsBucket,
+		indicesBucket, streamsBucket, streamRulesBucket, alertConditionsBucket,
+		notificationsBucket, clusterConfigsBucket, tokensBucket, settingsBucket,
+	}
+)
+
+const (
+	defaultIndexSetIDKey = "default_index_set_id"
+	ldapSettingsKey      = "ldap_settings"
+)
+
+// Store is the implementation of the Store interface with BoltDB.
+// Each value is stored as JSON.
+type Store struct {
+	db *bbolt.DB
+}
+
+// NewStore opens the BoltDB file and returns a new Store.
+// If the file doesn't exist, the file is created.
+// The caller should close the store by Close.
+func NewStore(dataPath string) (*Store, error) {
+	if dataPath == "" {
+		return nil, fmt.Errorf("data path is empty")
+	}
+	db, err := bbolt.Open(dataPath, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save does nothing because the data is written to the file at each operation.
+func (s *Store) Save() error {
+	return nil
+}
+
+// Load does nothing because the data is read from the file at each operation.
+func (s *Store) Load() error {
+	return nil
+}
+
+// Authorize authorizes the user.
+// The user's permissions and the roles' permissions are matched
+// with Graylog's wildcard permission semantics.
+func (s *Store) Authorize(user *graylog.User, scope string, args ...string) (bool, error) {
+	return store.Authorize(s, user, scope, args...)
+}
+
+// getValue reads a value from the bucket and decodes it to v.
+// If the key is not found, false is returned.
+func getValue(tx *bbolt.Tx, bucket []byte, key string, v interface{}) (bool, error) {
+	b := tx.Bucket(bucket).Get([]byte(key))
+	if b == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+// putValue encodes v to JSON and writes it to the bucket.
+func putValue(tx *bbolt.Tx, bucket []byte, key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucket).Put([]byte(key), b)
+}
+
+// get reads a value from the bucket and decodes it to v.
+// If the key is not found, false is returned.
+func (s *Store) get(bucket []byte, key string, v interface{}) (bool, error) {
+	found := false
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		found, err = getValue(tx, bucket, key, v)
+		return err
+	})
+	return found, err
+}
+
+// has returns whether the key exists in the bucket.
+func (s *Store) has(bucket []byte, key string) (bool, error) {
+	found := false
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		found = tx.Bucket(bucket).Get([]byte(key)) != nil
+		return nil
+	})
+	return found, err
+}
+
+// put encodes v to JSON and writes it to the bucket.
+func (s *Store) put(bucket []byte, key string, v interface{}) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return putValue(tx, bucket, key, v)
+	})
+}
+
+// delete removes the key from the bucket.
+// If the key is not found, nothing happens.
+func (s *Store) delete(bucket []byte, key string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(key))
+	})
+}
+
+// each calls f with every value of the bucket in the key order.
+func (s *Store) each(bucket []byte, f func(v []byte) error) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(_, v []byte) error {
+			return f(v)
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package bolt_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/bolt"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/storetest"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "go-graylog-bolt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestConformance(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	stores := []*bolt.Store{}
+	defer func() {
+		for _, s := range stores {
+			s.Close()
+		}
+	}()
+	storetest.RunConformance(t, func(t *testing.T) store.Store {
+		s, err := bolt.NewStore(filepath.Join(dir, fmt.Sprintf("%d.db", len(stores))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stores = append(stores, s)
+		return s
+	})
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := bolt.NewStore(""); err == nil {
+		t.Fatal("data path is required")
+	}
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	dataPath := filepath.Join(dir, "data.db")
+	s, err := bolt.NewStore(dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	role := testutil.Role()
+	if err := s.AddRole(role); err != nil {
+		t.Fatal(err)
+	}
+	is := testutil.IndexSet("hoge")
+	if err := s.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetDefaultIndexSetID(is.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the data is persisted
+	s, err = bolt.NewStore(dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if ok, err := s.HasRole(role.Name); err != nil || !ok {
+		t.Fatalf("role should be persisted: %v, %v", ok, err)
+	}
+	id, err := s.GetDefaultIndexSetID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != is.ID {
+		t.Fatalf("default index set id = %s, wanted %s", id, is.ID)
+	}
+}
@@ -0,0 +1,128 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// HasStream returns whether the stream exists.
+func (s *Store) HasStream(id string) (bool, error) {
+	return s.has(streamsBucket, id)
+}
+
+// GetStream returns a stream.
+func (s *Store) GetStream(id string) (*graylog.Stream, error) {
+	stream := &graylog.Stream{}
+	ok, err := s.get(streamsBucket, id, stream)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// AddStream adds a stream to the store.
+func (s *Store) AddStream(stream *graylog.Stream) error {
+	if stream == nil {
+		return fmt.Errorf("stream is nil")
+	}
+	if stream.ID == "" {
+		stream.ID = st.NewObjectID()
+	}
+	return s.put(streamsBucket, stream.ID, stream)
+}
+
+// UpdateStream updates a stream at the store.
+func (s *Store) UpdateStream(prms *graylog.StreamUpdateParams) (*graylog.Stream, error) {
+	stream := &graylog.Stream{}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		ok, err := getValue(tx, streamsBucket, prms.ID, stream)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("the stream <%s> is not found", prms.ID)
+		}
+		if prms.Title != "" {
+			stream.Title = prms.Title
+		}
+		if prms.IndexSetID != "" {
+			stream.IndexSetID = prms.IndexSetID
+		}
+		if prms.Description != "" {
+			stream.Description = prms.Description
+		}
+		if prms.Outputs != nil {
+			stream.Outputs = prms.Outputs
+		}
+		if prms.MatchingType != "" {
+			stream.MatchingType = prms.MatchingType
+		}
+		if prms.Rules != nil {
+			stream.Rules = prms.Rules
+		}
+		if prms.AlertConditions != nil {
+			stream.AlertConditions = prms.AlertConditions
+		}
+		if prms.AlertReceivers != nil {
+			stream.AlertReceivers = prms.AlertReceivers
+		}
+		if prms.RemoveMatchesFromDefaultStream != nil {
+			stream.RemoveMatchesFromDefaultStream = *prms.RemoveMatchesFromDefaultStream
+		}
+		return putValue(tx, streamsBucket, stream.ID, stream)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// DeleteStream removes a stream and its stream rules from the store.
+func (s *Store) DeleteStream(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(streamsBucket).Delete([]byte(id)); err != nil {
+			return err
+		}
+		err := tx.Bucket(streamRulesBucket).DeleteBucket([]byte(id))
+		if err == bbolt.ErrBucketNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+// getStreams returns streams which satisfy the filter.
+func (s *Store) getStreams(filter func(stream *graylog.Stream) bool) ([]graylog.Stream, int, error) {
+	arr := []graylog.Stream{}
+	if err := s.each(streamsBucket, func(v []byte) error {
+		stream := graylog.Stream{}
+		if err := json.Unmarshal(v, &stream); err != nil {
+			return err
+		}
+		if filter(&stream) {
+			arr = append(arr, stream)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return arr, len(arr), nil
+}
+
+// GetStreams returns a list of all streams.
+func (s *Store) GetStreams() ([]graylog.Stream, int, error) {
+	return s.getStreams(func(*graylog.Stream) bool {
+		return true
+	})
+}
+
+// GetEnabledStreams returns all enabled streams.
+func (s *Store) GetEnabledStreams() ([]graylog.Stream, int, error) {
+	return s.getStreams(func(stream *graylog.Stream) bool {
+		return !stream.Disabled
+	})
+}
@@ -0,0 +1,148 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// Stream rules are stored at the nested bucket per stream.
+
+// streamRulesOf returns the bucket of a stream's rules.
+// If the bucket doesn't exist, nil is returned.
+func streamRulesOf(tx *bbolt.Tx, streamID string) *bbolt.Bucket {
+	return tx.Bucket(streamRulesBucket).Bucket([]byte(streamID))
+}
+
+// HasStreamRule returns whether the stream rule exists.
+func (s *Store) HasStreamRule(streamID, streamRuleID string) (bool, error) {
+	found := false
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		if b := streamRulesOf(tx, streamID); b != nil {
+			found = b.Get([]byte(streamRuleID)) != nil
+		}
+		return nil
+	})
+	return found, err
+}
+
+// GetStreamRule returns a stream rule.
+func (s *Store) GetStreamRule(streamID, streamRuleID string) (*graylog.StreamRule, error) {
+	var rule *graylog.StreamRule
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := streamRulesOf(tx, streamID)
+		if b == nil {
+			return nil
+		}
+		v := b.Get([]byte(streamRuleID))
+		if v == nil {
+			return nil
+		}
+		rule = &graylog.StreamRule{}
+		return json.Unmarshal(v, rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// GetStreamRules returns stream rules of the given stream.
+func (s *Store) GetStreamRules(id string) ([]graylog.StreamRule, int, error) {
+	var arr []graylog.StreamRule
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := streamRulesOf(tx, id)
+		if b == nil {
+			return nil
+		}
+		arr = []graylog.StreamRule{}
+		return b.ForEach(func(_, v []byte) error {
+			rule := graylog.StreamRule{}
+			if err := json.Unmarshal(v, &rule); err != nil {
+				return err
+			}
+			arr = append(arr, rule)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return arr, len(arr), nil
+}
+
+// AddStreamRule adds a stream rule.
+func (s *Store) AddStreamRule(rule *graylog.StreamRule) error {
+	if rule == nil {
+		return fmt.Errorf("rule is nil")
+	}
+	if rule.ID == "" {
+		rule.ID = st.NewObjectID()
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.Bucket(streamRulesBucket).CreateBucketIfNotExists([]byte(rule.StreamID))
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(rule.ID), v)
+	})
+}
+
+// UpdateStreamRule updates a stream rule.
+func (s *Store) UpdateStreamRule(prms *graylog.StreamRuleUpdateParams) error {
+	if prms == nil {
+		return fmt.Errorf("rule is nil")
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := streamRulesOf(tx, prms.StreamID)
+		if b == nil {
+			return fmt.Errorf("no stream with id <%s> is found", prms.StreamID)
+		}
+		v := b.Get([]byte(prms.ID))
+		if v == nil {
+			return fmt.Errorf("no stream rule with id <%s> is found", prms.ID)
+		}
+		rule := graylog.StreamRule{}
+		if err := json.Unmarshal(v, &rule); err != nil {
+			return err
+		}
+		if prms.Field != "" {
+			rule.Field = prms.Field
+		}
+		if prms.Description != "" {
+			rule.Description = prms.Description
+		}
+		if prms.Value != "" {
+			rule.Value = prms.Value
+		}
+		if prms.Type != nil {
+			rule.Type = *prms.Type
+		}
+		if prms.Inverted != nil {
+			rule.Inverted = *prms.Inverted
+		}
+		v, err := json.Marshal(&rule)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(rule.ID), v)
+	})
+}
+
+// DeleteStreamRule deletes a stream rule.
+func (s *Store) DeleteStreamRule(streamID, streamRuleID string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := streamRulesOf(tx, streamID)
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(streamRuleID))
+	})
+}
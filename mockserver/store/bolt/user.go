@@ -0,0 +1,120 @@
+package bolt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// HasUser returns whether the user exists.
+func (s *Store) HasUser(username string) (bool, error) {
+	return s.has(usersBucket, username)
+}
+
+// GetUser returns a user.
+// If the user is not found, this method returns nil and doesn't raise an error.
+func (s *Store) GetUser(username string) (*graylog.User, error) {
+	user := &graylog.User{}
+	ok, err := s.get(usersBucket, username, user)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUsers returns users
+func (s *Store) GetUsers() ([]graylog.User, error) {
+	arr := []graylog.User{}
+	if err := s.each(usersBucket, func(v []byte) error {
+		user := graylog.User{}
+		if err := json.Unmarshal(v, &user); err != nil {
+			return err
+		}
+		arr = append(arr, user)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
+// AddUser adds a user to the Store.
+func (s *Store) AddUser(user *graylog.User) error {
+	if user == nil {
+		return fmt.Errorf("user is nil")
+	}
+	if user.ID == "" {
+		user.ID = st.NewObjectID()
+	}
+	return s.put(usersBucket, user.Username, user)
+}
+
+// UpdateUser updates a user of the Store.
+// "email", "permissions", "full_name", "password"
+func (s *Store) UpdateUser(prms *graylog.UserUpdateParams) error {
+	if prms == nil {
+		return fmt.Errorf("user is nil")
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		user := &graylog.User{}
+		ok, err := getValue(tx, usersBucket, prms.Username, user)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(`the user "%s" is not found`, prms.Username)
+		}
+		if prms.Email != nil {
+			user.Email = *prms.Email
+		}
+		if prms.FullName != nil {
+			user.FullName = *prms.FullName
+		}
+		if prms.Password != nil {
+			user.Password = *prms.Password
+		}
+		if prms.Timezone != nil {
+			user.Timezone = *prms.Timezone
+		}
+		if prms.SessionTimeoutMs != nil {
+			user.SessionTimeoutMs = *prms.SessionTimeoutMs
+		}
+		if prms.Permissions != nil {
+			user.Permissions = prms.Permissions
+		}
+		if prms.Startpage != nil {
+			user.Startpage = prms.Startpage
+		}
+		if prms.Roles != nil {
+			user.Roles = prms.Roles
+		}
+		return putValue(tx, usersBucket, user.Username, user)
+	})
+}
+
+// DeleteUser removes a user from the Store.
+func (s *Store) DeleteUser(name string) error {
+	return s.delete(usersBucket, name)
+}
+
+// GetUserByAccessToken returns a user name.
+// If the user is not found, this method returns nil and doesn't raise an error.
+func (s *Store) GetUserByAccessToken(token string) (*graylog.User, error) {
+	var user *graylog.User
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		username := tx.Bucket(tokensBucket).Get([]byte(token))
+		if username == nil {
+			return nil
+		}
+		u := &graylog.User{}
+		ok, err := getValue(tx, usersBucket, string(username), u)
+		if ok {
+			user = u
+		}
+		return err
+	})
+	return user, err
+}
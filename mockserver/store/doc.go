@@ -1,4 +1,6 @@
 /*
 Package store provides store interface for Graylog API mock server.
+
+The storetest package provides the conformance test suite of the interface.
 */
 package store
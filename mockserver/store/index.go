@@ -0,0 +1,25 @@
+package store
+
+import (
+	"time"
+)
+
+// Index represents a mock of an Elasticsearch index which belongs to an index set.
+type Index struct {
+	// ex. "graylog_0"
+	Name       string `json:"name"`
+	IndexSetID string `json:"index_set_id"`
+	// the suffix number of the index name
+	Number    int `json:"number"`
+	Documents int `json:"documents"`
+	// bytes
+	Size     int  `json:"size"`
+	Closed   bool `json:"closed"`
+	Reopened bool `json:"reopened"`
+	// the time range of the index's messages
+	Begin time.Time `json:"begin"`
+	End   time.Time `json:"end"`
+	// when the index range was calculated
+	RangeCalculatedAt time.Time `json:"range_calculated_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,64 @@
+package plain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SetClusterConfig sets a copy of a cluster configuration to the store.
+func (store *Store) SetClusterConfig(class string, cfg map[string]interface{}) error {
+	if class == "" {
+		return fmt.Errorf("config class is empty")
+	}
+	if cfg == nil {
+		return fmt.Errorf("cluster config is nil")
+	}
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	if store.clusterConfigs == nil {
+		store.clusterConfigs = map[string]map[string]interface{}{}
+	}
+	store.clusterConfigs[class] = copyClusterConfig(cfg)
+	return nil
+}
+
+// GetClusterConfig returns a copy of a cluster configuration.
+func (store *Store) GetClusterConfig(class string) (map[string]interface{}, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	cfg, ok := store.clusterConfigs[class]
+	if ok {
+		return copyClusterConfig(cfg), nil
+	}
+	return nil, nil
+}
+
+// GetClusterConfigClasses returns the classes of all cluster configurations sorted by the name.
+func (store *Store) GetClusterConfigClasses() ([]string, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	arr := make([]string, 0, len(store.clusterConfigs))
+	for class := range store.clusterConfigs {
+		arr = append(arr, class)
+	}
+	sort.Strings(arr)
+	return arr, nil
+}
+
+// DeleteClusterConfig deletes a cluster configuration from the store.
+func (store *Store) DeleteClusterConfig(class string) error {
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	delete(store.clusterConfigs, class)
+	return nil
+}
+
+// copyClusterConfig returns a shallow copy of a cluster configuration,
+// so the caller can't change the store's configuration without the lock.
+func copyClusterConfig(cfg map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(cfg))
+	for k, v := range cfg {
+		c[k] = v
+	}
+	return c
+}
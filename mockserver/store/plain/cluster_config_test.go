@@ -0,0 +1,62 @@
+package plain_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+)
+
+func TestSetClusterConfig(t *testing.T) {
+	s := plain.NewStore("")
+	if err := s.SetClusterConfig("", map[string]interface{}{}); err == nil {
+		t.Fatal("config class is required")
+	}
+	if err := s.SetClusterConfig("foo", map[string]interface{}{"a": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := s.GetClusterConfig("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg["a"] != "b" {
+		t.Fatalf("cfg == %v", cfg)
+	}
+	classes, err := s.GetClusterConfigClasses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(classes) != 1 {
+		t.Fatalf("len(classes) == %d, wanted 1", len(classes))
+	}
+	if err := s.DeleteClusterConfig("foo"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = s.GetClusterConfig("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg != nil {
+		t.Fatal("the cluster config should be deleted")
+	}
+}
+
+func TestGetClusterConfigCopy(t *testing.T) {
+	s := plain.NewStore("")
+	src := map[string]interface{}{"a": "b"}
+	if err := s.SetClusterConfig("foo", src); err != nil {
+		t.Fatal(err)
+	}
+	src["a"] = "c"
+	cfg, err := s.GetClusterConfig("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg["a"] = "d"
+	cfg, err = s.GetClusterConfig("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg["a"] != "b" {
+		t.Fatalf(`cfg["a"] == %v, wanted b`, cfg["a"])
+	}
+}
@@ -0,0 +1,15 @@
+package plain_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/storetest"
+)
+
+func TestConformance(t *testing.T) {
+	storetest.RunConformance(t, func(t *testing.T) store.Store {
+		return plain.NewStore("")
+	})
+}
@@ -0,0 +1,77 @@
+package plain
+
+import (
+	"fmt"
+	"sort"
+
+	st "github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+// AddIndex adds an index to the store.
+func (store *Store) AddIndex(index *st.Index) error {
+	if index == nil {
+		return fmt.Errorf("index is nil")
+	}
+	if index.Name == "" {
+		return fmt.Errorf("index name is empty")
+	}
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	if store.indices == nil {
+		store.indices = map[string]st.Index{}
+	}
+	if _, ok := store.indices[index.Name]; ok {
+		return fmt.Errorf("index <%s> already exists", index.Name)
+	}
+	store.indices[index.Name] = *index
+	return nil
+}
+
+// GetIndex returns an index.
+func (store *Store) GetIndex(name string) (*st.Index, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	index, ok := store.indices[name]
+	if ok {
+		return &index, nil
+	}
+	return nil, nil
+}
+
+// GetIndices returns an index set's indices sorted by the number.
+func (store *Store) GetIndices(indexSetID string) ([]st.Index, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	arr := []st.Index{}
+	for _, index := range store.indices {
+		if index.IndexSetID == indexSetID {
+			arr = append(arr, index)
+		}
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].Number < arr[j].Number
+	})
+	return arr, nil
+}
+
+// UpdateIndex updates an index.
+func (store *Store) UpdateIndex(index *st.Index) error {
+	if index == nil {
+		return fmt.Errorf("index is nil")
+	}
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	if _, ok := store.indices[index.Name]; !ok {
+		return fmt.Errorf("no index <%s> is found", index.Name)
+	}
+	store.indices[index.Name] = *index
+	return nil
+}
+
+// DeleteIndex removes an index from the store.
+func (store *Store) DeleteIndex(name string) error {
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	delete(store.indices, name)
+	return nil
+}
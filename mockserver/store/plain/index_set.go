@@ -41,21 +41,22 @@ func (store *Store) GetDefaultIndexSetID() (string, error) {
 }
 
 // SetDefaultIndexSetID sets a default index set id.
+// The index set is checked and the id is set while the store is locked,
+// so the index set can't be removed in the meantime.
 func (store *Store) SetDefaultIndexSetID(id string) error {
-	is, err := store.GetIndexSet(id)
-	if err != nil {
-		return err
-	}
-	if is == nil {
-		return fmt.Errorf("no index set with id <%s> is not found", id)
-	}
-	if !is.Writable {
-		return fmt.Errorf("default index set must be writable")
-	}
 	store.imutex.Lock()
 	defer store.imutex.Unlock()
-	store.defaultIndexSetID = id
-	return nil
+	for _, is := range store.indexSets {
+		if is.ID != id {
+			continue
+		}
+		if !is.Writable {
+			return fmt.Errorf("default index set must be writable")
+		}
+		store.defaultIndexSetID = id
+		return nil
+	}
+	return fmt.Errorf("no index set with id <%s> is not found", id)
 }
 
 // AddIndexSet adds an index set to the store.
@@ -85,6 +86,7 @@ func (store *Store) UpdateIndexSet(prms *graylog.IndexSetUpdateParams) (*graylog
 		is.IndexPrefix = prms.IndexPrefix
 		is.RotationStrategyClass = prms.RotationStrategyClass
 		is.RotationStrategy = prms.RotationStrategy
+		is.RetentionStrategyClass = prms.RetentionStrategyClass
 		is.RetentionStrategy = prms.RetentionStrategy
 		is.IndexAnalyzer = prms.IndexAnalyzer
 		is.Shards = prms.Shards
@@ -101,6 +103,9 @@ func (store *Store) UpdateIndexSet(prms *graylog.IndexSetUpdateParams) (*graylog
 		if prms.Writable != nil {
 			is.Writable = *prms.Writable
 		}
+		if prms.FieldTypeRefreshInterval != nil {
+			is.FieldTypeRefreshInterval = *prms.FieldTypeRefreshInterval
+		}
 		store.indexSets[i] = is
 		return &is, nil
 	}
@@ -111,23 +116,12 @@ func (store *Store) UpdateIndexSet(prms *graylog.IndexSetUpdateParams) (*graylog
 func (store *Store) DeleteIndexSet(id string) error {
 	store.imutex.Lock()
 	defer store.imutex.Unlock()
-	size := len(store.indexSets)
-	if size == 0 {
-		return nil
-	}
-	var arr []graylog.IndexSet
-	if size == 1 {
-		arr = []graylog.IndexSet{}
-	} else {
-		arr = make([]graylog.IndexSet, size-1)
-	}
-	i := 0
+	arr := make([]graylog.IndexSet, 0, len(store.indexSets))
 	for _, is := range store.indexSets {
 		if is.ID == id {
 			continue
 		}
-		arr[i] = is
-		i++
+		arr = append(arr, is)
 	}
 	store.indexSets = arr
 	return nil
@@ -141,9 +135,10 @@ func (store *Store) GetIndexSets(skip, limit int) ([]graylog.IndexSet, int, erro
 	size := total
 	if skip < 0 {
 		skip = 0
-	} else {
-		size -= skip
+	} else if skip > total {
+		skip = total
 	}
+	size -= skip
 	if limit > 0 && limit < size {
 		size = limit
 	}
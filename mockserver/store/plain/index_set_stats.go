@@ -5,16 +5,24 @@ import (
 )
 
 // GetIndexSetStats returns an index set stats.
+// Closed indices aren't counted.
 func (store *Store) GetIndexSetStats(id string) (*graylog.IndexSetStats, error) {
 	ok, err := store.HasIndexSet(id)
 	if err != nil {
 		return nil, err
 	}
-	if ok {
-		// TODO returns correct index set stats
-		return &graylog.IndexSetStats{}, nil
+	if !ok {
+		return nil, nil
 	}
-	return nil, nil
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	stats := &graylog.IndexSetStats{}
+	for _, index := range store.indices {
+		if index.IndexSetID == id {
+			addIndexStats(stats, index.Documents, index.Size, index.Closed)
+		}
+	}
+	return stats, nil
 }
 
 // GetIndexSetStatsMap returns all of index set stats.
@@ -23,15 +31,39 @@ func (store *Store) GetIndexSetStatsMap() (map[string]graylog.IndexSetStats, err
 	store.imutex.RLock()
 	defer store.imutex.RUnlock()
 	for _, is := range store.indexSets {
-		// TODO returns correct index set stats
 		m[is.ID] = graylog.IndexSetStats{}
 	}
+	for _, index := range store.indices {
+		stats, ok := m[index.IndexSetID]
+		if !ok {
+			continue
+		}
+		addIndexStats(&stats, index.Documents, index.Size, index.Closed)
+		m[index.IndexSetID] = stats
+	}
 	return m, nil
 }
 
 // GetTotalIndexSetStats returns all index set's statistics.
 func (store *Store) GetTotalIndexSetStats() (*graylog.IndexSetStats, error) {
-	// TODO returns correct index set stats
+	m, err := store.GetIndexSetStatsMap()
+	if err != nil {
+		return nil, err
+	}
 	indexSetStats := &graylog.IndexSetStats{}
+	for _, stats := range m {
+		indexSetStats.Indices += stats.Indices
+		indexSetStats.Documents += stats.Documents
+		indexSetStats.Size += stats.Size
+	}
 	return indexSetStats, nil
 }
+
+func addIndexStats(stats *graylog.IndexSetStats, documents, size int, closed bool) {
+	if closed {
+		return
+	}
+	stats.Indices++
+	stats.Documents += documents
+	stats.Size += size
+}
@@ -0,0 +1,139 @@
+package plain_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+	"github.com/suzuki-shunsuke/go-graylog/testutil"
+)
+
+func TestAddIndex(t *testing.T) {
+	s := plain.NewStore("")
+	if err := s.AddIndex(nil); err == nil {
+		t.Fatal("index is nil")
+	}
+	if err := s.AddIndex(&store.Index{}); err == nil {
+		t.Fatal("index name is required")
+	}
+	index := &store.Index{Name: "hoge_0", IndexSetID: "foo"}
+	if err := s.AddIndex(index); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddIndex(index); err == nil {
+		t.Fatal("index hoge_0 already exists")
+	}
+}
+
+func TestGetIndex(t *testing.T) {
+	s := plain.NewStore("")
+	index, err := s.GetIndex("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != nil {
+		t.Fatal("index hoge_0 should not exist")
+	}
+	if err := s.AddIndex(&store.Index{Name: "hoge_0"}); err != nil {
+		t.Fatal(err)
+	}
+	index, err = s.GetIndex("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index == nil {
+		t.Fatal("index hoge_0 should exist")
+	}
+}
+
+func TestGetIndices(t *testing.T) {
+	s := plain.NewStore("")
+	for _, index := range []store.Index{
+		{Name: "hoge_1", IndexSetID: "foo", Number: 1},
+		{Name: "hoge_0", IndexSetID: "foo", Number: 0},
+		{Name: "fuga_0", IndexSetID: "bar", Number: 0},
+	} {
+		if err := s.AddIndex(&index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	indices, err := s.GetIndices("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 2 {
+		t.Fatalf("len(indices) == %d, wanted 2", len(indices))
+	}
+	if indices[0].Name != "hoge_0" {
+		t.Fatalf(`indices[0].Name == "%s", wanted "hoge_0"`, indices[0].Name)
+	}
+}
+
+func TestUpdateIndex(t *testing.T) {
+	s := plain.NewStore("")
+	index := &store.Index{Name: "hoge_0"}
+	if err := s.UpdateIndex(index); err == nil {
+		t.Fatal("index hoge_0 should not exist")
+	}
+	if err := s.AddIndex(index); err != nil {
+		t.Fatal(err)
+	}
+	index.Closed = true
+	if err := s.UpdateIndex(index); err != nil {
+		t.Fatal(err)
+	}
+	index, err := s.GetIndex("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !index.Closed {
+		t.Fatal("index hoge_0 should be closed")
+	}
+}
+
+func TestDeleteIndex(t *testing.T) {
+	s := plain.NewStore("")
+	if err := s.AddIndex(&store.Index{Name: "hoge_0"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteIndex("hoge_0"); err != nil {
+		t.Fatal(err)
+	}
+	index, err := s.GetIndex("hoge_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != nil {
+		t.Fatal("index hoge_0 should be deleted")
+	}
+}
+
+func TestIndexSetStatsWithIndices(t *testing.T) {
+	s := plain.NewStore("")
+	is := testutil.IndexSet("hoge")
+	if err := s.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	for _, index := range []store.Index{
+		{Name: "hoge_0", IndexSetID: is.ID, Documents: 10, Size: 100, Closed: true},
+		{Name: "hoge_1", IndexSetID: is.ID, Documents: 20, Size: 200, Number: 1},
+	} {
+		if err := s.AddIndex(&index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats, err := s.GetIndexSetStats(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Indices != 1 || stats.Documents != 20 || stats.Size != 200 {
+		t.Fatalf("stats == %+v, closed indices should not be counted", stats)
+	}
+	total, err := s.GetTotalIndexSetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *total != *stats {
+		t.Fatalf("total == %+v, wanted %+v", total, stats)
+	}
+}
@@ -55,10 +55,10 @@ func (store *Store) UpdateInput(prms *graylog.InputUpdateParams) (*graylog.Input
 	}
 	input.Title = prms.Title
 	input.Attrs = prms.Attrs
-	if prms.Global == nil {
+	if prms.Global != nil {
 		input.Global = *prms.Global
 	}
-	if prms.Node == "" {
+	if prms.Node != "" {
 		input.Node = prms.Node
 	}
 	store.inputs[input.ID] = input
@@ -86,3 +86,43 @@ func (store *Store) GetInputs() ([]graylog.Input, int, error) {
 	}
 	return arr, size, nil
 }
+
+// AddInputStaticField adds a static field to an input.
+func (store *Store) AddInputStaticField(id, key, value string) error {
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	input, ok := store.inputs[id]
+	if !ok {
+		return fmt.Errorf("the input <%s> is not found", id)
+	}
+	fields := make(map[string]string, len(input.StaticFields)+1)
+	for k, v := range input.StaticFields {
+		fields[k] = v
+	}
+	fields[key] = value
+	input.StaticFields = fields
+	store.inputs[id] = input
+	return nil
+}
+
+// DeleteInputStaticField deletes a static field from an input.
+func (store *Store) DeleteInputStaticField(id, key string) error {
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	input, ok := store.inputs[id]
+	if !ok {
+		return fmt.Errorf("the input <%s> is not found", id)
+	}
+	if _, ok := input.StaticFields[key]; !ok {
+		return nil
+	}
+	fields := make(map[string]string, len(input.StaticFields))
+	for k, v := range input.StaticFields {
+		if k != key {
+			fields[k] = v
+		}
+	}
+	input.StaticFields = fields
+	store.inputs[id] = input
+	return nil
+}
@@ -101,3 +101,48 @@ func TestDeleteInput(t *testing.T) {
 		t.Fatal("input should be deleted")
 	}
 }
+
+func TestAddInputStaticField(t *testing.T) {
+	store := plain.NewStore("")
+	if err := store.AddInputStaticField("foo", "env", "test"); err == nil {
+		t.Fatal("input foo should not exist")
+	}
+	input := testutil.Input()
+	if err := store.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := store.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.StaticFields["env"] != "test" {
+		t.Fatalf(`StaticFields["env"] = "%s", wanted "test"`, r.StaticFields["env"])
+	}
+}
+
+func TestDeleteInputStaticField(t *testing.T) {
+	store := plain.NewStore("")
+	if err := store.DeleteInputStaticField("foo", "env"); err == nil {
+		t.Fatal("input foo should not exist")
+	}
+	input := testutil.Input()
+	if err := store.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	r, err := store.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.StaticFields["env"]; ok {
+		t.Fatal(`static field "env" should be deleted`)
+	}
+}
@@ -0,0 +1,39 @@
+package plain
+
+import (
+	"fmt"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// SetLDAPSettings sets the LDAP settings to the store.
+func (store *Store) SetLDAPSettings(settings *graylog.LDAPSettings) error {
+	if settings == nil {
+		return fmt.Errorf("ldap settings is nil")
+	}
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	s := *settings
+	store.ldapSettings = &s
+	return nil
+}
+
+// GetLDAPSettings returns the LDAP settings.
+// If the LDAP settings aren't set, returns nil and not returns an error.
+func (store *Store) GetLDAPSettings() (*graylog.LDAPSettings, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	if store.ldapSettings == nil {
+		return nil, nil
+	}
+	s := *store.ldapSettings
+	return &s, nil
+}
+
+// DeleteLDAPSettings deletes the LDAP settings from the store.
+func (store *Store) DeleteLDAPSettings() error {
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	store.ldapSettings = nil
+	return nil
+}
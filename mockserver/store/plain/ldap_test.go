@@ -0,0 +1,43 @@
+package plain_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+)
+
+func TestSetLDAPSettings(t *testing.T) {
+	s := plain.NewStore("")
+	if err := s.SetLDAPSettings(nil); err == nil {
+		t.Fatal("ldap settings is nil")
+	}
+	settings, err := s.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings != nil {
+		t.Fatal("ldap settings should not be set")
+	}
+	if err := s.SetLDAPSettings(&graylog.LDAPSettings{
+		LDAPURI: "ldap://localhost:389"}); err != nil {
+		t.Fatal(err)
+	}
+	settings, err = s.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings == nil || settings.LDAPURI != "ldap://localhost:389" {
+		t.Fatalf("settings == %+v", settings)
+	}
+	if err := s.DeleteLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+	settings, err = s.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings != nil {
+		t.Fatal("ldap settings should be deleted")
+	}
+}
@@ -0,0 +1,62 @@
+package plain
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// AddNotification adds a notification to the store.
+// A notification of the same type is overwritten.
+func (store *Store) AddNotification(notification *graylog.Notification) error {
+	if notification == nil {
+		return fmt.Errorf("notification is nil")
+	}
+	if notification.Type == "" {
+		return fmt.Errorf("notification type is empty")
+	}
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	if store.notifications == nil {
+		store.notifications = map[graylog.NotificationType]graylog.Notification{}
+	}
+	store.notifications[notification.Type] = *notification
+	return nil
+}
+
+// GetNotification returns a notification of a given type.
+func (store *Store) GetNotification(typ graylog.NotificationType) (*graylog.Notification, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	notification, ok := store.notifications[typ]
+	if ok {
+		return &notification, nil
+	}
+	return nil, nil
+}
+
+// GetNotifications returns all notifications sorted by the timestamp.
+func (store *Store) GetNotifications() ([]graylog.Notification, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
+	arr := make([]graylog.Notification, 0, len(store.notifications))
+	for _, notification := range store.notifications {
+		arr = append(arr, notification)
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		if arr[i].Timestamp == arr[j].Timestamp {
+			return arr[i].Type < arr[j].Type
+		}
+		return arr[i].Timestamp < arr[j].Timestamp
+	})
+	return arr, nil
+}
+
+// DeleteNotification deletes a notification of a given type.
+func (store *Store) DeleteNotification(typ graylog.NotificationType) error {
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
+	delete(store.notifications, typ)
+	return nil
+}
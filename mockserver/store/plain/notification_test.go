@@ -0,0 +1,39 @@
+package plain_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
+)
+
+func TestAddNotification(t *testing.T) {
+	s := plain.NewStore("")
+	if err := s.AddNotification(nil); err == nil {
+		t.Fatal("notification is nil")
+	}
+	if err := s.AddNotification(&graylog.Notification{}); err == nil {
+		t.Fatal("notification type is required")
+	}
+	if err := s.AddNotification(&graylog.Notification{
+		Type: graylog.NotificationTypeNoMaster}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := s.GetNotification(graylog.NotificationTypeNoMaster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == nil {
+		t.Fatal("the notification should be added")
+	}
+	if err := s.DeleteNotification(graylog.NotificationTypeNoMaster); err != nil {
+		t.Fatal(err)
+	}
+	notifications, err := s.GetNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 0 {
+		t.Fatalf("len(notifications) == %d, wanted 0", len(notifications))
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
-	"strings"
 	"sync"
 
 	"github.com/suzuki-shunsuke/go-graylog"
@@ -18,56 +17,81 @@ type Store struct {
 	inputs            map[string]graylog.Input
 	indexSets         []graylog.IndexSet
 	defaultIndexSetID string
+	indices           map[string]store.Index
 	streams           map[string]graylog.Stream
 	streamRules       map[string]map[string]graylog.StreamRule
 	alertConditions   map[string]graylog.AlertCondition
+	notifications     map[graylog.NotificationType]graylog.Notification
+	clusterConfigs    map[string]map[string]interface{}
+	ldapSettings      *graylog.LDAPSettings
 	dataPath          string
 	tokens            map[string]string
-	imutex            sync.RWMutex
+	// imutex guards the data.
+	imutex sync.RWMutex
+	// fmutex serializes Save and Load, so an older snapshot never overwrites a newer one.
+	fmutex sync.Mutex
 }
 
 type plainStore struct {
-	Users             map[string]graylog.User                  `json:"users"`
-	Roles             map[string]graylog.Role                  `json:"roles"`
-	Inputs            map[string]graylog.Input                 `json:"inputs"`
-	IndexSets         []graylog.IndexSet                       `json:"index_sets"`
-	DefaultIndexSetID string                                   `json:"default_index_set_id"`
-	Streams           map[string]graylog.Stream                `json:"streams"`
-	StreamRules       map[string]map[string]graylog.StreamRule `json:"stream_rules"`
-	AlertConditions   map[string]graylog.AlertCondition        `json:"alert_conditions"`
-	Tokens            map[string]string                        `json:"tokens"`
+	Users             map[string]graylog.User                           `json:"users"`
+	Roles             map[string]graylog.Role                           `json:"roles"`
+	Inputs            map[string]graylog.Input                          `json:"inputs"`
+	IndexSets         []graylog.IndexSet                                `json:"index_sets"`
+	DefaultIndexSetID string                                            `json:"default_index_set_id"`
+	Indices           map[string]store.Index                            `json:"indices"`
+	Streams           map[string]graylog.Stream                         `json:"streams"`
+	StreamRules       map[string]map[string]graylog.StreamRule          `json:"stream_rules"`
+	AlertConditions   map[string]graylog.AlertCondition                 `json:"alert_conditions"`
+	Notifications     map[graylog.NotificationType]graylog.Notification `json:"notifications"`
+	ClusterConfigs    map[string]map[string]interface{}                 `json:"cluster_configs"`
+	LDAPSettings      *graylog.LDAPSettings                             `json:"ldap_settings"`
+	Tokens            map[string]string                                 `json:"tokens"`
 }
 
 // MarshalJSON is the implementation of the json.Marshaler interface.
+// The data are encoded while the store is locked, so the result is a consistent snapshot.
 func (store *Store) MarshalJSON() ([]byte, error) {
+	store.imutex.RLock()
+	defer store.imutex.RUnlock()
 	data := map[string]interface{}{
 		"users":                store.users,
 		"roles":                store.roles,
 		"inputs":               store.inputs,
 		"index_sets":           store.indexSets,
 		"default_index_set_id": store.defaultIndexSetID,
+		"indices":              store.indices,
 		"streams":              store.streams,
 		"stream_rules":         store.streamRules,
 		"alert_conditions":     store.alertConditions,
+		"notifications":        store.notifications,
+		"cluster_configs":      store.clusterConfigs,
+		"ldap_settings":        store.ldapSettings,
 		"tokens":               store.tokens,
 	}
 	return json.Marshal(data)
 }
 
 // UnmarshalJSON is the implementation of the json.Unmarshaler interface.
+// All data are replaced at once.
 func (store *Store) UnmarshalJSON(b []byte) error {
 	s := &plainStore{}
 	if err := json.Unmarshal(b, s); err != nil {
 		return err
 	}
+	store.imutex.Lock()
+	defer store.imutex.Unlock()
 	store.users = s.Users
 	store.roles = s.Roles
 	store.inputs = s.Inputs
 	store.indexSets = s.IndexSets
 	store.defaultIndexSetID = s.DefaultIndexSetID
+	store.indices = s.Indices
 	store.streams = s.Streams
 	store.streamRules = s.StreamRules
 	store.alertConditions = s.AlertConditions
+	store.notifications = s.Notifications
+	store.clusterConfigs = s.ClusterConfigs
+	store.ldapSettings = s.LDAPSettings
 	store.tokens = s.Tokens
 	return nil
 }
@@ -81,18 +105,23 @@ func NewStore(dataPath string) store.Store {
 		users:           map[string]graylog.User{},
 		inputs:          map[string]graylog.Input{},
 		indexSets:       []graylog.IndexSet{},
+		indices:         map[string]store.Index{},
 		streams:         map[string]graylog.Stream{},
 		streamRules:     map[string]map[string]graylog.StreamRule{},
 		alertConditions: map[string]graylog.AlertCondition{},
+		notifications:   map[graylog.NotificationType]graylog.Notification{},
+		clusterConfigs:  map[string]map[string]interface{}{},
 		tokens:          map[string]string{},
 		dataPath:        dataPath,
 	}
 }
 
 // Save writes Mock Server's data in a file for persistence.
+// The handlers can change the data while the file is written,
+// because the data are locked only while they are encoded.
 func (store *Store) Save() error {
-	store.imutex.RLock()
-	defer store.imutex.RUnlock()
+	store.fmutex.Lock()
+	defer store.fmutex.Unlock()
 	if store.dataPath == "" {
 		return nil
 	}
@@ -105,8 +134,8 @@ func (store *Store) Save() error {
 
 // Load reads Mock Server's data from a file.
 func (store *Store) Load() error {
-	store.imutex.Lock()
-	defer store.imutex.Unlock()
+	store.fmutex.Lock()
+	defer store.fmutex.Unlock()
 	if store.dataPath == "" {
 		return nil
 	}
@@ -121,37 +150,8 @@ func (store *Store) Load() error {
 }
 
 // Authorize authorizes the user.
-func (store *Store) Authorize(user *graylog.User, scope string, args ...string) (bool, error) {
-	if user == nil {
-		return true, nil
-	}
-	perm := scope
-	if len(args) != 0 {
-		perm += ":" + strings.Join(args, ":")
-	}
-	// check user permissions
-	if user.Permissions != nil {
-		if user.Permissions.HasAny("*", scope, perm) {
-			return true, nil
-		}
-	}
-	// check user roles
-	if user.Roles == nil {
-		return false, nil
-	}
-	for k := range user.Roles.ToMap(false) {
-		// get role
-		role, err := store.GetRole(k)
-		if err != nil {
-			return false, err
-		}
-		// check role permissions
-		if role.Permissions == nil {
-			continue
-		}
-		if role.Permissions.HasAny("*", scope, perm) {
-			return true, nil
-		}
-	}
-	return false, nil
+// The user's permissions and the roles' permissions are matched
+// with Graylog's wildcard permission semantics.
+func (s *Store) Authorize(user *graylog.User, scope string, args ...string) (bool, error) {
+	return store.Authorize(s, user, scope, args...)
 }
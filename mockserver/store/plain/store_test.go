@@ -3,6 +3,7 @@ package plain_test
 import (
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/suzuki-shunsuke/go-graylog/mockserver/store/plain"
@@ -52,6 +53,58 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestSaveConcurrently(t *testing.T) {
+	tmpfile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	store := plain.NewStore(tmpfile.Name())
+	if err := store.SetClusterConfig("foo", map[string]interface{}{"n": 0}); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := store.Save(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				// the returned configuration is changed while the store is saved
+				cfg, err := store.GetClusterConfig("foo")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				cfg["n"] = n
+				if err := store.AddStream(testutil.Stream()); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded := plain.NewStore(tmpfile.Name())
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, total, err := loaded.GetStreams(); err != nil || total != 80 {
+		t.Fatalf("total = %d, wanted 80: %v", total, err)
+	}
+}
+
 func TestAuthorize(t *testing.T) {
 	store := plain.NewStore("")
 	ok, err := store.Authorize(nil, "users:read")
@@ -119,3 +172,49 @@ func TestAuthorize(t *testing.T) {
 		t.Fatal("not allowed")
 	}
 }
+
+func TestAuthorizeWildcard(t *testing.T) {
+	store := plain.NewStore("")
+	user := testutil.User()
+	user.Permissions = set.NewStrSet("streams:read,edit:foo,bar", "*:read")
+	data := []struct {
+		scope string
+		args  []string
+		exp   bool
+	}{
+		{"streams:edit", []string{"bar"}, true},
+		{"streams:edit", []string{"baz"}, false},
+		{"streams:delete", []string{"foo"}, false},
+		{"users:read", []string{"admin"}, true},
+		{"users:edit", []string{"admin"}, false},
+		// permissions are case sensitive
+		{"streams:edit", []string{"BAR"}, false},
+		// an empty id is omitted
+		{"users:read", []string{""}, true},
+		{"streams:edit", []string{""}, false},
+	}
+	for _, d := range data {
+		ok, err := store.Authorize(user, d.scope, d.args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != d.exp {
+			t.Fatalf("store.Authorize(user, %s, %v) == %t, wanted %t",
+				d.scope, d.args, ok, d.exp)
+		}
+	}
+
+	role := testutil.Role()
+	role.Permissions = set.NewStrSet("streams:*")
+	if err := store.AddRole(role); err != nil {
+		t.Fatal(err)
+	}
+	user.Roles = set.NewStrSet(role.Name)
+	ok, err := store.Authorize(user, "streams:delete", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("streams:* implies streams:delete:foo")
+	}
+}
@@ -80,11 +80,12 @@ func (store *Store) UpdateStream(prms *graylog.StreamUpdateParams) (*graylog.Str
 	return &stream, nil
 }
 
-// DeleteStream removes a stream from the store.
+// DeleteStream removes a stream and its stream rules from the store.
 func (store *Store) DeleteStream(id string) error {
 	store.imutex.Lock()
 	defer store.imutex.Unlock()
 	delete(store.streams, id)
+	delete(store.streamRules, id)
 	return nil
 }
 
@@ -34,14 +34,21 @@ type Store interface {
 	UpdateInput(*graylog.InputUpdateParams) (*graylog.Input, error)
 	DeleteInput(id string) error
 	HasInput(id string) (bool, error)
+	AddInputStaticField(id, key, value string) error
+	DeleteInputStaticField(id, key string) error
 
 	AddIndexSet(*graylog.IndexSet) error
 	GetIndexSet(id string) (*graylog.IndexSet, error)
+	// GetIndexSets returns index sets in the order of addition and the total number of index sets.
+	// The Default field is set by the store.
 	GetIndexSets(skip, limit int) ([]graylog.IndexSet, int, error)
 	UpdateIndexSet(*graylog.IndexSetUpdateParams) (*graylog.IndexSet, error)
 	DeleteIndexSet(id string) error
 	HasIndexSet(id string) (bool, error)
+	// IsConflictIndexPrefix returns true if indexPrefix is a prefix of other index set's prefix or vice versa.
 	IsConflictIndexPrefix(id, indexPrefix string) (bool, error)
+	// SetDefaultIndexSetID sets the default index set.
+	// If the index set doesn't exist or isn't writable, returns an error.
 	SetDefaultIndexSetID(id string) error
 	GetDefaultIndexSetID() (string, error)
 
@@ -50,11 +57,21 @@ type Store interface {
 	GetTotalIndexSetStats() (*graylog.IndexSetStats, error)
 	GetIndexSetStatsMap() (map[string]graylog.IndexSetStats, error)
 
+	AddIndex(*Index) error
+	// GetIndex returns an index.
+	// If no index with given name is found, returns nil and not returns an error.
+	GetIndex(name string) (*Index, error)
+	// GetIndices returns an index set's indices sorted by the number.
+	GetIndices(indexSetID string) ([]Index, error)
+	UpdateIndex(*Index) error
+	DeleteIndex(name string) error
+
 	AddStream(*graylog.Stream) error
 	GetStream(id string) (*graylog.Stream, error)
 	GetStreams() ([]graylog.Stream, int, error)
 	GetEnabledStreams() ([]graylog.Stream, int, error)
 	UpdateStream(*graylog.StreamUpdateParams) (*graylog.Stream, error)
+	// DeleteStream deletes a stream and its stream rules.
 	DeleteStream(id string) error
 	HasStream(id string) (bool, error)
 
@@ -66,4 +83,30 @@ type Store interface {
 	HasStreamRule(streamID, streamRuleID string) (bool, error)
 
 	GetAlertConditions() ([]graylog.AlertCondition, int, error)
+
+	// AddNotification adds a notification.
+	// A notification of the same type is overwritten.
+	AddNotification(*graylog.Notification) error
+	// GetNotification returns a notification of a given type.
+	// If no notification of the type is found, returns nil and not returns an error.
+	GetNotification(graylog.NotificationType) (*graylog.Notification, error)
+	// GetNotifications returns all notifications sorted by the timestamp.
+	GetNotifications() ([]graylog.Notification, error)
+	DeleteNotification(graylog.NotificationType) error
+
+	SetClusterConfig(class string, cfg map[string]interface{}) error
+	// GetClusterConfig returns a cluster configuration.
+	// If no configuration of the class is found, returns nil and not returns an error.
+	GetClusterConfig(class string) (map[string]interface{}, error)
+	// GetClusterConfigClasses returns the classes of all cluster configurations sorted by the name.
+	GetClusterConfigClasses() ([]string, error)
+	DeleteClusterConfig(class string) error
+
+	// SetLDAPSettings sets the LDAP settings.
+	// The system password is stored as it is.
+	SetLDAPSettings(*graylog.LDAPSettings) error
+	// GetLDAPSettings returns the LDAP settings.
+	// If the LDAP settings aren't set, returns nil and not returns an error.
+	GetLDAPSettings() (*graylog.LDAPSettings, error)
+	DeleteLDAPSettings() error
 }
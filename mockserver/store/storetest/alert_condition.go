@@ -0,0 +1,17 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testAlertCondition(t *testing.T, s store.Store) {
+	conds, total, err := s.GetAlertConditions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 || len(conds) != 0 {
+		t.Fatalf("alert conditions = %+v, wanted empty", conds)
+	}
+}
@@ -0,0 +1,42 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+func testAuthorize(t *testing.T, s store.Store) {
+	if err := s.AddRole(&graylog.Role{
+		Name: "Reader", Permissions: set.NewStrSet("streams:read"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	user := &graylog.User{
+		Username: "foo", Permissions: set.NewStrSet("users:*"),
+		Roles: set.NewStrSet("Reader", "Unknown"),
+	}
+	data := []struct {
+		scope string
+		args  []string
+		ok    bool
+	}{
+		{"users:edit", []string{"foo"}, true},
+		{"streams:read", []string{"foo"}, true},
+		{"streams:edit", []string{"foo"}, false},
+	}
+	for _, d := range data {
+		ok, err := s.Authorize(user, d.scope, d.args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != d.ok {
+			t.Fatalf("Authorize(%s, %v) = %v, wanted %v", d.scope, d.args, ok, d.ok)
+		}
+	}
+	if ok, err := s.Authorize(nil, "streams:edit"); err != nil || !ok {
+		t.Fatalf("nil user should be authorized: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,44 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testClusterConfig(t *testing.T, s store.Store) {
+	if err := s.SetClusterConfig("", map[string]interface{}{}); err == nil {
+		t.Fatal("it should be failed to set a config without the class")
+	}
+	if err := s.SetClusterConfig("foo", nil); err == nil {
+		t.Fatal("it should be failed to set nil")
+	}
+	if cfg, err := s.GetClusterConfig("foo"); err != nil || cfg != nil {
+		t.Fatalf("config should not exist: %v, %v", cfg, err)
+	}
+	for _, class := range []string{"foo", "bar"} {
+		if err := s.SetClusterConfig(class, map[string]interface{}{"name": class}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	classes, err := s.GetClusterConfigClasses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(classes) != 2 || classes[0] != "bar" || classes[1] != "foo" {
+		t.Fatalf("classes = %v, wanted [bar foo]", classes)
+	}
+	cfg, err := s.GetClusterConfig("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg["name"] != "foo" {
+		t.Fatalf("config = %v, wanted name: foo", cfg)
+	}
+	if err := s.DeleteClusterConfig("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err := s.GetClusterConfig("foo"); err != nil || cfg != nil {
+		t.Fatalf("config should be deleted: %v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,159 @@
+package storetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+const (
+	concurrentWorkers    = 8
+	concurrentIterations = 20
+)
+
+// testConcurrency calls the store's methods from several goroutines at once.
+// Run the suite with the -race flag to detect the data races.
+func testConcurrency(t *testing.T, s store.Store) {
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		streamID []string
+	)
+	for i := 0; i < concurrentWorkers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < concurrentIterations; j++ {
+				id, err := streamLifecycle(s, fmt.Sprintf("stream-%d-%d", worker, j))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mutex.Lock()
+				streamID = append(streamID, id)
+				mutex.Unlock()
+			}
+		}(i)
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < concurrentIterations; j++ {
+				if err := indexSetLifecycle(s, fmt.Sprintf("prefix-%d-%d", worker, j)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			class := fmt.Sprintf("class-%d", worker%2)
+			for j := 0; j < concurrentIterations; j++ {
+				if err := clusterConfigLifecycle(s, class, j); err != nil {
+					t.Error(err)
+					return
+				}
+				if err := s.Save(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	if _, total, err := s.GetStreams(); err != nil || total != 0 {
+		t.Fatalf("total = %d, wanted 0: %v", total, err)
+	}
+	for _, id := range streamID {
+		if _, total, err := s.GetStreamRules(id); err != nil || total != 0 {
+			t.Fatalf("the rules of the stream <%s> should be deleted with the stream: %d, %v", id, total, err)
+		}
+	}
+	if _, total, err := s.GetIndexSets(0, 0); err != nil || total != 0 {
+		t.Fatalf("total = %d, wanted 0: %v", total, err)
+	}
+}
+
+// streamLifecycle adds a stream and its rules, changes them and removes the stream.
+func streamLifecycle(s store.Store, title string) (string, error) {
+	stream := &graylog.Stream{Title: title, MatchingType: "AND", IndexSetID: "foo"}
+	if err := s.AddStream(stream); err != nil {
+		return "", err
+	}
+	for k := 0; k < 2; k++ {
+		rule := &graylog.StreamRule{StreamID: stream.ID, Type: 1, Field: "tag", Value: title}
+		if err := s.AddStreamRule(rule); err != nil {
+			return "", err
+		}
+		if err := s.UpdateStreamRule(&graylog.StreamRuleUpdateParams{
+			ID: rule.ID, StreamID: stream.ID, Field: "tag", Value: "changed",
+		}); err != nil {
+			return "", err
+		}
+	}
+	if _, err := s.UpdateStream(&graylog.StreamUpdateParams{
+		ID: stream.ID, Title: title + "-changed",
+	}); err != nil {
+		return "", err
+	}
+	if _, _, err := s.GetStreams(); err != nil {
+		return "", err
+	}
+	if _, _, err := s.GetEnabledStreams(); err != nil {
+		return "", err
+	}
+	if _, total, err := s.GetStreamRules(stream.ID); err != nil || total != 2 {
+		return "", fmt.Errorf("the stream <%s> has %d rules, wanted 2: %v", stream.ID, total, err)
+	}
+	return stream.ID, s.DeleteStream(stream.ID)
+}
+
+// indexSetLifecycle adds an index set, makes it default and removes it.
+func indexSetLifecycle(s store.Store, prefix string) error {
+	is := &graylog.IndexSet{Title: prefix, IndexPrefix: prefix, Writable: true}
+	if err := s.AddIndexSet(is); err != nil {
+		return err
+	}
+	if err := s.SetDefaultIndexSetID(is.ID); err != nil {
+		return err
+	}
+	if _, _, err := s.GetIndexSets(0, 0); err != nil {
+		return err
+	}
+	if _, err := s.IsConflictIndexPrefix(is.ID, prefix); err != nil {
+		return err
+	}
+	if _, err := s.GetTotalIndexSetStats(); err != nil {
+		return err
+	}
+	if _, err := s.GetDefaultIndexSetID(); err != nil {
+		return err
+	}
+	return s.DeleteIndexSet(is.ID)
+}
+
+// clusterConfigLifecycle sets a cluster configuration and changes the returned configuration.
+// The change must not affect the store's configuration.
+func clusterConfigLifecycle(s store.Store, class string, n int) error {
+	if err := s.SetClusterConfig(class, map[string]interface{}{"n": n}); err != nil {
+		return err
+	}
+	cfg, err := s.GetClusterConfig(class)
+	if err != nil {
+		return err
+	}
+	if cfg != nil {
+		cfg["n"] = -1
+	}
+	if _, err := s.GetClusterConfigClasses(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+/*
+Package storetest provides the conformance test suite of the store.Store interface.
+
+Every store.Store implementation must pass the suite,
+and custom store implementations can run it in their own tests.
+
+  func TestConformance(t *testing.T) {
+  	storetest.RunConformance(t, func(t *testing.T) store.Store {
+  		return NewStore()
+  	})
+  }
+*/
+package storetest
@@ -0,0 +1,76 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testIndex(t *testing.T, s store.Store) {
+	is := newIndexSet("foo")
+	if err := s.AddIndexSet(is); err != nil {
+		t.Fatal(err)
+	}
+	for i, index := range []store.Index{
+		{Name: "foo_1", IndexSetID: is.ID, Number: 1, Documents: 10, Size: 100},
+		{Name: "foo_0", IndexSetID: is.ID, Number: 0, Documents: 5, Size: 50},
+		{Name: "foo_2", IndexSetID: is.ID, Number: 2, Documents: 3, Size: 30, Closed: true},
+	} {
+		idx := index
+		if err := s.AddIndex(&idx); err != nil {
+			t.Fatal(i, err)
+		}
+	}
+	if err := s.AddIndex(&store.Index{Name: "foo_0", IndexSetID: is.ID}); err == nil {
+		t.Fatal("it should be failed to add an index which already exists")
+	}
+	indices, err := s.GetIndices(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != 3 || indices[0].Name != "foo_0" || indices[2].Name != "foo_2" {
+		t.Fatalf("indices = %+v, wanted sorted by the number", indices)
+	}
+	stats, err := s.GetIndexSetStats(is.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the closed index isn't counted
+	if stats.Indices != 2 || stats.Documents != 15 || stats.Size != 150 {
+		t.Fatalf("stats = %+v, wanted 2 indices, 15 documents and 150 bytes", stats)
+	}
+	if stats, err := s.GetIndexSetStats("foo"); err != nil || stats != nil {
+		t.Fatalf("stats of an index set which doesn't exist should be nil: %v, %v", stats, err)
+	}
+	total, err := s.GetTotalIndexSetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total.Indices != 2 {
+		t.Fatalf("total.Indices = %d, wanted 2", total.Indices)
+	}
+	index, err := s.GetIndex("foo_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	index.Closed = true
+	if err := s.UpdateIndex(index); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateIndex(&store.Index{Name: "bar"}); err == nil {
+		t.Fatal("it should be failed to update an index which doesn't exist")
+	}
+	m, err := s.GetIndexSetStatsMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m[is.ID].Indices != 1 {
+		t.Fatalf("stats = %+v, wanted 1 index", m[is.ID])
+	}
+	if err := s.DeleteIndex("foo_0"); err != nil {
+		t.Fatal(err)
+	}
+	if index, err := s.GetIndex("foo_0"); err != nil || index != nil {
+		t.Fatalf("index should be deleted: %v, %v", index, err)
+	}
+}
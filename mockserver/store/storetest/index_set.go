@@ -0,0 +1,206 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func newIndexSet(prefix string) *graylog.IndexSet {
+	return &graylog.IndexSet{
+		Title:                           prefix,
+		IndexPrefix:                     prefix,
+		RotationStrategyClass:           graylog.MessageCountRotationStrategy,
+		RotationStrategy:                graylog.NewMessageCountRotationStrategy(0),
+		RetentionStrategyClass:          graylog.DeletionRetentionStrategy,
+		RetentionStrategy:               graylog.NewDeletionRetentionStrategy(0),
+		IndexOptimizationMaxNumSegments: 1,
+		Shards:                          4,
+		Writable:                        true,
+	}
+}
+
+// addIndexSets adds index sets with given prefixes and returns their ids.
+func addIndexSets(t *testing.T, s store.Store, prefixes ...string) []string {
+	ids := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		is := newIndexSet(prefix)
+		if err := s.AddIndexSet(is); err != nil {
+			t.Fatal(err)
+		}
+		if is.ID == "" {
+			t.Fatal("index set id should be set")
+		}
+		ids[i] = is.ID
+	}
+	return ids
+}
+
+func testIndexSet(t *testing.T, s store.Store) {
+	is, err := s.GetIndexSet("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if is != nil {
+		t.Fatal("index set foo should not exist")
+	}
+	if err := s.AddIndexSet(nil); err == nil {
+		t.Fatal("it should be failed to add nil")
+	}
+	iss, total, err := s.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 || len(iss) != 0 {
+		t.Fatalf("index sets = %+v, wanted empty", iss)
+	}
+	ids := addIndexSets(t, s, "foo", "bar", "zoo")
+	// index sets are returned in the order of addition
+	iss, total, err = s.GetIndexSets(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(iss) != 1 {
+		t.Fatalf("len(index sets) = %d, total = %d, wanted 1 and 3", len(iss), total)
+	}
+	if iss[0].ID != ids[1] {
+		t.Fatalf("index set id = %s, wanted %s", iss[0].ID, ids[1])
+	}
+	iss, total, err = s.GetIndexSets(5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(iss) != 0 {
+		t.Fatalf("len(index sets) = %d, total = %d, wanted 0 and 3", len(iss), total)
+	}
+	desc := "changed"
+	if _, err := s.UpdateIndexSet(&graylog.IndexSetUpdateParams{
+		ID: ids[0], Title: "changed", IndexPrefix: "foo", Description: &desc,
+		RotationStrategyClass:  graylog.MessageCountRotationStrategy,
+		RotationStrategy:       graylog.NewMessageCountRotationStrategy(0),
+		RetentionStrategyClass: graylog.DeletionRetentionStrategy,
+		RetentionStrategy:      graylog.NewDeletionRetentionStrategy(0),
+		Shards:                 4,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	is, err = s.GetIndexSet(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if is.Title != "changed" || is.Description != desc || !is.Writable {
+		t.Fatalf("index set = %+v, wanted the title and description changed", is)
+	}
+	if _, err := s.UpdateIndexSet(&graylog.IndexSetUpdateParams{ID: "foo"}); err == nil {
+		t.Fatal("it should be failed to update an index set which doesn't exist")
+	}
+	if err := s.DeleteIndexSet(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteIndexSet("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasIndexSet(ids[0]); err != nil || ok {
+		t.Fatalf("index set should be deleted: %v, %v", ok, err)
+	}
+	iss, total, err = s.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || iss[0].ID != ids[1] || iss[1].ID != ids[2] {
+		t.Fatalf("index sets = %+v, wanted %v", iss, ids[1:])
+	}
+}
+
+func testDefaultIndexSet(t *testing.T, s store.Store) {
+	id, err := s.GetDefaultIndexSetID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "" {
+		t.Fatalf("default index set id = %s, wanted empty", id)
+	}
+	ids := addIndexSets(t, s, "foo", "bar")
+	if err := s.SetDefaultIndexSetID("foo"); err == nil {
+		t.Fatal("it should be failed to set an index set which doesn't exist as default")
+	}
+	if err := s.SetDefaultIndexSetID(ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	id, err = s.GetDefaultIndexSetID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != ids[1] {
+		t.Fatalf("default index set id = %s, wanted %s", id, ids[1])
+	}
+	// Default is set by the store
+	is, err := s.GetIndexSet(ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !is.Default {
+		t.Fatal("the default index set's Default should be true")
+	}
+	is, err = s.GetIndexSet(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if is.Default {
+		t.Fatal("the other index set's Default should be false")
+	}
+	iss, _, err := s.GetIndexSets(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iss[0].Default || !iss[1].Default {
+		t.Fatalf("index sets = %+v, wanted only %s to be default", iss, ids[1])
+	}
+	// the default index set must be writable
+	writable := false
+	if _, err := s.UpdateIndexSet(&graylog.IndexSetUpdateParams{
+		ID: ids[0], Title: "foo", IndexPrefix: "foo", Writable: &writable,
+		RotationStrategyClass:  graylog.MessageCountRotationStrategy,
+		RotationStrategy:       graylog.NewMessageCountRotationStrategy(0),
+		RetentionStrategyClass: graylog.DeletionRetentionStrategy,
+		RetentionStrategy:      graylog.NewDeletionRetentionStrategy(0),
+		Shards:                 4,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetDefaultIndexSetID(ids[0]); err == nil {
+		t.Fatal("it should be failed to set an index set which isn't writable as default")
+	}
+	if id, err := s.GetDefaultIndexSetID(); err != nil || id != ids[1] {
+		t.Fatalf("default index set id = %s, wanted %s: %v", id, ids[1], err)
+	}
+}
+
+func testIsConflictIndexPrefix(t *testing.T, s store.Store) {
+	ids := addIndexSets(t, s, "foo_bar")
+	data := []struct {
+		id     string
+		prefix string
+		ok     bool
+	}{
+		// an existing prefix is a prefix of the new prefix
+		{"", "foo_bar_baz", true},
+		// the new prefix is a prefix of an existing prefix
+		{"", "foo", true},
+		{"", "foo_bar", true},
+		{"", "bar", false},
+		{"", "foo_baz", false},
+		// the index set itself is excluded
+		{ids[0], "foo", false},
+	}
+	for _, d := range data {
+		ok, err := s.IsConflictIndexPrefix(d.id, d.prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != d.ok {
+			t.Fatalf(`IsConflictIndexPrefix("%s", "%s") = %v, wanted %v`, d.id, d.prefix, ok, d.ok)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testInput(t *testing.T, s store.Store) {
+	if input, err := s.GetInput("foo"); err != nil || input != nil {
+		t.Fatalf("input foo should not exist: %v, %v", input, err)
+	}
+	if err := s.AddInput(nil); err == nil {
+		t.Fatal("it should be failed to add nil")
+	}
+	input := &graylog.Input{
+		Title: "test",
+		Attrs: &graylog.InputBeatsAttrs{BindAddress: "0.0.0.0", Port: 514},
+	}
+	if err := s.AddInput(input); err != nil {
+		t.Fatal(err)
+	}
+	if input.ID == "" || input.CreatedAt == "" {
+		t.Fatalf("input = %+v, wanted the id and created_at to be set", input)
+	}
+	if _, err := s.UpdateInput(&graylog.InputUpdateParams{
+		ID: input.ID, Title: "changed", Attrs: input.Attrs,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddInputStaticField(input.ID, "env", "test"); err != nil {
+		t.Fatal(err)
+	}
+	in, err := s.GetInput(input.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in == nil {
+		t.Fatal("input is not found")
+	}
+	if in.Title != "changed" || in.Type() != graylog.InputTypeBeats {
+		t.Fatalf("input = %+v, wanted the title changed and the type %s", in, graylog.InputTypeBeats)
+	}
+	if in.StaticFields["env"] != "test" {
+		t.Fatalf("input.StaticFields = %v, wanted env: test", in.StaticFields)
+	}
+	if err := s.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteInputStaticField(input.ID, "env"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddInputStaticField("foo", "env", "test"); err == nil {
+		t.Fatal("it should be failed to add a static field to an input which doesn't exist")
+	}
+	if err := s.DeleteInputStaticField("foo", "env"); err == nil {
+		t.Fatal("it should be failed to delete a static field from an input which doesn't exist")
+	}
+	if _, err := s.UpdateInput(&graylog.InputUpdateParams{
+		ID: "foo", Title: "foo", Attrs: input.Attrs,
+	}); err == nil {
+		t.Fatal("it should be failed to update an input which doesn't exist")
+	}
+	inputs, total, err := s.GetInputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(inputs) != 1 {
+		t.Fatalf("len(inputs) = %d, total = %d, wanted 1", len(inputs), total)
+	}
+	if len(inputs[0].StaticFields) != 0 {
+		t.Fatalf("input.StaticFields = %v, wanted empty", inputs[0].StaticFields)
+	}
+	if err := s.DeleteInput(input.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasInput(input.ID); err != nil || ok {
+		t.Fatalf("input should be deleted: %v, %v", ok, err)
+	}
+}
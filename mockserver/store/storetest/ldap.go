@@ -0,0 +1,45 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testLDAPSettings(t *testing.T, s store.Store) {
+	settings, err := s.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings != nil {
+		t.Fatal("ldap settings should not be set")
+	}
+	if err := s.SetLDAPSettings(nil); err == nil {
+		t.Fatal("it should be failed to set nil")
+	}
+	if err := s.SetLDAPSettings(&graylog.LDAPSettings{
+		Enabled: true, LDAPURI: "ldap://localhost:389", SystemPassword: "password",
+		GroupMapping: map[string]string{"admins": "Admin"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	settings, err = s.GetLDAPSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.SystemPassword != "password" || settings.GroupMapping["admins"] != "Admin" {
+		t.Fatalf("ldap settings = %+v, wanted the password and group mapping stored", settings)
+	}
+	// the returned settings is a copy
+	settings.LDAPURI = "ldap://example.com:389"
+	if settings, err := s.GetLDAPSettings(); err != nil || settings.LDAPURI != "ldap://localhost:389" {
+		t.Fatalf("the stored settings should not be changed: %+v, %v", settings, err)
+	}
+	if err := s.DeleteLDAPSettings(); err != nil {
+		t.Fatal(err)
+	}
+	if settings, err := s.GetLDAPSettings(); err != nil || settings != nil {
+		t.Fatalf("ldap settings should be deleted: %v, %v", settings, err)
+	}
+}
@@ -0,0 +1,50 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testNotification(t *testing.T, s store.Store) {
+	if n, err := s.GetNotification(graylog.NotificationTypeNoMaster); err != nil || n != nil {
+		t.Fatalf("notification should not exist: %v, %v", n, err)
+	}
+	if err := s.AddNotification(nil); err == nil {
+		t.Fatal("it should be failed to add nil")
+	}
+	if err := s.AddNotification(&graylog.Notification{}); err == nil {
+		t.Fatal("it should be failed to add a notification without the type")
+	}
+	for _, n := range []graylog.Notification{
+		{Type: graylog.NotificationTypeNoInputRunning, Timestamp: "2018-02-02T00:00:00.000Z"},
+		{Type: graylog.NotificationTypeMultiMaster, Timestamp: "2018-02-01T00:00:00.000Z"},
+		{Type: graylog.NotificationTypeNoInputRunning, Timestamp: "2018-02-03T00:00:00.000Z"},
+	} {
+		notification := n
+		if err := s.AddNotification(&notification); err != nil {
+			t.Fatal(err)
+		}
+	}
+	arr, err := s.GetNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arr) != 2 || arr[0].Type != graylog.NotificationTypeMultiMaster {
+		t.Fatalf("notifications = %+v, wanted 2 notifications sorted by the timestamp", arr)
+	}
+	n, err := s.GetNotification(graylog.NotificationTypeNoInputRunning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Timestamp != "2018-02-03T00:00:00.000Z" {
+		t.Fatalf("notification = %+v, wanted to be overwritten", n)
+	}
+	if err := s.DeleteNotification(graylog.NotificationTypeNoInputRunning); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := s.GetNotification(graylog.NotificationTypeNoInputRunning); err != nil || n != nil {
+		t.Fatalf("notification should be deleted: %v, %v", n, err)
+	}
+}
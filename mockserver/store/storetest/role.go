@@ -0,0 +1,72 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+func testRole(t *testing.T, s store.Store) {
+	role, err := s.GetRole("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role != nil {
+		t.Fatal("role foo should not exist")
+	}
+	if ok, err := s.HasRole("foo"); err != nil || ok {
+		t.Fatalf("role foo should not exist: %v, %v", ok, err)
+	}
+	if roles, total, err := s.GetRoles(); err != nil || total != 0 || len(roles) != 0 {
+		t.Fatalf("roles = %+v, total = %d, wanted empty: %v", roles, total, err)
+	}
+	if err := s.AddRole(&graylog.Role{
+		Name: "foo", Description: "foo", Permissions: set.NewStrSet("users:read"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ok, err := s.HasRole("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("role foo should exist")
+	}
+	desc := "bar"
+	role, err = s.UpdateRole("foo", &graylog.RoleUpdateParams{
+		Name: "bar", Description: &desc, Permissions: set.NewStrSet("users:edit"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role.Name != "bar" || role.Description != "bar" {
+		t.Fatalf(`role = %+v, wanted the name "bar" and the description "bar"`, role)
+	}
+	if ok, err := s.HasRole("foo"); err != nil || ok {
+		t.Fatalf("the old role name should be removed: %v, %v", ok, err)
+	}
+	if _, err := s.UpdateRole("foo", &graylog.RoleUpdateParams{Name: "foo"}); err == nil {
+		t.Fatal("it should be failed to update a role which doesn't exist")
+	}
+	roles, total, err := s.GetRoles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(roles) != 1 {
+		t.Fatalf("len(roles) = %d, total = %d, wanted 1", len(roles), total)
+	}
+	if !roles[0].Permissions.Has("users:edit") {
+		t.Fatalf("roles[0].Permissions = %v, wanted users:edit", roles[0].Permissions)
+	}
+	if err := s.DeleteRole("bar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteRole("bar"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasRole("bar"); err != nil || ok {
+		t.Fatalf("role bar should be deleted: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,54 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+// Factory returns a new empty store.
+// Factory is called at each test case, so the store must not share the data with other stores.
+// If the store can't be created, call t.Fatal.
+type Factory func(t *testing.T) store.Store
+
+// RunConformance checks that the stores which the factory returns satisfy the contract of store.Store.
+// Each test case is run as a subtest with a new store.
+func RunConformance(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.Store)
+	}{
+		{"SaveAndLoad", testSaveAndLoad},
+		{"Role", testRole},
+		{"User", testUser},
+		{"Input", testInput},
+		{"IndexSet", testIndexSet},
+		{"DefaultIndexSet", testDefaultIndexSet},
+		{"IsConflictIndexPrefix", testIsConflictIndexPrefix},
+		{"Index", testIndex},
+		{"Stream", testStream},
+		{"StreamRule", testStreamRule},
+		{"DeleteStreamCascade", testDeleteStreamCascade},
+		{"AlertCondition", testAlertCondition},
+		{"Notification", testNotification},
+		{"ClusterConfig", testClusterConfig},
+		{"LDAPSettings", testLDAPSettings},
+		{"Authorize", testAuthorize},
+		{"Concurrency", testConcurrency},
+	}
+	for _, tt := range tests {
+		test := tt.test
+		t.Run(tt.name, func(t *testing.T) {
+			test(t, factory(t))
+		})
+	}
+}
+
+func testSaveAndLoad(t *testing.T, s store.Store) {
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,87 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testStream(t *testing.T, s store.Store) {
+	if stream, err := s.GetStream("foo"); err != nil || stream != nil {
+		t.Fatalf("stream foo should not exist: %v, %v", stream, err)
+	}
+	if err := s.AddStream(nil); err == nil {
+		t.Fatal("it should be failed to add nil")
+	}
+	stream := &graylog.Stream{Title: "foo", MatchingType: "AND", IndexSetID: "foo"}
+	if err := s.AddStream(stream); err != nil {
+		t.Fatal(err)
+	}
+	if stream.ID == "" {
+		t.Fatal("stream id should be set")
+	}
+	if err := s.AddStream(&graylog.Stream{Title: "bar", Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateStream(&graylog.StreamUpdateParams{
+		ID: stream.ID, Title: "changed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := s.GetStream(stream.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Title != "changed" || st.MatchingType != "AND" {
+		t.Fatalf("stream = %+v, wanted only the title changed", st)
+	}
+	if _, err := s.UpdateStream(&graylog.StreamUpdateParams{ID: "foo"}); err == nil {
+		t.Fatal("it should be failed to update a stream which doesn't exist")
+	}
+	if _, total, err := s.GetStreams(); err != nil || total != 2 {
+		t.Fatalf("total = %d, wanted 2: %v", total, err)
+	}
+	streams, total, err := s.GetEnabledStreams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || streams[0].ID != stream.ID {
+		t.Fatalf("enabled streams = %+v, wanted only %s", streams, stream.ID)
+	}
+	if err := s.DeleteStream(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasStream(stream.ID); err != nil || ok {
+		t.Fatalf("stream should be deleted: %v, %v", ok, err)
+	}
+}
+
+func testDeleteStreamCascade(t *testing.T, s store.Store) {
+	stream := &graylog.Stream{Title: "foo", MatchingType: "AND"}
+	if err := s.AddStream(stream); err != nil {
+		t.Fatal(err)
+	}
+	rule := &graylog.StreamRule{StreamID: stream.ID, Type: 1, Field: "tag", Value: "test"}
+	if err := s.AddStreamRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	other := &graylog.StreamRule{StreamID: "bar", Type: 1, Field: "tag", Value: "test"}
+	if err := s.AddStreamRule(other); err != nil {
+		t.Fatal(err)
+	}
+	// the stream's rules are deleted with the stream
+	if err := s.DeleteStream(stream.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasStreamRule(stream.ID, rule.ID); err != nil || ok {
+		t.Fatalf("the stream rule should be deleted with the stream: %v, %v", ok, err)
+	}
+	if _, total, err := s.GetStreamRules(stream.ID); err != nil || total != 0 {
+		t.Fatalf("total = %d, wanted 0: %v", total, err)
+	}
+	// other streams' rules are kept
+	if ok, err := s.HasStreamRule("bar", other.ID); err != nil || !ok {
+		t.Fatalf("other stream's rule should not be deleted: %v, %v", ok, err)
+	}
+}
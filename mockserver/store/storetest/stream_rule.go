@@ -0,0 +1,60 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+)
+
+func testStreamRule(t *testing.T, s store.Store) {
+	rules, total, err := s.GetStreamRules("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 || len(rules) != 0 {
+		t.Fatalf("rules = %+v, wanted empty", rules)
+	}
+	if err := s.AddStreamRule(nil); err == nil {
+		t.Fatal("it should be failed to add nil")
+	}
+	rule := &graylog.StreamRule{StreamID: "foo", Type: 1, Field: "tag", Value: "test"}
+	if err := s.AddStreamRule(rule); err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == "" {
+		t.Fatal("stream rule id should be set")
+	}
+	if err := s.UpdateStreamRule(&graylog.StreamRuleUpdateParams{
+		StreamID: "foo", ID: rule.ID, Value: "changed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateStreamRule(&graylog.StreamRuleUpdateParams{
+		StreamID: "bar", ID: rule.ID,
+	}); err == nil {
+		t.Fatal("it should be failed to update a stream rule of a stream which doesn't have rules")
+	}
+	r, err := s.GetStreamRule("foo", rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Value != "changed" || r.Field != "tag" {
+		t.Fatalf("rule = %+v, wanted only the value changed", r)
+	}
+	if r, err := s.GetStreamRule("bar", rule.ID); err != nil || r != nil {
+		t.Fatalf("rule of another stream should be nil: %v, %v", r, err)
+	}
+	if _, total, err := s.GetStreamRules("foo"); err != nil || total != 1 {
+		t.Fatalf("total = %d, wanted 1: %v", total, err)
+	}
+	if err := s.DeleteStreamRule("foo", rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteStreamRule("bar", rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasStreamRule("foo", rule.ID); err != nil || ok {
+		t.Fatalf("stream rule should be deleted: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,67 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/mockserver/store"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+func testUser(t *testing.T, s store.Store) {
+	user, err := s.GetUser("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != nil {
+		t.Fatal("user foo should not exist")
+	}
+	if err := s.AddUser(nil); err == nil {
+		t.Fatal("it should be failed to add nil")
+	}
+	user = &graylog.User{
+		Username: "foo", Email: "foo@example.com", FullName: "foo",
+		Password: "password", Permissions: set.NewStrSet("*"),
+	}
+	if err := s.AddUser(user); err != nil {
+		t.Fatal(err)
+	}
+	if user.ID == "" {
+		t.Fatal("user id should be set")
+	}
+	email := "bar@example.com"
+	if err := s.UpdateUser(&graylog.UserUpdateParams{
+		Username: "foo", Email: &email,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	u, err := s.GetUser("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u == nil {
+		t.Fatal("user foo is not found")
+	}
+	if u.Email != email || u.FullName != "foo" || u.ID != user.ID {
+		t.Fatalf("user = %+v, wanted only the email to be updated", u)
+	}
+	if err := s.UpdateUser(&graylog.UserUpdateParams{Username: "bar"}); err == nil {
+		t.Fatal("it should be failed to update a user which doesn't exist")
+	}
+	users, err := s.GetUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 {
+		t.Fatalf("len(users) = %d, wanted 1", len(users))
+	}
+	if u, err := s.GetUserByAccessToken("foo"); err != nil || u != nil {
+		t.Fatalf("GetUserByAccessToken should return nil: %v, %v", u, err)
+	}
+	if err := s.DeleteUser("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.HasUser("foo"); err != nil || ok {
+		t.Fatalf("user foo should be deleted: %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,50 @@
+package graylog
+
+// Node represents a Graylog server node of the cluster.
+type Node struct {
+	// ex. "c0fe7b5a-3b3c-4a7e-8d3e-0d0ef1a3b8c4"
+	ClusterID string `json:"cluster_id,omitempty"`
+	// ex. "2ad6b340-3e5f-4a96-ae81-040cfb8b6024"
+	NodeID string `json:"node_id"`
+	// ex. "2ad6b340"
+	ShortNodeID string `json:"short_node_id,omitempty"`
+	// ex. "server"
+	Type string `json:"type,omitempty"`
+	// ex. "http://172.18.0.4:9000/api/"
+	TransportAddress string `json:"transport_address,omitempty"`
+	// ex. "2018-02-24T03:02:26.001Z"
+	LastSeen string `json:"last_seen,omitempty"`
+	// ex. "graylog-server-1"
+	Hostname string `json:"hostname,omitempty"`
+	IsMaster bool   `json:"is_master"`
+}
+
+// NodesBody represents Get Cluster Nodes API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type NodesBody struct {
+	Nodes []Node `json:"nodes"`
+	Total int    `json:"total"`
+}
+
+// SystemOverview represents the overview of the Graylog node which serves the API.
+type SystemOverview struct {
+	// ex. "graylog-server"
+	Facility string `json:"facility,omitempty"`
+	// ex. "Noir"
+	Codename  string `json:"codename,omitempty"`
+	NodeID    string `json:"node_id,omitempty"`
+	ClusterID string `json:"cluster_id,omitempty"`
+	// ex. "2.4.3+2c41897"
+	Version string `json:"version,omitempty"`
+	// ex. "2018-02-24T03:02:26.001Z"
+	StartedAt    string `json:"started_at,omitempty"`
+	IsProcessing bool   `json:"is_processing"`
+	Hostname     string `json:"hostname,omitempty"`
+	// ex. "running"
+	Lifecycle string `json:"lifecycle,omitempty"`
+	// ex. "alive"
+	LBStatus string `json:"lb_status,omitempty"`
+	// ex. "UTC"
+	Timezone        string `json:"timezone,omitempty"`
+	OperatingSystem string `json:"operating_system,omitempty"`
+}
@@ -0,0 +1,89 @@
+package graylog
+
+const (
+	// NotificationTypeDeflectorExistsAsIndex means an index exists with the deflector's name.
+	NotificationTypeDeflectorExistsAsIndex NotificationType = "deflector_exists_as_index"
+	// NotificationTypeMultiMaster means more than one node is configured as the master.
+	NotificationTypeMultiMaster NotificationType = "multi_master"
+	// NotificationTypeNoMaster means no node is configured as the master.
+	NotificationTypeNoMaster NotificationType = "no_master"
+	// NotificationTypeESOpenFiles means Elasticsearch nodes have too low open file limits.
+	NotificationTypeESOpenFiles NotificationType = "es_open_files"
+	// NotificationTypeESClusterRed means the Elasticsearch cluster is red.
+	NotificationTypeESClusterRed NotificationType = "es_cluster_red"
+	// NotificationTypeESUnavailable means Elasticsearch is unavailable.
+	NotificationTypeESUnavailable NotificationType = "es_unavailable"
+	// NotificationTypeNoInputRunning means no input is running.
+	NotificationTypeNoInputRunning NotificationType = "no_input_running"
+	// NotificationTypeInputFailedToStart means an input failed to start.
+	NotificationTypeInputFailedToStart NotificationType = "input_failed_to_start"
+	// NotificationTypeCheckServerClocks means the nodes' clocks may be out of sync.
+	NotificationTypeCheckServerClocks NotificationType = "check_server_clocks"
+	// NotificationTypeOutdatedVersion means a newer Graylog version is available.
+	NotificationTypeOutdatedVersion NotificationType = "outdated_version"
+	// NotificationTypeEmergencyIndexSet means messages were written to the emergency index set.
+	NotificationTypeEmergencyIndexSet NotificationType = "emergency_index_set"
+	// NotificationTypeESVersionMismatch means the Elasticsearch version is unexpected.
+	NotificationTypeESVersionMismatch NotificationType = "es_version_mismatch"
+	// NotificationTypeGeneric is a generic notification.
+	NotificationTypeGeneric NotificationType = "generic"
+	// NotificationTypeIndexRangesRecalculation means index ranges should be recalculated.
+	NotificationTypeIndexRangesRecalculation NotificationType = "index_ranges_recalculation"
+	// NotificationTypeJournalUtilizationTooHigh means the message journal is almost full.
+	NotificationTypeJournalUtilizationTooHigh NotificationType = "journal_utilization_too_high"
+	// NotificationTypeJournalUncommittedMessagesDeleted means uncommitted messages were deleted from the journal.
+	NotificationTypeJournalUncommittedMessagesDeleted NotificationType = "journal_uncommitted_messages_deleted"
+	// NotificationTypeOutputDisabled means an output was disabled.
+	NotificationTypeOutputDisabled NotificationType = "output_disabled"
+	// NotificationTypeOutputFailing means an output is failing.
+	NotificationTypeOutputFailing NotificationType = "output_failing"
+
+	// NotificationSeverityUrgent is the severity of a notification which requires immediate action.
+	NotificationSeverityUrgent NotificationSeverity = "urgent"
+	// NotificationSeverityNormal is the severity of a notification which doesn't require immediate action.
+	NotificationSeverityNormal NotificationSeverity = "normal"
+)
+
+// NotificationType represents a system notification's type.
+// Graylog keeps at most one notification per type.
+// ex. "no_master"
+type NotificationType string
+
+// NotificationSeverity represents a system notification's severity.
+// ex. "urgent"
+type NotificationSeverity string
+
+// NotificationTypes returns all notification types.
+func NotificationTypes() []NotificationType {
+	return []NotificationType{
+		NotificationTypeDeflectorExistsAsIndex, NotificationTypeMultiMaster,
+		NotificationTypeNoMaster, NotificationTypeESOpenFiles,
+		NotificationTypeESClusterRed, NotificationTypeESUnavailable,
+		NotificationTypeNoInputRunning, NotificationTypeInputFailedToStart,
+		NotificationTypeCheckServerClocks, NotificationTypeOutdatedVersion,
+		NotificationTypeEmergencyIndexSet, NotificationTypeESVersionMismatch,
+		NotificationTypeGeneric, NotificationTypeIndexRangesRecalculation,
+		NotificationTypeJournalUtilizationTooHigh,
+		NotificationTypeJournalUncommittedMessagesDeleted,
+		NotificationTypeOutputDisabled, NotificationTypeOutputFailing,
+	}
+}
+
+// Notification represents a system notification.
+type Notification struct {
+	Type     NotificationType     `json:"type"`
+	Severity NotificationSeverity `json:"severity"`
+	// ex. "2018-02-24T03:02:26.001Z"
+	Timestamp string `json:"timestamp,omitempty"`
+	// ex. "2ad6b340-3e5f-4a96-ae81-040cfb8b6024"
+	NodeID string `json:"node_id,omitempty"`
+	// the type specific information, for example the input which failed to start
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// NotificationsBody represents Get Notifications API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type NotificationsBody struct {
+	Notifications []Notification `json:"notifications"`
+	Total         int            `json:"total"`
+}
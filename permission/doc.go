@@ -0,0 +1,13 @@
+/*
+Package permission parses and matches Graylog's permission strings.
+
+Graylog uses Apache Shiro's WildcardPermission, so a permission string consists of parts divided by ":"
+and each part consists of subparts divided by ",".
+"*" matches any subpart, and a permission implies the permissions which have more parts.
+
+  // "streams:read" implies "streams:read:5b39fd6e0b2f0f0001d0e4b4"
+  ok, err := permission.Implies("streams:read,edit", "streams:edit:5b39fd6e0b2f0f0001d0e4b4")
+
+https://shiro.apache.org/permissions.html
+*/
+package permission
@@ -0,0 +1,128 @@
+package permission
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+const (
+	// Wildcard is the subpart which matches any subpart.
+	Wildcard = "*"
+	// PartDivider is the divider of the parts.
+	PartDivider = ":"
+	// SubpartDivider is the divider of the subparts.
+	SubpartDivider = ","
+)
+
+// Permission represents a parsed permission string.
+// As Graylog's CaseSensitiveWildcardPermission does, permissions are case sensitive,
+// so "Streams:read" doesn't imply "streams:read".
+type Permission struct {
+	parts []set.StrSet
+}
+
+// Parse parses a permission string.
+// An error is returned if the string is empty or has an empty part.
+func Parse(s string) (*Permission, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("permission string is empty")
+	}
+	perm := &Permission{}
+	for _, part := range strings.Split(s, PartDivider) {
+		subparts := set.NewStrSet()
+		for _, subpart := range strings.Split(part, SubpartDivider) {
+			if subpart = strings.TrimSpace(subpart); subpart != "" {
+				subparts.Add(subpart)
+			}
+		}
+		if len(subparts) == 0 {
+			return nil, fmt.Errorf(
+				"permission string must not contain empty parts: %s", s)
+		}
+		perm.parts = append(perm.parts, subparts)
+	}
+	return perm, nil
+}
+
+// MustParse is like Parse but panics if the string can't be parsed.
+func MustParse(s string) *Permission {
+	perm, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return perm
+}
+
+// Implies returns whether the permission implies another permission.
+// A permission implies another permission when each part is the wildcard or contains all subparts
+// of the other's corresponding part. The missing parts of the permission are treated as the wildcard,
+// so "streams" implies "streams:read:foo" but "streams:read:foo" doesn't imply "streams".
+func (perm *Permission) Implies(other *Permission) bool {
+	if perm == nil || other == nil {
+		return false
+	}
+	for i, otherPart := range other.parts {
+		if len(perm.parts) <= i {
+			return true
+		}
+		part := perm.parts[i]
+		if !part.Has(Wildcard) && !part.HasAll(otherPart.ToList()...) {
+			return false
+		}
+	}
+	for _, part := range perm.parts[len(other.parts):] {
+		if !part.Has(Wildcard) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the permission string.
+// The subparts are in no particular order.
+func (perm *Permission) String() string {
+	parts := make([]string, len(perm.parts))
+	for i, part := range perm.parts {
+		parts[i] = strings.Join(part.ToList(), SubpartDivider)
+	}
+	return strings.Join(parts, PartDivider)
+}
+
+// Implies returns whether a granted permission string implies a required permission string.
+func Implies(granted, required string) (bool, error) {
+	g, err := Parse(granted)
+	if err != nil {
+		return false, err
+	}
+	r, err := Parse(required)
+	if err != nil {
+		return false, err
+	}
+	return g.Implies(r), nil
+}
+
+// Permitted returns whether some of the granted permission strings imply a required permission string.
+// The invalid granted permission strings are ignored because they imply nothing.
+// An error is returned only if the required permission string is invalid.
+//
+//   // true
+//   ok, err := permission.Permitted([]string{"dashboards:read", "streams:*"}, "streams:edit:foo")
+func Permitted(granted []string, required string) (bool, error) {
+	r, err := Parse(required)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range granted {
+		g, err := Parse(s)
+		if err != nil {
+			continue
+		}
+		if g.Implies(r) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
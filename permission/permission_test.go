@@ -0,0 +1,93 @@
+package permission_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog/permission"
+)
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{"", " ", "streams::read", "streams:read:", ":read", "streams:,"} {
+		if _, err := permission.Parse(s); err == nil {
+			t.Fatalf(`permission.Parse("%s") should fail`, s)
+		}
+	}
+	perm, err := permission.Parse("Streams:read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the case is kept
+	if perm.String() != "Streams:read" {
+		t.Fatalf(`perm.String() == "%s", wanted "Streams:read"`, perm.String())
+	}
+}
+
+func TestImplies(t *testing.T) {
+	data := []struct {
+		granted  string
+		required string
+		exp      bool
+	}{
+		{"*", "streams:read:foo", true},
+		{"*", "streams", true},
+		{"streams", "streams:read:foo", true},
+		{"streams:*", "streams:read:foo", true},
+		{"streams:read", "streams:read:foo", true},
+		{"streams:read", "streams:edit:foo", false},
+		{"streams:read", "streams", false},
+		{"streams:read:foo", "streams:read", false},
+		{"streams:read:*", "streams:read", true},
+		{"streams:*:*", "streams", true},
+		{"streams:read,edit:foo,bar", "streams:edit:bar", true},
+		{"streams:read,edit:foo,bar", "streams:edit:baz", false},
+		{"streams:read,edit", "streams:read,edit:foo", true},
+		{"streams:read", "streams:read,edit:foo", false},
+		{"*:read", "streams:read:foo", true},
+		{"*:read", "streams:edit:foo", false},
+		{"streams:read,*", "streams:edit", true},
+		// permissions are case sensitive
+		{"STREAMS:READ", "streams:read", false},
+		{"Streams:read", "streams:read", false},
+		{"streams:read:Foo", "streams:read:foo", false},
+		{"streams:read:Foo", "streams:read:Foo", true},
+		{"dashboards:read", "streams:read", false},
+		{"users:edit:admin", "users:edit:admin", true},
+	}
+	for _, d := range data {
+		ok, err := permission.Implies(d.granted, d.required)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != d.exp {
+			t.Fatalf(`permission.Implies("%s", "%s") == %t, wanted %t`,
+				d.granted, d.required, ok, d.exp)
+		}
+	}
+	if _, err := permission.Implies("", "streams:read"); err == nil {
+		t.Fatal("the granted permission is invalid")
+	}
+	if _, err := permission.Implies("*", ""); err == nil {
+		t.Fatal("the required permission is invalid")
+	}
+}
+
+func TestPermitted(t *testing.T) {
+	ok, err := permission.Permitted(
+		[]string{"", "dashboards:read", "streams:*"}, "streams:edit:foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("streams:* implies streams:edit:foo")
+	}
+	ok, err = permission.Permitted([]string{"dashboards:read"}, "streams:edit:foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("dashboards:read doesn't imply streams:edit:foo")
+	}
+	if _, err := permission.Permitted(nil, ""); err == nil {
+		t.Fatal("the required permission is invalid")
+	}
+}
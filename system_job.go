@@ -0,0 +1,27 @@
+package graylog
+
+// SystemJob represents a long-running operation such as an index ranges rebuild.
+// A system job disappears from Graylog when it finishes.
+type SystemJob struct {
+	// ex. "2e3ad7d0-9bd1-11e8-8ec5-0242ac130004"
+	ID string `json:"id,omitempty"`
+	// ex. "org.graylog2.indexer.ranges.RebuildIndexRangesJob"
+	Name string `json:"name,omitempty"`
+	// ex. "Rebuilds index range information."
+	Description string `json:"description,omitempty"`
+	Info        string `json:"info,omitempty"`
+	// ex. "2ad6b340-3e5f-4a96-ae81-040cfb8b6024"
+	NodeID string `json:"node_id,omitempty"`
+	// ex. "2018-02-24T03:02:26.001Z"
+	StartedAt string `json:"started_at,omitempty"`
+	// ex. 50
+	PercentComplete  int  `json:"percent_complete"`
+	IsCancelable     bool `json:"is_cancelable"`
+	ProvidesProgress bool `json:"provides_progress"`
+}
+
+// SystemJobsBody represents Get System Jobs API's response body.
+// Basically users don't use this struct, but this struct is public because some sub packages use this struct.
+type SystemJobsBody struct {
+	Jobs []SystemJob `json:"jobs"`
+}
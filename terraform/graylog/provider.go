@@ -27,11 +27,13 @@ func Provider() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"graylog_role":      resourceRole(),
-			"graylog_user":      resourceUser(),
-			"graylog_input":     resourceInput(),
-			"graylog_index_set": resourceIndexSet(),
-			"graylog_stream":    resourceStream(),
+			"graylog_role":           resourceRole(),
+			"graylog_user":           resourceUser(),
+			"graylog_input":          resourceInput(),
+			"graylog_index_set":      resourceIndexSet(),
+			"graylog_stream":         resourceStream(),
+			"graylog_cluster_config": resourceClusterConfig(),
+			"graylog_ldap_settings":  resourceLDAPSettings(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
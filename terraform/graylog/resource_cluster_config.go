@@ -0,0 +1,137 @@
+package graylog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func resourceClusterConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceClusterConfigUpdate,
+		Read:   resourceClusterConfigRead,
+		Update: resourceClusterConfigUpdate,
+		Delete: resourceClusterConfigDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceClusterConfigImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"config_class": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// JSON string
+			"config": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressClusterConfigDiff,
+			},
+		},
+	}
+}
+
+// normalizeClusterConfig decodes a cluster configuration's JSON string
+// into the typed struct of the class and encodes it again,
+// so that the JSON strings which represent the same configuration are equal.
+func normalizeClusterConfig(class, cfg string) (string, error) {
+	c := graylog.NewClusterConfig(class)
+	if err := json.Unmarshal([]byte(cfg), c); err != nil {
+		return "", fmt.Errorf("failed to parse the cluster config %s: %s", class, err)
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func suppressClusterConfigDiff(k, old, new string, d *schema.ResourceData) bool {
+	class := d.Get("config_class").(string)
+	o, err := normalizeClusterConfig(class, old)
+	if err != nil {
+		return false
+	}
+	n, err := normalizeClusterConfig(class, new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+func newClusterConfig(d *schema.ResourceData) (graylog.ClusterConfig, error) {
+	class := d.Get("config_class").(string)
+	cfg := graylog.NewClusterConfig(class)
+	if err := json.Unmarshal([]byte(d.Get("config").(string)), cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the cluster config %s: %s", class, err)
+	}
+	return cfg, nil
+}
+
+func resourceClusterConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cl, err := client.NewClient(
+		config.Endpoint, config.AuthName, config.AuthPassword)
+	if err != nil {
+		return err
+	}
+	cfg, err := newClusterConfig(d)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.UpdateClusterConfig(cfg); err != nil {
+		return err
+	}
+	d.SetId(cfg.ConfigClass())
+	return nil
+}
+
+func resourceClusterConfigRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cl, err := client.NewClient(
+		config.Endpoint, config.AuthName, config.AuthPassword)
+	if err != nil {
+		return err
+	}
+	cfg, ei, err := cl.GetClusterConfig(d.Id())
+	if err != nil {
+		if ei != nil && ei.Response != nil && ei.Response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	setStrToRD(d, "config_class", cfg.ConfigClass())
+	setStrToRD(d, "config", string(b))
+	return nil
+}
+
+func resourceClusterConfigDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cl, err := client.NewClient(
+		config.Endpoint, config.AuthName, config.AuthPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.DeleteClusterConfig(d.Id()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceClusterConfigImport(
+	d *schema.ResourceData, m interface{},
+) ([]*schema.ResourceData, error) {
+	// the resource id is the config class
+	setStrToRD(d, "config_class", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,103 @@
+package graylog
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testDeleteClusterConfig(
+	cl *client.Client, class string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		if _, _, err := cl.GetClusterConfig(class); err == nil {
+			return fmt.Errorf(`cluster config "%s" must be deleted`, class)
+		}
+		return nil
+	}
+}
+
+func testUpdateClusterConfig(
+	cl *client.Client, limit string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		cfg, _, err := cl.GetClusterConfig(graylog.SearchesClusterConfigClass)
+		if err != nil {
+			return err
+		}
+		act := cfg.(*graylog.SearchesClusterConfig).QueryTimeRangeLimit
+		if act != limit {
+			return fmt.Errorf(
+				`query_time_range_limit = "%s", wanted "%s"`, act, limit)
+		}
+		return nil
+	}
+}
+
+func TestAccClusterConfig(t *testing.T) {
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	tf := `
+resource "graylog_cluster_config" "searches" {
+  config_class = "%s"
+  config = <<EOF
+{
+  "query_time_range_limit": "%s",
+  "relative_timerange_options": {"PT5M": "Search in the last 5 minutes"},
+  "surrounding_timerange_options": {"PT1S": "1 second"},
+  "surrounding_filter_fields": ["source"]
+}
+EOF
+}`
+	class := graylog.SearchesClusterConfigClass
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteClusterConfig(cl, class),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(tf, class, "P30D"),
+				Check: resource.ComposeTestCheckFunc(
+					testUpdateClusterConfig(cl, "P30D"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tf, class, "P1D"),
+				Check: resource.ComposeTestCheckFunc(
+					testUpdateClusterConfig(cl, "P1D"),
+				),
+			},
+			{
+				// the config deleted outside Terraform is created again
+				PreConfig: func() {
+					if _, err := cl.DeleteClusterConfig(class); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: fmt.Sprintf(tf, class, "P1D"),
+				Check: resource.ComposeTestCheckFunc(
+					testUpdateClusterConfig(cl, "P1D"),
+				),
+			},
+		},
+	})
+}
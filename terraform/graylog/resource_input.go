@@ -7,6 +7,7 @@ import (
 
 	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
 )
 
 func resourceInput() *schema.Resource {
@@ -35,6 +36,9 @@ func resourceInput() *schema.Resource {
 		Update: resourceInputUpdate,
 		Delete: resourceInputDelete,
 
+		// validate the attributes at the plan phase
+		CustomizeDiff: resourceInputCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -66,6 +70,7 @@ func resourceInput() *schema.Resource {
 			"node": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 
 			"created_at": {
@@ -78,19 +83,33 @@ func resourceInput() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"static_fields": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			// "context_pack": &schema.Schema{
 			// 	Type:     schema.TypeString,
 			// 	Optional: true,
 			// },
-			// "static_fields": &schema.Schema{
-			// 	Type:     schema.TypeString,
-			// 	Optional: true,
-			// },
 		},
 	}
 }
 
-func newInput(d *schema.ResourceData) (*graylog.Input, error) {
+// inputResourceData is an interface which both schema.ResourceData and schema.ResourceDiff implement.
+type inputResourceData interface {
+	Get(string) interface{}
+	Id() string
+}
+
+func newInput(d inputResourceData) (*graylog.Input, error) {
+	// at the plan phase the attributes may be unknown
+	attrs := map[string]interface{}{}
+	if list, ok := d.Get("attributes").([]interface{}); ok && len(list) != 0 {
+		if a, ok := list[0].(map[string]interface{}); ok {
+			attrs = a
+		}
+	}
 	data := &graylog.InputData{
 		Title:         d.Get("title").(string),
 		Type:          d.Get("type").(string),
@@ -99,7 +118,7 @@ func newInput(d *schema.ResourceData) (*graylog.Input, error) {
 		ID:            d.Id(),
 		CreatorUserID: d.Get("creator_user_id").(string),
 		CreatedAt:     d.Get("created_at").(string),
-		Attrs:         d.Get("attributes").([]interface{})[0].(map[string]interface{}),
+		Attrs:         attrs,
 	}
 	input := &graylog.Input{}
 	if err := data.ToInput(input); err != nil {
@@ -108,6 +127,33 @@ func newInput(d *schema.ResourceData) (*graylog.Input, error) {
 	return input, nil
 }
 
+func resourceInputCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	// if an attribute is unknown at the plan phase, for example
+	// bind_address = "${aws_instance.foo.private_ip}",
+	// the attributes aren't validated until the API validates them at the apply phase.
+	if hasUnknownValue(d, "type") || hasUnknownValue(d, "attributes") {
+		return nil
+	}
+	input, err := newInput(d)
+	if err != nil {
+		return err
+	}
+	return validator.ValidateInputAttrs(input.Attrs)
+}
+
+// hasUnknownValue returns whether a value whose key begins with a given prefix is unknown at the plan phase.
+// Terraform v0.11 reads an unknown value as the zero value,
+// so a key which is changed to the zero value is regarded as unknown.
+// A value which is removed is regarded as unknown too.
+func hasUnknownValue(d *schema.ResourceDiff, prefix string) bool {
+	for _, key := range d.GetChangedKeysPrefix(prefix) {
+		if _, ok := d.GetOk(key); !ok {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceInputCreate(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	cl, err := client.NewClient(
@@ -124,6 +170,12 @@ func resourceInputCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 	d.SetId(input.ID)
+	// static fields can't be set by the Create Input API
+	for k, v := range getStaticFields(d.Get("static_fields")) {
+		if _, err := cl.AddInputStaticField(input.ID, k, v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -158,6 +210,7 @@ func resourceInputRead(d *schema.ResourceData, m interface{}) error {
 	setStrToRD(d, "node", input.Node)
 	setStrToRD(d, "creator_user_id", input.CreatorUserID)
 	setStrToRD(d, "created_at", input.CreatedAt)
+	d.Set("static_fields", input.StaticFields)
 	return nil
 }
 
@@ -175,9 +228,44 @@ func resourceInputUpdate(d *schema.ResourceData, m interface{}) error {
 	if _, _, err := cl.UpdateInput(input.NewUpdateParams()); err != nil {
 		return err
 	}
+	if !d.HasChange("static_fields") {
+		return nil
+	}
+	// static fields can't be updated by the Update Input API
+	o, n := d.GetChange("static_fields")
+	oldFields := getStaticFields(o)
+	newFields := getStaticFields(n)
+	for k, v := range oldFields {
+		if nv, ok := newFields[k]; ok && nv == v {
+			continue
+		}
+		if _, err := cl.DeleteInputStaticField(input.ID, k); err != nil {
+			return err
+		}
+	}
+	for k, v := range newFields {
+		if ov, ok := oldFields[k]; ok && ov == v {
+			continue
+		}
+		if _, err := cl.AddInputStaticField(input.ID, k, v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func getStaticFields(src interface{}) map[string]string {
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	dest := make(map[string]string, len(m))
+	for k, v := range m {
+		dest[k] = v.(string)
+	}
+	return dest
+}
+
 func resourceInputDelete(d *schema.ResourceData, m interface{}) error {
 	config := m.(*Config)
 	cl, err := client.NewClient(
@@ -3,10 +3,15 @@ package graylog
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/hil/ast"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/suzuki-shunsuke/go-graylog"
 	"github.com/suzuki-shunsuke/go-graylog/client"
 )
 
@@ -14,6 +19,29 @@ var (
 	terraformTestInputID string
 )
 
+type pluginInputAttrs struct {
+	Host    string `json:"terraform_plugin_host,omitempty"`
+	Workers int    `json:"terraform_plugin_workers,omitempty"`
+	Debug   bool   `json:"terraform_plugin_debug,omitempty"`
+}
+
+const pluginInputType = "com.example.graylog.TerraformPluginInput"
+
+func (attrs *pluginInputAttrs) InputType() string {
+	return pluginInputType
+}
+
+func registerPluginInput(t *testing.T) {
+	if graylog.InputAttrsStrFieldSet.Has("terraform_plugin_host") {
+		return
+	}
+	if err := graylog.RegisterInputAttrs(pluginInputType, func() graylog.InputAttrs {
+		return &pluginInputAttrs{}
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func testDeleteInput(cl *client.Client, key string) resource.TestCheckFunc {
 	return func(tfState *terraform.State) error {
 		if _, _, err := cl.GetInput(terraformTestInputID); err == nil {
@@ -36,7 +64,7 @@ func testCreateInput(cl *client.Client, key string) resource.TestCheckFunc {
 	}
 }
 
-func testUpdateInput(cl *client.Client, key, title string) resource.TestCheckFunc {
+func testUpdateInput(cl *client.Client, key, title, env string) resource.TestCheckFunc {
 	return func(tfState *terraform.State) error {
 		id, err := getIDFromTfState(tfState, key)
 		if err != nil {
@@ -49,6 +77,9 @@ func testUpdateInput(cl *client.Client, key, title string) resource.TestCheckFun
 		if input.Title != title {
 			return fmt.Errorf("input.Title == %s, wanted %s", input.Title, title)
 		}
+		if input.StaticFields["env"] != env {
+			return fmt.Errorf(`input.StaticFields["env"] == %s, wanted %s`, input.StaticFields["env"], env)
+		}
 		return nil
 	}
 }
@@ -73,9 +104,12 @@ resource "graylog_input" "test" {
   type = "org.graylog2.inputs.syslog.udp.SyslogUDPInput"
   attributes = {
     bind_address = "0.0.0.0"
-    port = 514
+    port = %d
     recv_buffer_size = 262144
   }
+  static_fields = {
+    env = "%s"
+  }
 }`
 	createTitle := "terraform test input title"
 	updateTitle := "terraform test input title updated"
@@ -90,12 +124,150 @@ resource "graylog_input" "test" {
 		CheckDestroy: testDeleteInput(cl, key),
 		Steps: []resource.TestStep{
 			{
-				Config: fmt.Sprintf(roleTf, createTitle),
+				Config: fmt.Sprintf(roleTf, createTitle, 514, "test"),
 				Check:  testCreateInput(cl, key),
 			},
 			{
-				Config: fmt.Sprintf(roleTf, updateTitle),
-				Check:  testUpdateInput(cl, key, updateTitle),
+				Config: fmt.Sprintf(roleTf, updateTitle, 514, "production"),
+				Check:  testUpdateInput(cl, key, updateTitle, "production"),
+			},
+			{
+				Config:      fmt.Sprintf(roleTf, updateTitle, 70000, "production"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("port must be between 1 and 65535"),
+			},
+		},
+	})
+}
+
+func TestResourceInputCustomizeDiff(t *testing.T) {
+	data := []struct {
+		name  string
+		attrs map[string]interface{}
+		exp   string
+	}{
+		{"valid", map[string]interface{}{
+			"bind_address": "0.0.0.0", "port": 514, "recv_buffer_size": 262144}, ""},
+		{"required", map[string]interface{}{
+			"port": 514, "recv_buffer_size": 262144}, "bind_address is required"},
+		// the value is known at the apply phase
+		{"unknown", map[string]interface{}{
+			"bind_address": "${var.foo}", "port": 514, "recv_buffer_size": 262144}, ""},
+	}
+	for _, d := range data {
+		rc, err := config.NewRawConfig(map[string]interface{}{
+			"title":      "foo",
+			"type":       "org.graylog2.inputs.syslog.udp.SyslogUDPInput",
+			"attributes": []interface{}{d.attrs},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rc.Interpolate(map[string]ast.Variable{
+			"var.foo": {Value: config.UnknownVariableValue, Type: ast.TypeUnknown},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = resourceInput().Diff(nil, terraform.NewResourceConfig(rc), nil)
+		if d.exp == "" {
+			if err != nil {
+				t.Fatalf("%s: %v", d.name, err)
+			}
+			continue
+		}
+		if err == nil || !regexp.MustCompile(d.exp).MatchString(err.Error()) {
+			t.Fatalf("%s: err = %v, wanted %s", d.name, err, d.exp)
+		}
+	}
+}
+
+func TestResourceInputRegisteredType(t *testing.T) {
+	registerPluginInput(t)
+	// the schema is built after the input type is registered
+	r := resourceInput()
+	attrs := r.Schema["attributes"].Elem.(*schema.Resource).Schema
+	data := map[string]schema.ValueType{
+		"terraform_plugin_host":    schema.TypeString,
+		"terraform_plugin_workers": schema.TypeInt,
+		"terraform_plugin_debug":   schema.TypeBool,
+	}
+	for k, exp := range data {
+		s, ok := attrs[k]
+		if !ok {
+			t.Fatalf("the attribute %s is not in the schema", k)
+		}
+		if s.Type != exp {
+			t.Fatalf("the type of %s is %v, wanted %v", k, s.Type, exp)
+		}
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"title": "plugin",
+		"type":  pluginInputType,
+		"attributes": []interface{}{map[string]interface{}{
+			"terraform_plugin_host":    "example.com",
+			"terraform_plugin_workers": 2,
+			"terraform_plugin_debug":   true,
+		}},
+	})
+	input, err := newInput(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, ok := input.Attrs.(*pluginInputAttrs)
+	if !ok {
+		t.Fatalf("input.Attrs is %T, wanted *pluginInputAttrs", input.Attrs)
+	}
+	if *a != (pluginInputAttrs{Host: "example.com", Workers: 2, Debug: true}) {
+		t.Fatalf("input.Attrs == %+v", a)
+	}
+}
+
+func TestAccInputRegisteredType(t *testing.T) {
+	registerPluginInput(t)
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	tf := `
+resource "graylog_input" "plugin" {
+  title = "terraform test plugin input"
+  type = "%s"
+  attributes = {
+    terraform_plugin_host = "example.com"
+    terraform_plugin_workers = %d
+    terraform_plugin_debug = true
+  }
+}`
+	key := "graylog_input.plugin"
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteInput(cl, key),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(tf, pluginInputType, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testCreateInput(cl, key),
+					resource.TestCheckResourceAttr(key, "attributes.0.terraform_plugin_workers", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tf, pluginInputType, 4),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(key, "attributes.0.terraform_plugin_workers", "4"),
+				),
 			},
 		},
 	})
@@ -0,0 +1,195 @@
+package graylog
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+	"github.com/suzuki-shunsuke/go-set"
+)
+
+// ldapSettingsID is the resource id of the LDAP settings,
+// because Graylog has only one LDAP settings.
+const ldapSettingsID = "ldap_settings"
+
+func resourceLDAPSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLDAPSettingsUpdate,
+		Read:   resourceLDAPSettingsRead,
+		Update: resourceLDAPSettingsUpdate,
+		Delete: resourceLDAPSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required
+			"ldap_uri": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"search_base": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"search_pattern": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"display_name_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Optional
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"system_username": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// system_password is write only, so the change by others isn't detected.
+			"system_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"use_start_tls": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"trust_all_certificates": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"active_directory": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"default_group": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_mapping": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"group_search_base": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_id_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"additional_default_groups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"group_search_pattern": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed
+			"system_password_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func newLDAPSettings(d *schema.ResourceData) *graylog.LDAPSettings {
+	mapping := map[string]string{}
+	for k, v := range d.Get("group_mapping").(map[string]interface{}) {
+		mapping[k] = v.(string)
+	}
+	return &graylog.LDAPSettings{
+		Enabled:              d.Get("enabled").(bool),
+		SystemUsername:       d.Get("system_username").(string),
+		SystemPassword:       d.Get("system_password").(string),
+		LDAPURI:              d.Get("ldap_uri").(string),
+		UseStartTLS:          d.Get("use_start_tls").(bool),
+		TrustAllCertificates: d.Get("trust_all_certificates").(bool),
+		ActiveDirectory:      d.Get("active_directory").(bool),
+		SearchBase:           d.Get("search_base").(string),
+		SearchPattern:        d.Get("search_pattern").(string),
+		DisplayNameAttribute: d.Get("display_name_attribute").(string),
+		DefaultGroup:         d.Get("default_group").(string),
+		GroupMapping:         mapping,
+		GroupSearchBase:      d.Get("group_search_base").(string),
+		GroupIDAttribute:     d.Get("group_id_attribute").(string),
+		AdditionalDefaultGroups: set.NewStrSet(getStringArray(
+			d.Get("additional_default_groups").(*schema.Set).List())...),
+		GroupSearchPattern: d.Get("group_search_pattern").(string),
+	}
+}
+
+func resourceLDAPSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cl, err := client.NewClient(
+		config.Endpoint, config.AuthName, config.AuthPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.UpdateLDAPSettings(newLDAPSettings(d)); err != nil {
+		return err
+	}
+	d.SetId(ldapSettingsID)
+	return nil
+}
+
+func resourceLDAPSettingsRead(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cl, err := client.NewClient(
+		config.Endpoint, config.AuthName, config.AuthPassword)
+	if err != nil {
+		return err
+	}
+	settings, _, err := cl.GetLDAPSettings()
+	if err != nil {
+		return err
+	}
+	if settings.LDAPURI == "" {
+		// LDAP isn't configured
+		d.SetId("")
+		return nil
+	}
+	setBoolToRD(d, "enabled", settings.Enabled)
+	setStrToRD(d, "system_username", settings.SystemUsername)
+	setStrToRD(d, "ldap_uri", settings.LDAPURI)
+	setBoolToRD(d, "use_start_tls", settings.UseStartTLS)
+	setBoolToRD(d, "trust_all_certificates", settings.TrustAllCertificates)
+	setBoolToRD(d, "active_directory", settings.ActiveDirectory)
+	setStrToRD(d, "search_base", settings.SearchBase)
+	setStrToRD(d, "search_pattern", settings.SearchPattern)
+	setStrToRD(d, "display_name_attribute", settings.DisplayNameAttribute)
+	setStrToRD(d, "default_group", settings.DefaultGroup)
+	d.Set("group_mapping", settings.GroupMapping)
+	setStrToRD(d, "group_search_base", settings.GroupSearchBase)
+	setStrToRD(d, "group_id_attribute", settings.GroupIDAttribute)
+	setStrListToRD(d, "additional_default_groups", settings.AdditionalDefaultGroups.ToList())
+	setStrToRD(d, "group_search_pattern", settings.GroupSearchPattern)
+	setBoolToRD(d, "system_password_set", settings.SystemPasswordSet)
+	return nil
+}
+
+func resourceLDAPSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	config := m.(*Config)
+	cl, err := client.NewClient(
+		config.Endpoint, config.AuthName, config.AuthPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := cl.DeleteLDAPSettings(); err != nil {
+		return err
+	}
+	return nil
+}
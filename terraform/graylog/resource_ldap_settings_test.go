@@ -0,0 +1,100 @@
+package graylog
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/suzuki-shunsuke/go-graylog/client"
+)
+
+func testDeleteLDAPSettings(cl *client.Client) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		settings, _, err := cl.GetLDAPSettings()
+		if err != nil {
+			return err
+		}
+		if settings.LDAPURI != "" {
+			return fmt.Errorf("ldap settings must be deleted")
+		}
+		return nil
+	}
+}
+
+func testUpdateLDAPSettings(
+	cl *client.Client, searchBase string,
+) resource.TestCheckFunc {
+	return func(tfState *terraform.State) error {
+		settings, _, err := cl.GetLDAPSettings()
+		if err != nil {
+			return err
+		}
+		if settings.SearchBase != searchBase {
+			return fmt.Errorf(
+				`search_base = "%s", wanted "%s"`, settings.SearchBase, searchBase)
+		}
+		if !settings.SystemPasswordSet {
+			return fmt.Errorf("system password must be set")
+		}
+		if settings.SystemPassword != "" {
+			return fmt.Errorf("system password must not be returned")
+		}
+		if settings.GroupMapping["admins"] != "Admin" {
+			return fmt.Errorf("group_mapping = %v", settings.GroupMapping)
+		}
+		return nil
+	}
+}
+
+func TestAccLDAPSettings(t *testing.T) {
+	cl, server, err := setEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server != nil {
+		defer os.Unsetenv("GRAYLOG_WEB_ENDPOINT_URI")
+	}
+
+	testAccProvider := Provider()
+	testAccProviders := map[string]terraform.ResourceProvider{
+		"graylog": testAccProvider,
+	}
+
+	tf := `
+resource "graylog_ldap_settings" "ldap" {
+  ldap_uri = "ldap://localhost:389"
+  system_username = "cn=admin,dc=example,dc=com"
+  system_password = "admin"
+  search_base = "%s"
+  search_pattern = "(&(objectClass=inetOrgPerson)(uid={0}))"
+  display_name_attribute = "cn"
+  default_group = "Admin"
+  group_mapping = {
+    admins = "Admin"
+  }
+}`
+	if server != nil {
+		server.Start()
+		defer server.Close()
+	}
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testDeleteLDAPSettings(cl),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(tf, "cn=users,dc=example,dc=com"),
+				Check: resource.ComposeTestCheckFunc(
+					testUpdateLDAPSettings(cl, "cn=users,dc=example,dc=com"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tf, "ou=people,dc=example,dc=com"),
+				Check: resource.ComposeTestCheckFunc(
+					testUpdateLDAPSettings(cl, "ou=people,dc=example,dc=com"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,241 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+)
+
+// InputAttrsRule is a validation rule of InputAttrs.
+// If attrs is invalid, an error is returned.
+type InputAttrsRule func(attrs graylog.InputAttrs) error
+
+var (
+	inputAttrsRules = map[string][]InputAttrsRule{}
+	tlsClientAuths  = map[string]bool{
+		"": true, "disabled": true, "optional": true, "required": true}
+)
+
+func init() {
+	network := []InputAttrsRule{
+		RequiredRule("bind_address", "port", "recv_buffer_size"),
+		PortRule("port"),
+		RangeRule("recv_buffer_size", 1, math.MaxInt32),
+	}
+	tcp := []InputAttrsRule{network[0], network[1], network[2], TLSRule}
+	amqp := []InputAttrsRule{
+		RequiredRule("broker_hostname", "queue", "exchange", "routing_key"),
+		PortRule("broker_port"),
+		RangeRule("prefetch", 0, math.MaxInt32),
+		RangeRule("parallel_queues", 0, math.MaxInt32),
+		RangeRule("heartbeat", 0, math.MaxInt32),
+	}
+	kafka := []InputAttrsRule{
+		RequiredRule("zookeeper", "topic_filter"),
+		RangeRule("threads", 0, math.MaxInt32),
+		RangeRule("fetch_wait_max", 0, math.MaxInt32),
+		RangeRule("fetch_min_bytes", 0, math.MaxInt32),
+	}
+	for _, t := range []string{
+		graylog.InputTypeCEFUDP, graylog.InputTypeGELFUDP,
+		graylog.InputTypeNetFlowUDP, graylog.InputTypeSyslogUDP,
+	} {
+		SetInputAttrsRules(t, network...)
+	}
+	for _, t := range []string{
+		graylog.InputTypeBeats, graylog.InputTypeCEFTCP, graylog.InputTypeGELFHTTP,
+		graylog.InputTypeGELFTCP, graylog.InputTypeSyslogTCP,
+	} {
+		SetInputAttrsRules(t, tcp...)
+	}
+	for _, t := range []string{
+		graylog.InputTypeCEFAMQP, graylog.InputTypeGELFAMQP,
+		graylog.InputTypeRawAMQP, graylog.InputTypeSyslogAMQP,
+	} {
+		SetInputAttrsRules(t, amqp...)
+	}
+	for _, t := range []string{
+		graylog.InputTypeCEFKafka, graylog.InputTypeGELFKafka,
+		graylog.InputTypeSyslogKafka,
+	} {
+		SetInputAttrsRules(t, kafka...)
+	}
+	SetInputAttrsRules(
+		graylog.InputTypeAWSCloudWatchLogs,
+		RequiredRule("aws_region", "kinesis_stream_name"))
+	SetInputAttrsRules(
+		graylog.InputTypeAWSFlowLogs,
+		RequiredRule("aws_region", "kinesis_stream_name"))
+	SetInputAttrsRules(
+		graylog.InputTypeAWSCloudTrail,
+		RequiredRule("aws_sqs_region", "aws_s3_region", "aws_sqs_queue_name"))
+	SetInputAttrsRules(
+		graylog.InputTypeJSONPath,
+		RequiredRule("target_url", "path", "interval", "timeunit"),
+		RangeRule("interval", 1, math.MaxInt32))
+	SetInputAttrsRules(
+		graylog.InputTypeFakeHTTPMessage,
+		RangeRule("sleep", 0, math.MaxInt32),
+		RangeRule("sleep_deviation", 0, math.MaxInt32))
+}
+
+// SetInputAttrsRules sets the validation rules of a given input type.
+// The existing rules of the input type are overwritten.
+// If no rule is given, the input type's rules are removed.
+//
+//   validator.SetInputAttrsRules(
+//   	"com.example.graylog.MyInput",
+//   	validator.RequiredRule("bind_address", "port"), validator.PortRule("port"))
+func SetInputAttrsRules(inputType string, rules ...InputAttrsRule) {
+	if len(rules) == 0 {
+		delete(inputAttrsRules, inputType)
+		return
+	}
+	inputAttrsRules[inputType] = rules
+}
+
+// GetInputAttrsRules returns the validation rules of a given input type.
+func GetInputAttrsRules(inputType string) []InputAttrsRule {
+	return inputAttrsRules[inputType]
+}
+
+// ValidateInputAttrs validates InputAttrs with the rules of its input type.
+// An input type which has no rule, for example an unknown input type, is not validated.
+func ValidateInputAttrs(attrs graylog.InputAttrs) error {
+	if attrs == nil {
+		return fmt.Errorf("input attributes are required")
+	}
+	for _, rule := range inputAttrsRules[attrs.InputType()] {
+		if err := rule(attrs); err != nil {
+			return fmt.Errorf("invalid attributes of %s: %s", attrs.InputType(), err)
+		}
+	}
+	return nil
+}
+
+// RequiredRule returns a rule that given fields must not be zero values.
+// A field is specified by its json tag name, and a field the attrs doesn't have is ignored.
+func RequiredRule(fields ...string) InputAttrsRule {
+	return func(attrs graylog.InputAttrs) error {
+		for _, field := range fields {
+			v, ok := getAttrsField(attrs, field)
+			if !ok {
+				continue
+			}
+			if v.Interface() == reflect.Zero(v.Type()).Interface() {
+				return fmt.Errorf("%s is required", field)
+			}
+		}
+		return nil
+	}
+}
+
+// RangeRule returns a rule that a given int field must be between min and max.
+// A zero value means the field isn't set, so it is ignored.
+// To reject a zero value, use RequiredRule together.
+func RangeRule(field string, min, max int) InputAttrsRule {
+	return func(attrs graylog.InputAttrs) error {
+		v, ok := getAttrsField(attrs, field)
+		if !ok {
+			return nil
+		}
+		if v.Kind() != reflect.Int {
+			return nil
+		}
+		i := int(v.Int())
+		if i == 0 {
+			return nil
+		}
+		if i < min || i > max {
+			return fmt.Errorf(
+				"%s must be between %d and %d: %d", field, min, max, i)
+		}
+		return nil
+	}
+}
+
+// PortRule returns a rule that a given field must be a valid port number.
+func PortRule(field string) InputAttrsRule {
+	return RangeRule(field, 1, 65535)
+}
+
+// TLSRule is a rule that the TLS settings must be consistent.
+// If tls_enable is true, tls_cert_file and tls_key_file are required.
+// If tls_client_auth is "optional" or "required",
+// tls_enable must be true and tls_client_auth_cert_file is required.
+func TLSRule(attrs graylog.InputAttrs) error {
+	enable, _ := getAttrsBoolField(attrs, "tls_enable")
+	clientAuth, _ := getAttrsStrField(attrs, "tls_client_auth")
+	if !tlsClientAuths[clientAuth] {
+		return fmt.Errorf(
+			`tls_client_auth must be any of "disabled", "optional" and "required": %s`,
+			clientAuth)
+	}
+	clientAuthEnabled := clientAuth == "optional" || clientAuth == "required"
+	if !enable {
+		if clientAuthEnabled {
+			return fmt.Errorf(
+				"tls_enable must be true if tls_client_auth is %s", clientAuth)
+		}
+		return nil
+	}
+	for _, field := range []string{"tls_cert_file", "tls_key_file"} {
+		if s, ok := getAttrsStrField(attrs, field); ok && s == "" {
+			return fmt.Errorf("%s is required if tls_enable is true", field)
+		}
+	}
+	if clientAuthEnabled {
+		if s, ok := getAttrsStrField(attrs, "tls_client_auth_cert_file"); ok && s == "" {
+			return fmt.Errorf(
+				"tls_client_auth_cert_file is required if tls_client_auth is %s",
+				clientAuth)
+		}
+	}
+	return nil
+}
+
+// getAttrsField returns the value of the attrs' field whose json tag name is a given name.
+func getAttrsField(attrs graylog.InputAttrs, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(attrs)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return v, false
+	}
+	t := v.Type()
+	n := t.NumField()
+	for i := 0; i < n; i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return v.Field(i), true
+		}
+	}
+	return v, false
+}
+
+func getAttrsStrField(attrs graylog.InputAttrs, name string) (string, bool) {
+	v, ok := getAttrsField(attrs, name)
+	if !ok || v.Kind() != reflect.String {
+		return "", false
+	}
+	return v.String(), true
+}
+
+func getAttrsBoolField(attrs graylog.InputAttrs, name string) (bool, bool) {
+	v, ok := getAttrsField(attrs, name)
+	if !ok || v.Kind() != reflect.Bool {
+		return false, false
+	}
+	return v.Bool(), true
+}
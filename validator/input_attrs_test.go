@@ -0,0 +1,63 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/go-graylog"
+	"github.com/suzuki-shunsuke/go-graylog/validator"
+)
+
+func TestValidateInputAttrs(t *testing.T) {
+	if err := validator.ValidateInputAttrs(nil); err == nil {
+		t.Fatal("attrs is required")
+	}
+	data := []struct {
+		attrs graylog.InputAttrs
+		valid bool
+	}{
+		{&graylog.InputSyslogUDPAttrs{
+			BindAddress: "0.0.0.0", Port: 514, RecvBufferSize: 262144}, true},
+		{&graylog.InputSyslogUDPAttrs{
+			Port: 514, RecvBufferSize: 262144}, false},
+		{&graylog.InputSyslogUDPAttrs{
+			BindAddress: "0.0.0.0", Port: 70000, RecvBufferSize: 262144}, false},
+		{&graylog.InputBeatsAttrs{
+			BindAddress: "0.0.0.0", Port: 5044, RecvBufferSize: 1048576,
+			TLSEnable: true}, false},
+		{&graylog.InputBeatsAttrs{
+			BindAddress: "0.0.0.0", Port: 5044, RecvBufferSize: 1048576,
+			TLSEnable: true, TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"}, true},
+		{&graylog.InputBeatsAttrs{
+			BindAddress: "0.0.0.0", Port: 5044, RecvBufferSize: 1048576,
+			TLSClientAuth: "required"}, false},
+		{&graylog.InputBeatsAttrs{
+			BindAddress: "0.0.0.0", Port: 5044, RecvBufferSize: 1048576,
+			TLSClientAuth: "foo"}, false},
+		{&graylog.InputUnknownAttrs{}, true},
+	}
+	for _, d := range data {
+		err := validator.ValidateInputAttrs(d.attrs)
+		if d.valid && err != nil {
+			t.Fatalf("%s: %v", d.attrs.InputType(), err)
+		}
+		if !d.valid && err == nil {
+			t.Fatalf("%s: %+v should be invalid", d.attrs.InputType(), d.attrs)
+		}
+	}
+}
+
+func TestSetInputAttrsRules(t *testing.T) {
+	inputType := "com.example.graylog.TestInput"
+	if rules := validator.GetInputAttrsRules(inputType); len(rules) != 0 {
+		t.Fatalf("len(rules) == %d, wanted 0", len(rules))
+	}
+	validator.SetInputAttrsRules(inputType, validator.RequiredRule("title"))
+	defer validator.SetInputAttrsRules(inputType)
+	if rules := validator.GetInputAttrsRules(inputType); len(rules) != 1 {
+		t.Fatalf("len(rules) == %d, wanted 1", len(rules))
+	}
+	validator.SetInputAttrsRules(inputType)
+	if rules := validator.GetInputAttrsRules(inputType); len(rules) != 0 {
+		t.Fatalf("len(rules) == %d, wanted 0", len(rules))
+	}
+}